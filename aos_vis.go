@@ -24,6 +24,7 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/aosedge/aos_common/aoserrors"
 	"github.com/coreos/go-systemd/daemon"
@@ -31,11 +32,18 @@ import (
 	log "github.com/sirupsen/logrus"
 
 	"github.com/aosedge/aos_vis/config"
+	"github.com/aosedge/aos_vis/metrics"
 	"github.com/aosedge/aos_vis/permissionprovider"
 	_ "github.com/aosedge/aos_vis/plugins"
 	"github.com/aosedge/aos_vis/visserver"
 )
 
+/*******************************************************************************
+ * Consts
+ ******************************************************************************/
+
+const configWatchPeriod = 1 * time.Second
+
 /*******************************************************************************
  * Types
  ******************************************************************************/
@@ -110,6 +118,54 @@ func (hook *journalHook) Levels() []log.Level {
 	}
 }
 
+/*******************************************************************************
+ * Private
+ ******************************************************************************/
+
+func handleSignal(server *visserver.Server, configFile string, sig os.Signal) (err error) {
+	switch sig {
+	case syscall.SIGHUP:
+		return reloadConfig(server, configFile)
+
+	case syscall.SIGUSR1:
+		return aoserrors.Wrap(server.StartRecording())
+
+	default:
+		return aoserrors.Wrap(server.StopRecording())
+	}
+}
+
+func reloadConfig(server *visserver.Server, configFile string) (err error) {
+	log.WithField("configFile", configFile).Info("Reload config")
+
+	config, err := config.New(configFile)
+	if err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	return aoserrors.Wrap(server.Reload(config))
+}
+
+// watchConfig requests reload by sending SIGHUP when config file modification time is changed.
+func watchConfig(configFile string, c chan<- os.Signal) {
+	var modTime time.Time
+
+	if info, err := os.Stat(configFile); err == nil {
+		modTime = info.ModTime()
+	}
+
+	for range time.Tick(configWatchPeriod) {
+		info, err := os.Stat(configFile)
+		if err != nil || info.ModTime().Equal(modTime) {
+			continue
+		}
+
+		modTime = info.ModTime()
+
+		c <- syscall.SIGHUP
+	}
+}
+
 /*******************************************************************************
  * Main
  ******************************************************************************/
@@ -120,6 +176,7 @@ func main() {
 	strLogLevel := flag.String("v", "info", `log level: "debug", "info", "warn", "error", "fatal", "panic"`)
 	showVersion := flag.Bool("version", false, `show VIS version`)
 	useJournal := flag.Bool("j", false, "output logs to systemd journal")
+	watchConfigFile := flag.Bool("w", false, "reload adapters on config file change")
 
 	flag.Parse()
 
@@ -153,25 +210,49 @@ func main() {
 		log.Fatalf("Can' open config file: %s", err)
 	}
 
-	permissionsProvider, err := permissionprovider.New(config, false)
+	permissionsProvider, err := permissionprovider.NewProvider(config, false)
 	if err != nil {
 		log.Fatalf("Can't create permission provider: %s", err)
 	}
 
+	visserver.Version = GitSummary
+
 	server, err := visserver.New(config, permissionsProvider)
 	if err != nil {
 		log.Fatalf("Can't create ws server: %s", err)
 	}
 
+	var metricsServer *metrics.Server
+
+	if config.MetricsServerURL != "" {
+		if metricsServer, err = metrics.New(config.MetricsServerURL, server); err != nil {
+			log.Fatalf("Can't create metrics server: %s", err)
+		}
+	}
+
 	// Notify systemd
 	if _, err = daemon.SdNotify(false, daemon.SdNotifyReady); err != nil {
 		log.Errorf("Can't notify systemd: %s", err)
 	}
 
-	// handle SIGTERM
+	// handle SIGTERM, SIGHUP reloads config, SIGUSR1 and SIGUSR2 start and stop recording
 	c := make(chan os.Signal, 2) //nolint:gomnd
-	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
-	<-c
+	signal.Notify(c, os.Interrupt, syscall.SIGTERM, syscall.SIGHUP, syscall.SIGUSR1, syscall.SIGUSR2)
+
+	if *watchConfigFile {
+		go watchConfig(*configFile, c)
+	}
+
+	for sig := <-c; sig != os.Interrupt && sig != syscall.SIGTERM; sig = <-c {
+		if err = handleSignal(server, *configFile, sig); err != nil {
+			log.Errorf("Can't handle %s signal: %s", sig, err)
+		}
+	}
+
+	if metricsServer != nil {
+		metricsServer.Close()
+	}
+
 	server.Close()
 
 	permissionsProvider.Close()
@@ -0,0 +1,286 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2021 Renesas Electronics Corporation.
+// Copyright (C) 2021 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package audit writes audit trail of set and authorize operations.
+package audit
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/aosedge/aos_common/aoserrors"
+	"github.com/coreos/go-systemd/journal"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/aosedge/aos_vis/config"
+	"github.com/aosedge/aos_vis/dataprovider"
+)
+
+/*******************************************************************************
+ * Consts
+ ******************************************************************************/
+
+const (
+	defaultMaxFileSize = 10 * 1024 * 1024
+	defaultMaxFiles    = 5
+	defaultIdentifier  = "aos_vis_audit"
+)
+
+// Audited actions.
+const (
+	ActionSet       = "set"
+	ActionAuthorize = "authorize"
+)
+
+// Action results.
+const (
+	ResultSuccess = "success"
+	ResultFailure = "failure"
+)
+
+/*******************************************************************************
+ * Types
+ ******************************************************************************/
+
+// Record audit record.
+type Record struct {
+	Timestamp   time.Time                    `json:"timestamp"`
+	Action      string                       `json:"action"`
+	Protocol    string                       `json:"protocol"`
+	RemoteAddr  string                       `json:"remoteAddr"`
+	Identity    *dataprovider.ClientIdentity `json:"identity,omitempty"`
+	Path        string                       `json:"path,omitempty"`
+	Value       interface{}                  `json:"value,omitempty"`
+	Changes     []dataprovider.DataChange    `json:"changes,omitempty"`
+	Result      string                       `json:"result"`
+	ErrorNumber int                          `json:"errorNumber,omitempty"`
+	Error       string                       `json:"error,omitempty"`
+}
+
+// Logger writes audit records.
+type Logger struct {
+	sync.Mutex
+	config   config.AuditConfig
+	file     *os.File
+	fileSize int64
+}
+
+/*******************************************************************************
+ * Public
+ ******************************************************************************/
+
+// New creates audit logger. Nil logger is returned if audit is disabled.
+func New(cfg config.AuditConfig) (logger *Logger, err error) {
+	if cfg.File == "" && !cfg.Journal {
+		return nil, nil
+	}
+
+	if cfg.MaxFileSize <= 0 {
+		cfg.MaxFileSize = defaultMaxFileSize
+	}
+
+	if cfg.MaxFiles <= 0 {
+		cfg.MaxFiles = defaultMaxFiles
+	}
+
+	if cfg.Identifier == "" {
+		cfg.Identifier = defaultIdentifier
+	}
+
+	logger = &Logger{config: cfg}
+
+	if cfg.File != "" {
+		if err = os.MkdirAll(filepath.Dir(cfg.File), 0o755); err != nil {
+			return nil, aoserrors.Wrap(err)
+		}
+
+		if err = logger.openFile(); err != nil {
+			return nil, err
+		}
+	}
+
+	return logger, nil
+}
+
+// Close closes audit logger.
+func (logger *Logger) Close() {
+	if logger == nil {
+		return
+	}
+
+	logger.Lock()
+	defer logger.Unlock()
+
+	if logger.file != nil {
+		if err := logger.closeFile(); err != nil {
+			log.Errorf("Can't close audit file: %s", err)
+		}
+	}
+}
+
+// Log writes audit record. Nil logger ignores records.
+func (logger *Logger) Log(record Record) {
+	if logger == nil {
+		return
+	}
+
+	if record.Timestamp.IsZero() {
+		record.Timestamp = time.Now().UTC()
+	}
+
+	line, err := json.Marshal(record)
+	if err != nil {
+		log.Errorf("Can't marshal audit record: %s", err)
+
+		return
+	}
+
+	logger.Lock()
+	defer logger.Unlock()
+
+	if logger.config.File != "" {
+		if err = logger.write(append(line, '\n')); err != nil {
+			log.Errorf("Can't write audit record: %s", err)
+		}
+	}
+
+	if logger.config.Journal {
+		if err = logger.sendJournal(record, line); err != nil {
+			log.Errorf("Can't send audit record to journal: %s", err)
+		}
+	}
+}
+
+/*******************************************************************************
+ * Private
+ ******************************************************************************/
+
+func (logger *Logger) write(line []byte) (err error) {
+	if logger.file == nil {
+		if err = logger.openFile(); err != nil {
+			return err
+		}
+	}
+
+	if logger.fileSize > 0 && logger.fileSize+int64(len(line)) > logger.config.MaxFileSize {
+		if err = logger.rotate(); err != nil {
+			return err
+		}
+	}
+
+	if _, err = logger.file.Write(line); err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	logger.fileSize += int64(len(line))
+
+	return nil
+}
+
+// openFile opens audit file in append only mode.
+func (logger *Logger) openFile() (err error) {
+	if logger.file, err = os.OpenFile(
+		logger.config.File, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600); err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	info, err := logger.file.Stat()
+	if err != nil {
+		logger.file.Close()
+		logger.file = nil
+
+		return aoserrors.Wrap(err)
+	}
+
+	logger.fileSize = info.Size()
+
+	return nil
+}
+
+func (logger *Logger) closeFile() (err error) {
+	file := logger.file
+
+	logger.file = nil
+
+	if err = file.Sync(); err != nil {
+		file.Close()
+
+		return aoserrors.Wrap(err)
+	}
+
+	return aoserrors.Wrap(file.Close())
+}
+
+// rotate renames audit files: file.1 is the latest rotated one, files above MaxFiles are removed.
+func (logger *Logger) rotate() (err error) {
+	if err = logger.closeFile(); err != nil {
+		return err
+	}
+
+	if err = os.Remove(logger.rotatedFileName(logger.config.MaxFiles)); err != nil && !os.IsNotExist(err) {
+		return aoserrors.Wrap(err)
+	}
+
+	for i := logger.config.MaxFiles - 1; i >= 0; i-- {
+		if err = os.Rename(logger.rotatedFileName(i), logger.rotatedFileName(i+1)); err != nil &&
+			!os.IsNotExist(err) {
+			return aoserrors.Wrap(err)
+		}
+	}
+
+	log.WithField("file", logger.config.File).Debug("Audit file rotated")
+
+	return logger.openFile()
+}
+
+func (logger *Logger) rotatedFileName(index int) (fileName string) {
+	if index == 0 {
+		return logger.config.File
+	}
+
+	return logger.config.File + "." + strconv.Itoa(index)
+}
+
+func (logger *Logger) sendJournal(record Record, line []byte) (err error) {
+	vars := map[string]string{
+		"SYSLOG_IDENTIFIER": logger.config.Identifier,
+		"AUDIT_ACTION":      record.Action,
+		"AUDIT_RESULT":      record.Result,
+		"AUDIT_REMOTE_ADDR": record.RemoteAddr,
+	}
+
+	if record.Path != "" {
+		vars["AUDIT_PATH"] = record.Path
+	}
+
+	if record.Identity != nil {
+		vars["AUDIT_SERVICE_ID"] = record.Identity.ServiceID
+		vars["AUDIT_SUBJECT_ID"] = record.Identity.SubjectID
+	}
+
+	priority := journal.PriNotice
+
+	if record.Result != ResultSuccess {
+		priority = journal.PriWarning
+	}
+
+	return aoserrors.Wrap(journal.Send(string(line), priority, vars))
+}
@@ -0,0 +1,206 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2021 Renesas Electronics Corporation.
+// Copyright (C) 2021 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aosedge/aos_common/aoserrors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/aosedge/aos_vis/audit"
+	"github.com/aosedge/aos_vis/config"
+	"github.com/aosedge/aos_vis/dataprovider"
+)
+
+/*******************************************************************************
+ * Init
+ ******************************************************************************/
+
+func init() {
+	log.SetFormatter(&log.TextFormatter{
+		DisableTimestamp: false,
+		TimestampFormat:  "2006-01-02 15:04:05.000",
+		FullTimestamp:    true,
+	})
+	log.SetLevel(log.DebugLevel)
+	log.SetOutput(os.Stdout)
+}
+
+/*******************************************************************************
+ * Tests
+ ******************************************************************************/
+
+func TestDisabled(t *testing.T) {
+	logger, err := audit.New(config.AuditConfig{})
+	if err != nil {
+		t.Fatalf("Can't create audit logger: %s", err)
+	}
+
+	if logger != nil {
+		t.Error("Audit logger should be disabled")
+	}
+
+	logger.Log(audit.Record{Action: audit.ActionSet})
+	logger.Close()
+}
+
+func TestLog(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "audit", "audit.log")
+
+	logger, err := audit.New(config.AuditConfig{File: fileName})
+	if err != nil {
+		t.Fatalf("Can't create audit logger: %s", err)
+	}
+
+	identity := &dataprovider.ClientIdentity{ServiceID: "service1", SubjectID: "subject1", Instance: 1}
+
+	logger.Log(audit.Record{
+		Action: audit.ActionAuthorize, Protocol: "ws", RemoteAddr: "127.0.0.1:1000", Identity: identity,
+		Result: audit.ResultSuccess,
+	})
+	logger.Log(audit.Record{
+		Action: audit.ActionSet, Protocol: "ws", RemoteAddr: "127.0.0.1:1000", Identity: identity,
+		Path: "Signal.Door.Locked", Value: true,
+		Changes: []dataprovider.DataChange{{Path: "Signal.Door.Locked", OldValue: false, NewValue: true}},
+		Result:  audit.ResultSuccess,
+	})
+	logger.Log(audit.Record{
+		Action: audit.ActionSet, Protocol: "rest", RemoteAddr: "127.0.0.1:2000", Path: "Signal.Door.Locked",
+		Value: false, Result: audit.ResultFailure, ErrorNumber: 403, Error: "client does not have permissions",
+	})
+
+	logger.Close()
+
+	records, err := readRecords(fileName)
+	if err != nil {
+		t.Fatalf("Can't read audit records: %s", err)
+	}
+
+	if len(records) != 3 {
+		t.Fatalf("Wrong records count: %d", len(records))
+	}
+
+	for _, record := range records {
+		if record.Timestamp.IsZero() {
+			t.Error("Record timestamp is not set")
+		}
+	}
+
+	if records[0].Action != audit.ActionAuthorize || records[0].Result != audit.ResultSuccess ||
+		records[0].Identity == nil || *records[0].Identity != *identity {
+		t.Errorf("Wrong authorize record: %v", records[0])
+	}
+
+	if records[1].Action != audit.ActionSet || records[1].Path != "Signal.Door.Locked" ||
+		len(records[1].Changes) != 1 || records[1].Changes[0].OldValue != false ||
+		records[1].Changes[0].NewValue != true {
+		t.Errorf("Wrong set record: %v", records[1])
+	}
+
+	if records[2].Result != audit.ResultFailure || records[2].ErrorNumber != 403 || records[2].Identity != nil {
+		t.Errorf("Wrong failure record: %v", records[2])
+	}
+
+	// Records are appended to existing file
+	if logger, err = audit.New(config.AuditConfig{File: fileName}); err != nil {
+		t.Fatalf("Can't create audit logger: %s", err)
+	}
+
+	logger.Log(audit.Record{Action: audit.ActionAuthorize, Result: audit.ResultFailure})
+	logger.Close()
+
+	if records, err = readRecords(fileName); err != nil {
+		t.Fatalf("Can't read audit records: %s", err)
+	}
+
+	if len(records) != 4 {
+		t.Errorf("Wrong records count: %d", len(records))
+	}
+}
+
+func TestRotation(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "audit.log")
+
+	logger, err := audit.New(config.AuditConfig{File: fileName, MaxFileSize: 200, MaxFiles: 2})
+	if err != nil {
+		t.Fatalf("Can't create audit logger: %s", err)
+	}
+
+	for i := 0; i < 10; i++ {
+		logger.Log(audit.Record{
+			Action: audit.ActionSet, Protocol: "ws", Path: "Signal.Test", Value: i, Result: audit.ResultSuccess,
+		})
+	}
+
+	logger.Close()
+
+	for _, name := range []string{fileName, fileName + ".1", fileName + ".2"} {
+		info, err := os.Stat(name)
+		if err != nil {
+			t.Fatalf("Can't stat audit file: %s", err)
+		}
+
+		if info.Size() > 200 {
+			t.Errorf("Audit file %s exceeds max size: %d", name, info.Size())
+		}
+	}
+
+	if _, err = os.Stat(fileName + ".3"); !os.IsNotExist(err) {
+		t.Error("Extra rotated audit file should be removed")
+	}
+
+	records, err := readRecords(fileName)
+	if err != nil {
+		t.Fatalf("Can't read audit records: %s", err)
+	}
+
+	if len(records) == 0 || records[len(records)-1].Value != float64(9) {
+		t.Errorf("Wrong last audit record: %v", records)
+	}
+}
+
+/*******************************************************************************
+ * Private
+ ******************************************************************************/
+
+func readRecords(fileName string) (records []audit.Record, err error) {
+	file, err := os.Open(fileName)
+	if err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+
+	for scanner.Scan() {
+		var record audit.Record
+
+		if err = json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return nil, aoserrors.Wrap(err)
+		}
+
+		records = append(records, record)
+	}
+
+	return records, aoserrors.Wrap(scanner.Err())
+}
@@ -31,12 +31,87 @@ import (
 
 // Config instance.
 type Config struct {
-	ServerURL           string          `json:"serverUrl"`
-	CACert              string          `json:"caCert"`
-	VISCert             string          `json:"visCert"`
-	VISKey              string          `json:"visKey"`
-	Adapters            []AdapterConfig `json:"adapters"`
-	PermissionServerURL string          `json:"permissionServerUrl"`
+	ServerURL           string                   `json:"serverUrl"`
+	RESTServerURL       string                   `json:"restServerUrl"`
+	GRPCServerURL       string                   `json:"grpcServerUrl"`
+	MetricsServerURL    string                   `json:"metricsServerUrl"`
+	CACert              string                   `json:"caCert"`
+	VISCert             string                   `json:"visCert"`
+	VISKey              string                   `json:"visKey"`
+	Adapters            []AdapterConfig          `json:"adapters"`
+	PermissionServerURL string                   `json:"permissionServerUrl"`
+	AuthTTL             int64                    `json:"authTtl"`
+	VSSCatalog          string                   `json:"vssCatalog"`
+	VSSCatalogStrict    bool                     `json:"vssCatalogStrict"`
+	ProtocolVersion     int                      `json:"protocolVersion"`
+	History             []HistoryConfig          `json:"history"`
+	Recording           RecordingConfig          `json:"recording"`
+	Subscription        SubscriptionConfig       `json:"subscription"`
+	Audit               AuditConfig              `json:"audit"`
+	Limits              LimitsConfig             `json:"limits"`
+	PermissionProvider  PermissionProviderConfig `json:"permissionProvider"`
+}
+
+// HistoryConfig signal history configuration. Path could contain wildcards.
+type HistoryConfig struct {
+	Path        string `json:"path"`
+	MaxCount    int    `json:"maxCount"`
+	MaxDuration int64  `json:"maxDuration"`
+}
+
+// RecordingConfig adapter changes recording configuration. Recording is disabled if Dir is not set.
+type RecordingConfig struct {
+	Dir         string   `json:"dir"`
+	Paths       []string `json:"paths"`
+	MaxFileSize int64    `json:"maxFileSize"`
+	Quota       int64    `json:"quota"`
+	AutoStart   bool     `json:"autoStart"`
+}
+
+// SubscriptionConfig default subscription queue size and overflow policy: dropNewest, dropOldest, coalesce or
+// disconnect.
+type SubscriptionConfig struct {
+	QueueSize int    `json:"queueSize"`
+	Overflow  string `json:"overflow"`
+}
+
+// AuditConfig set and authorize operations audit configuration. Records are written to rotating File and/or to
+// systemd journal with Identifier. Audit is disabled if neither File nor Journal is set.
+type AuditConfig struct {
+	File        string `json:"file"`
+	MaxFileSize int64  `json:"maxFileSize"`
+	MaxFiles    int    `json:"maxFiles"`
+	Journal     bool   `json:"journal"`
+	Identifier  string `json:"identifier"`
+}
+
+// LimitsConfig per client connection limits. Authorized limits are applied to authorized clients, if not set
+// Default limits are applied to all clients.
+type LimitsConfig struct {
+	Default    ClientLimitsConfig  `json:"default"`
+	Authorized *ClientLimitsConfig `json:"authorized"`
+}
+
+// ClientLimitsConfig client limits. RequestRates contains allowed requests per second by VIS action, "*" rate limits
+// total rate of actions which are not listed. Zero values mean no limit.
+type ClientLimitsConfig struct {
+	RequestRates         map[string]float64 `json:"requestRates"`
+	MaxSubscriptions     int                `json:"maxSubscriptions"`
+	MaxSubscriptionPaths int                `json:"maxSubscriptionPaths"`
+}
+
+// PermissionProviderConfig permission provider configuration. Type is "iam" (default), "static" or "jwt". Static
+// provider reads token permissions from TokensFile. JWT provider validates tokens with PublicKey PEM file or JWKSFile
+// keys and reads permissions from PermissionsClaim. IAM responses are cached for CacheTTL seconds (60 by default),
+// rejected tokens for NegativeCacheTTL seconds (5 by default), negative value disables caching.
+type PermissionProviderConfig struct {
+	Type             string `json:"type"`
+	TokensFile       string `json:"tokensFile"`
+	PublicKey        string `json:"publicKey"`
+	JWKSFile         string `json:"jwksFile"`
+	PermissionsClaim string `json:"permissionsClaim"`
+	CacheTTL         int64  `json:"cacheTtl"`
+	NegativeCacheTTL int64  `json:"negativeCacheTtl"`
 }
 
 // AdapterConfig adapter configuration.
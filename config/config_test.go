@@ -35,6 +35,9 @@ import (
 func createConfigFile() (err error) {
 	configContent := `{
 "ServerUrl": "localhost:443",
+"RESTServerURL": "localhost:8088",
+"GRPCServerURL": "localhost:8089",
+"MetricsServerURL": "localhost:9090",
 "CACert": "/etc/ssl/certs/rootCA.crt",
 "VISCert": "wwwivi.crt.pem",
 "VISKey": "wwwivi.key.pem",
@@ -46,7 +49,27 @@ func createConfigFile() (err error) {
 	}, {
 		"Plugin": "test3"
 	}],
-"PermissionServerURL": "aosiam:8090"
+"PermissionServerURL": "aosiam:8090",
+"AuthTTL": 3600,
+"VSSCatalog": "/etc/aos/vss.json",
+"VSSCatalogStrict": true,
+"ProtocolVersion": 2,
+"History": [{"Path": "Private.V2C.Events.*", "MaxCount": 50, "MaxDuration": 600}],
+"Recording": {
+	"Dir": "/var/aos/vis/recordings", "Paths": ["Signal.*"], "MaxFileSize": 1024, "Quota": 4096, "AutoStart": true
+},
+"Subscription": {"QueueSize": 64, "Overflow": "coalesce"},
+"Audit": {
+	"File": "/var/aos/vis/audit.log", "MaxFileSize": 2048, "MaxFiles": 3, "Journal": true, "Identifier": "vis_audit"
+},
+"Limits": {
+	"Default": {"RequestRates": {"*": 5, "set": 1}, "MaxSubscriptions": 2, "MaxSubscriptionPaths": 10},
+	"Authorized": {"RequestRates": {"*": 50}, "MaxSubscriptions": 20}
+},
+"PermissionProvider": {
+	"Type": "jwt", "TokensFile": "/etc/aos/vis/tokens.json", "PublicKey": "/etc/aos/vis/jwt.pem",
+	"JWKSFile": "/etc/aos/vis/jwks.json", "PermissionsClaim": "permissions"
+}
 }`
 
 	if err := os.WriteFile(path.Join("tmp", "visconfig.json"), []byte(configContent), 0o600); err != nil {
@@ -108,6 +131,18 @@ func TestGetCredentials(t *testing.T) {
 		t.Errorf("Wrong ServerURL value: %s", config.ServerURL)
 	}
 
+	if config.RESTServerURL != "localhost:8088" {
+		t.Errorf("Wrong RESTServerURL value: %s", config.RESTServerURL)
+	}
+
+	if config.GRPCServerURL != "localhost:8089" {
+		t.Errorf("Wrong GRPCServerURL value: %s", config.GRPCServerURL)
+	}
+
+	if config.MetricsServerURL != "localhost:9090" {
+		t.Errorf("Wrong MetricsServerURL value: %s", config.MetricsServerURL)
+	}
+
 	if config.VISCert != "wwwivi.crt.pem" {
 		t.Errorf("Wrong VISCert value: %s", config.VISCert)
 	}
@@ -152,3 +187,130 @@ func TestPermissionServerURL(t *testing.T) {
 		t.Errorf("Wrong PermissionServerURL value: %s", config.ServerURL)
 	}
 }
+
+func TestAuthTTL(t *testing.T) {
+	config, err := config.New("tmp/visconfig.json")
+	if err != nil {
+		t.Fatalf("Error opening config file: %s", err)
+	}
+
+	if config.AuthTTL != 3600 {
+		t.Errorf("Wrong AuthTTL value: %d", config.AuthTTL)
+	}
+}
+
+func TestVSSCatalog(t *testing.T) {
+	config, err := config.New("tmp/visconfig.json")
+	if err != nil {
+		t.Fatalf("Error opening config file: %s", err)
+	}
+
+	if config.VSSCatalog != "/etc/aos/vss.json" {
+		t.Errorf("Wrong VSSCatalog value: %s", config.VSSCatalog)
+	}
+
+	if !config.VSSCatalogStrict {
+		t.Error("VSSCatalogStrict should be set")
+	}
+}
+
+func TestProtocolVersion(t *testing.T) {
+	config, err := config.New("tmp/visconfig.json")
+	if err != nil {
+		t.Fatalf("Error opening config file: %s", err)
+	}
+
+	if config.ProtocolVersion != 2 {
+		t.Errorf("Wrong ProtocolVersion value: %d", config.ProtocolVersion)
+	}
+}
+
+func TestHistory(t *testing.T) {
+	config, err := config.New("tmp/visconfig.json")
+	if err != nil {
+		t.Fatalf("Error opening config file: %s", err)
+	}
+
+	if len(config.History) != 1 {
+		t.Fatalf("Wrong history count: %d", len(config.History))
+	}
+
+	if config.History[0].Path != "Private.V2C.Events.*" || config.History[0].MaxCount != 50 ||
+		config.History[0].MaxDuration != 600 {
+		t.Errorf("Wrong history value: %v", config.History[0])
+	}
+}
+
+func TestRecording(t *testing.T) {
+	config, err := config.New("tmp/visconfig.json")
+	if err != nil {
+		t.Fatalf("Error opening config file: %s", err)
+	}
+
+	if config.Recording.Dir != "/var/aos/vis/recordings" || len(config.Recording.Paths) != 1 ||
+		config.Recording.Paths[0] != "Signal.*" || config.Recording.MaxFileSize != 1024 ||
+		config.Recording.Quota != 4096 || !config.Recording.AutoStart {
+		t.Errorf("Wrong recording value: %v", config.Recording)
+	}
+}
+
+func TestSubscription(t *testing.T) {
+	config, err := config.New("tmp/visconfig.json")
+	if err != nil {
+		t.Fatalf("Error opening config file: %s", err)
+	}
+
+	if config.Subscription.QueueSize != 64 || config.Subscription.Overflow != "coalesce" {
+		t.Errorf("Wrong subscription value: %v", config.Subscription)
+	}
+}
+
+func TestAudit(t *testing.T) {
+	config, err := config.New("tmp/visconfig.json")
+	if err != nil {
+		t.Fatalf("Error opening config file: %s", err)
+	}
+
+	if config.Audit.File != "/var/aos/vis/audit.log" || config.Audit.MaxFileSize != 2048 ||
+		config.Audit.MaxFiles != 3 || !config.Audit.Journal || config.Audit.Identifier != "vis_audit" {
+		t.Errorf("Wrong audit value: %v", config.Audit)
+	}
+}
+
+func TestLimits(t *testing.T) {
+	config, err := config.New("tmp/visconfig.json")
+	if err != nil {
+		t.Fatalf("Error opening config file: %s", err)
+	}
+
+	limits := config.Limits.Default
+
+	if limits.RequestRates["*"] != 5 || limits.RequestRates["set"] != 1 || limits.MaxSubscriptions != 2 ||
+		limits.MaxSubscriptionPaths != 10 {
+		t.Errorf("Wrong default limits value: %v", limits)
+	}
+
+	if config.Limits.Authorized == nil {
+		t.Fatal("Authorized limits are not set")
+	}
+
+	limits = *config.Limits.Authorized
+
+	if limits.RequestRates["*"] != 50 || limits.MaxSubscriptions != 20 || limits.MaxSubscriptionPaths != 0 {
+		t.Errorf("Wrong authorized limits value: %v", limits)
+	}
+}
+
+func TestPermissionProvider(t *testing.T) {
+	config, err := config.New("tmp/visconfig.json")
+	if err != nil {
+		t.Fatalf("Error opening config file: %s", err)
+	}
+
+	if config.PermissionProvider.Type != "jwt" || config.PermissionProvider.TokensFile != "/etc/aos/vis/tokens.json" ||
+		config.PermissionProvider.PublicKey != "/etc/aos/vis/jwt.pem" ||
+		config.PermissionProvider.JWKSFile != "/etc/aos/vis/jwks.json" ||
+		config.PermissionProvider.PermissionsClaim != "permissions" {
+		t.Errorf("Wrong permission provider value: %v", config.PermissionProvider)
+	}
+}
@@ -86,6 +86,36 @@ func PublicPath(adapterInfo *TestAdapterInfo) (err error) {
 	return nil
 }
 
+// GetMetadata tests GetMetadata adapter method.
+func GetMetadata(adapterInfo *TestAdapterInfo) (err error) {
+	pathList, err := adapterInfo.Adapter.GetPathList()
+	if err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	metadata, err := adapterInfo.Adapter.GetMetadata(pathList)
+	if err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	for _, path := range pathList {
+		signalMetadata, ok := metadata[path]
+		if !ok || signalMetadata == nil {
+			return aoserrors.Errorf("no metadata for path: %s", path)
+		}
+
+		if !signalMetadata.Readable {
+			return aoserrors.Errorf("path %s should be readable", path)
+		}
+	}
+
+	if _, err = adapterInfo.Adapter.GetMetadata([]string{"Unknown.Path"}); err == nil {
+		return aoserrors.New("error expected for unknown path")
+	}
+
+	return nil
+}
+
 // GetSetData tests Get and Set adapter methods.
 func GetSetData(adapterInfo *TestAdapterInfo) (err error) {
 	if adapterInfo.SetData == nil {
@@ -119,6 +149,41 @@ func GetSetData(adapterInfo *TestAdapterInfo) (err error) {
 	return nil
 }
 
+// GetTimestamps tests GetTimestamps adapter method.
+func GetTimestamps(adapterInfo *TestAdapterInfo) (err error) {
+	if adapterInfo.SetData == nil {
+		return nil
+	}
+
+	setTime := time.Now()
+
+	if err = adapterInfo.Adapter.SetData(adapterInfo.SetData); err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	pathList := make([]string, 0, len(adapterInfo.SetData))
+	for path := range adapterInfo.SetData {
+		pathList = append(pathList, path)
+	}
+
+	timestamps, err := adapterInfo.Adapter.GetTimestamps(pathList)
+	if err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	for _, path := range pathList {
+		if timestamps[path].Before(setTime) || timestamps[path].After(time.Now()) {
+			return aoserrors.Errorf("wrong path: %s timestamp: %v", path, timestamps[path])
+		}
+	}
+
+	if _, err = adapterInfo.Adapter.GetTimestamps([]string{"Unknown.Path"}); err == nil {
+		return aoserrors.New("error expected for unknown path")
+	}
+
+	return nil
+}
+
 // SubscribeUnsubscribe tests Subscribe and Unsubscribe adapter methods.
 func SubscribeUnsubscribe(adapterInfo *TestAdapterInfo) (err error) {
 	if adapterInfo.SetData == nil {
@@ -140,7 +205,9 @@ func SubscribeUnsubscribe(adapterInfo *TestAdapterInfo) (err error) {
 	}
 
 	select {
-	case getData := <-adapterInfo.Adapter.GetSubscribeChannel():
+	case changes := <-adapterInfo.Adapter.GetSubscribeChannel():
+		getData, _ := dataprovider.UnpackChanges(changes)
+
 		// check data
 		for path, data := range getData {
 			if !reflect.DeepEqual(adapterInfo.SetSubscribeData[path], data) {
@@ -20,6 +20,7 @@ package dataprovider
 import (
 	"reflect"
 	"sync"
+	"time"
 
 	"github.com/aosedge/aos_common/aoserrors"
 )
@@ -34,14 +35,24 @@ type BaseAdapter struct {
 	Data map[string]*BaseData
 	sync.Mutex
 	SubscribeChannel chan map[string]interface{}
+	// notifyMutex keeps changes sent in the order they are stored
+	notifyMutex sync.Mutex
 }
 
 // BaseData base data type.
 type BaseData struct {
-	Public    bool
-	ReadOnly  bool
-	Value     interface{}
-	subscribe bool
+	Public      bool
+	ReadOnly    bool
+	Value       interface{}
+	Type        string
+	DataType    string
+	Unit        string
+	Min         interface{}
+	Max         interface{}
+	Allowed     []interface{}
+	Description string
+	subscribe   bool
+	timestamp   time.Time
 }
 
 /*******************************************************************************
@@ -93,6 +104,36 @@ func (adapter *BaseAdapter) IsPathPublic(path string) (result bool, err error) {
 	return adapter.Data[path].Public, nil
 }
 
+// GetMetadata returns metadata by path.
+func (adapter *BaseAdapter) GetMetadata(pathList []string) (metadata map[string]*SignalMetadata, err error) {
+	adapter.Lock()
+	defer adapter.Unlock()
+
+	metadata = make(map[string]*SignalMetadata)
+
+	for _, path := range pathList {
+		data, ok := adapter.Data[path]
+		if !ok {
+			return metadata, aoserrors.Errorf("path %s doesn't exits", path)
+		}
+
+		metadata[path] = &SignalMetadata{
+			Type:        data.Type,
+			DataType:    data.DataType,
+			Unit:        data.Unit,
+			Min:         data.Min,
+			Max:         data.Max,
+			Allowed:     data.Allowed,
+			Description: data.Description,
+			Readable:    true,
+			Writable:    !data.ReadOnly,
+			Public:      data.Public,
+		}
+	}
+
+	return metadata, nil
+}
+
 // GetData returns data by path.
 func (adapter *BaseAdapter) GetData(pathList []string) (data map[string]interface{}, err error) {
 	adapter.Lock()
@@ -111,38 +152,52 @@ func (adapter *BaseAdapter) GetData(pathList []string) (data map[string]interfac
 	return data, nil
 }
 
-// SetData sets data by pathes.
-func (adapter *BaseAdapter) SetData(data map[string]interface{}) (err error) {
+// GetTimestamps returns time when data was set. Zero time is returned for never set data.
+func (adapter *BaseAdapter) GetTimestamps(pathList []string) (timestamps map[string]time.Time, err error) {
 	adapter.Lock()
 	defer adapter.Unlock()
 
-	changedData := make(map[string]interface{})
+	timestamps = make(map[string]time.Time)
 
-	for path, value := range data {
+	for _, path := range pathList {
 		if _, ok := adapter.Data[path]; !ok {
-			return aoserrors.Errorf("path %s doesn't exits", path)
+			return timestamps, aoserrors.Errorf("path %s doesn't exits", path)
 		}
 
-		if adapter.Data[path].ReadOnly {
-			return aoserrors.Errorf("signal %s cannot be set since it is a read only signal", path)
-		}
+		timestamps[path] = adapter.Data[path].timestamp
+	}
 
-		oldValue := adapter.Data[path].Value
-		adapter.Data[path].Value = value
+	return timestamps, nil
+}
 
-		if !reflect.DeepEqual(oldValue, value) && adapter.Data[path].subscribe {
-			changedData[path] = value
-		}
-	}
+// SetData sets data by pathes.
+func (adapter *BaseAdapter) SetData(data map[string]interface{}) (err error) {
+	return adapter.storeData(data, true)
+}
 
-	if len(changedData) > 0 {
-		adapter.SubscribeChannel <- changedData
+// UpdateData updates data by pathes including read only ones. It is used by adapters to store values received from
+// their sources.
+func (adapter *BaseAdapter) UpdateData(data map[string]interface{}) (err error) {
+	return adapter.storeData(data, false)
+}
+
+// CheckSetData returns error if data can't be set: path doesn't exist or it is read only. It is used by adapters to
+// check set request before values are sent to their sources.
+func (adapter *BaseAdapter) CheckSetData(data map[string]interface{}) (err error) {
+	adapter.Lock()
+	defer adapter.Unlock()
+
+	for path := range data {
+		if err = adapter.checkPath(path, true); err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
 
-// GetSubscribeChannel returns channel on which data changes will be sent.
+// GetSubscribeChannel returns channel on which data changes will be sent. Changes are sent as DataPoint with time
+// when data was set.
 func (adapter *BaseAdapter) GetSubscribeChannel() (channel <-chan map[string]interface{}) {
 	return adapter.SubscribeChannel
 }
@@ -190,3 +245,63 @@ func (adapter *BaseAdapter) UnsubscribeAll() (err error) {
 
 	return nil
 }
+
+/*******************************************************************************
+ * Private
+ ******************************************************************************/
+
+func (adapter *BaseAdapter) storeData(data map[string]interface{}, checkReadOnly bool) (err error) {
+	adapter.notifyMutex.Lock()
+	defer adapter.notifyMutex.Unlock()
+
+	changedData, err := adapter.updateData(data, checkReadOnly)
+	if err != nil {
+		return err
+	}
+
+	// Changes are sent without data lock to not block data requests while channel is full
+	if len(changedData) > 0 {
+		adapter.SubscribeChannel <- changedData
+	}
+
+	return nil
+}
+
+func (adapter *BaseAdapter) updateData(
+	data map[string]interface{}, checkReadOnly bool,
+) (changedData map[string]interface{}, err error) {
+	adapter.Lock()
+	defer adapter.Unlock()
+
+	changedData = make(map[string]interface{})
+	now := time.Now()
+
+	for path, value := range data {
+		if err = adapter.checkPath(path, checkReadOnly); err != nil {
+			return nil, err
+		}
+
+		oldValue := adapter.Data[path].Value
+		adapter.Data[path].Value = value
+		adapter.Data[path].timestamp = now
+
+		if !reflect.DeepEqual(oldValue, value) && adapter.Data[path].subscribe {
+			changedData[path] = DataPoint{Value: value, Timestamp: now}
+		}
+	}
+
+	return changedData, nil
+}
+
+func (adapter *BaseAdapter) checkPath(path string, checkReadOnly bool) (err error) {
+	data, ok := adapter.Data[path]
+	if !ok {
+		return aoserrors.Errorf("path %s doesn't exits", path)
+	}
+
+	if checkReadOnly && data.ReadOnly {
+		return aoserrors.Errorf("signal %s cannot be set since it is a read only signal", path)
+	}
+
+	return nil
+}
@@ -20,13 +20,18 @@ package dataprovider
 import (
 	"container/list"
 	"encoding/json"
+	"slices"
+	"sort"
 	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 
 	"github.com/aosedge/aos_common/aoserrors"
 	"github.com/aosedge/aos_vis/config"
+	"github.com/aosedge/aos_vis/metrics"
 )
 
 /*******************************************************************************
@@ -40,23 +45,60 @@ const (
 	numPreallocatedPathes   = 10
 )
 
+// VSS node types.
+const (
+	NodeTypeBranch    = "branch"
+	NodeTypeSensor    = "sensor"
+	NodeTypeActuator  = "actuator"
+	NodeTypeAttribute = "attribute"
+)
+
 /*******************************************************************************
  * Types
  ******************************************************************************/
 
-// DataProvider interface for geeting vehicle data.
+// DataProvider interface for geeting vehicle data. Sensors registry and subscriptions are protected by RW lock,
+// adapters are called without it. Subscribe and unsubscribe calls of each adapter are serialized by its adapter mutex.
 type DataProvider struct {
-	sensors          map[string]*sensorDescription
-	currentSubsID    uint64
-	subscribeInfoMap map[uint64]*subscribeInfo
-	sync.Mutex
-	adapters []DataAdapter
+	sensors              map[string]*sensorDescription
+	sensorIndex          *PathIndex
+	catalog              map[string]*SignalMetadata
+	currentSubsID        uint64
+	subscribeInfoMap     map[uint64]*Subscription
+	subscribeOptions     SubscribeOptions
+	droppedNotifications atomic.Uint64
+	historyRules         []historyRule
+	recorder             *recorder
+	// config is initial configuration which is compared on reload to report ignored changes
+	config config.Config
+	sync.RWMutex
+	// reloadMutex serializes configuration reloads
+	reloadMutex    sync.Mutex
+	adapterMutexes map[DataAdapter]*sync.Mutex
+	adapters       []*adapterInstance
 }
 
-// AuthInfo authorization info.
+// AuthInfo authorization info. Zero value is info of not authorized client, authorized one is created by NewAuthInfo.
 type AuthInfo struct {
 	IsAuthorized bool
-	Permissions  map[string]string
+	// Identity is set if permission provider reports it
+	Identity *ClientIdentity
+
+	permissionMasks []permissionMask
+}
+
+// ClientIdentity identity of authorized client.
+type ClientIdentity struct {
+	ServiceID string `json:"serviceId,omitempty"`
+	SubjectID string `json:"subjectId,omitempty"`
+	Instance  uint64 `json:"instance,omitempty"`
+}
+
+// DataChange value of path before and after set request.
+type DataChange struct {
+	Path     string      `json:"path"`
+	OldValue interface{} `json:"oldValue"`
+	NewValue interface{} `json:"newValue"`
 }
 
 // DataAdapter interface to data adapter.
@@ -69,11 +111,16 @@ type DataAdapter interface {
 	GetPathList() (pathList []string, err error)
 	// IsPathPublic returns true if requested data accessible without authorization
 	IsPathPublic(path string) (result bool, err error)
+	// GetMetadata returns metadata by path
+	GetMetadata(pathList []string) (metadata map[string]*SignalMetadata, err error)
 	// GetData returns data by path
 	GetData(pathList []string) (data map[string]interface{}, err error)
+	// GetTimestamps returns time when data was sampled. Zero or absent timestamp means sample time is unknown
+	GetTimestamps(pathList []string) (timestamps map[string]time.Time, err error)
 	// SetData sets data by pathes
 	SetData(data map[string]interface{}) (err error)
-	// GetSubscribeChannel returns channel on which data changes will be sent
+	// GetSubscribeChannel returns channel on which data changes will be sent. Changed value could be sent as DataPoint
+	// to provide its sample time
 	GetSubscribeChannel() (channel <-chan map[string]interface{})
 	// Subscribe subscribes for data changes
 	Subscribe(pathList []string) (err error)
@@ -83,17 +130,56 @@ type DataAdapter interface {
 	UnsubscribeAll() (err error)
 }
 
+// SignalMetadata signal metadata.
+type SignalMetadata struct {
+	Type        string        `json:"type"`
+	DataType    string        `json:"datatype,omitempty"`
+	Unit        string        `json:"unit,omitempty"`
+	Min         interface{}   `json:"min,omitempty"`
+	Max         interface{}   `json:"max,omitempty"`
+	Allowed     []interface{} `json:"allowed,omitempty"`
+	Description string        `json:"description,omitempty"`
+	Readable    bool          `json:"readable,omitempty"`
+	Writable    bool          `json:"writable,omitempty"`
+	Public      bool          `json:"public,omitempty"`
+}
+
+// DataPoint signal value with its sample time.
+type DataPoint struct {
+	Value     interface{}
+	Timestamp time.Time
+}
+
+// MetadataNode VSS metadata tree node.
+type MetadataNode struct {
+	SignalMetadata
+	Children map[string]*MetadataNode `json:"children,omitempty"`
+}
+
 // NewPlugin plugin new function.
 type NewPlugin func(configJSON json.RawMessage) (adapter DataAdapter, err error)
 
 type sensorDescription struct {
 	adapter      DataAdapter
 	subscribeIds *list.List
+	metadata     *SignalMetadata
+	history      *historyBuffer
+	recorded     bool
+	// adapterSubscribed is set if adapter is subscribed for path changes
+	adapterSubscribed bool
+}
+
+type permissionMask struct {
+	filter      *PathFilter
+	permissions string
 }
 
-type subscribeInfo struct {
-	channel chan<- interface{}
-	path    string
+// adapterInstance created adapter with its config.
+type adapterInstance struct {
+	adapter DataAdapter
+	config  config.AdapterConfig
+	// done stops handling of adapter subscribe channel
+	done chan struct{}
 }
 
 /*******************************************************************************
@@ -106,6 +192,25 @@ var plugins = make(map[string]NewPlugin) //nolint:gochecknoglobals //by design
  * Public
  ******************************************************************************/
 
+// NewAuthInfo creates authorization info of authorized client. Permission masks are compiled once on creation.
+func NewAuthInfo(permissions map[string]string, identity *ClientIdentity) (authInfo *AuthInfo, err error) {
+	authInfo = &AuthInfo{
+		IsAuthorized: true, Identity: identity, permissionMasks: make([]permissionMask, 0, len(permissions)),
+	}
+
+	for mask, value := range permissions {
+		filter, err := CreatePathFilter(mask)
+		if err != nil {
+			return nil, aoserrors.Wrap(err)
+		}
+
+		authInfo.permissionMasks = append(authInfo.permissionMasks,
+			permissionMask{filter: filter, permissions: strings.ToLower(value)})
+	}
+
+	return authInfo, nil
+}
+
 // RegisterPlugin registers data adapter plugin.
 func RegisterPlugin(plugin string, newFunc NewPlugin) {
 	log.WithField("plugin", plugin).Info("Register plugin")
@@ -117,12 +222,40 @@ func RegisterPlugin(plugin string, newFunc NewPlugin) {
 func New(config *config.Config) (provider *DataProvider, err error) {
 	log.Debug("Create data provider")
 
-	provider = &DataProvider{}
+	provider = &DataProvider{config: *config}
+
+	// Slices are copied as caller could reuse config on reload
+	provider.config.History = slices.Clone(config.History)
+	provider.config.Recording.Paths = slices.Clone(config.Recording.Paths)
 
 	provider.sensors = make(map[string]*sensorDescription)
-	provider.subscribeInfoMap = make(map[uint64]*subscribeInfo)
+	provider.sensorIndex = NewPathIndex()
+	provider.subscribeInfoMap = make(map[uint64]*Subscription)
+	provider.adapterMutexes = make(map[DataAdapter]*sync.Mutex)
+
+	if provider.subscribeOptions, err = newSubscribeOptions(config.Subscription); err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	provider.adapters = make([]*adapterInstance, 0, numPreallocatedAdapters)
 
-	provider.adapters = make([]DataAdapter, 0, numPreallocatedAdapters)
+	if config.VSSCatalog != "" {
+		if provider.catalog, err = loadVSSCatalog(config.VSSCatalog); err != nil {
+			return nil, aoserrors.Wrap(err)
+		}
+
+		log.WithFields(log.Fields{
+			"file": config.VSSCatalog, "nodes": len(provider.catalog),
+		}).Debug("VSS catalog loaded")
+	}
+
+	if provider.historyRules, err = createHistoryRules(config.History); err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	if provider.recorder, err = newRecorder(config.Recording); err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
 
 	for _, adapterCfg := range config.Adapters {
 		if adapterCfg.Disabled {
@@ -130,272 +263,261 @@ func New(config *config.Config) (provider *DataProvider, err error) {
 			continue
 		}
 
-		adapter, err := provider.createAdapter(adapterCfg.Plugin, adapterCfg.Params)
+		instance, err := provider.createAdapter(adapterCfg)
 		if err != nil {
 			return nil, aoserrors.Wrap(err)
 		}
 
-		provider.adapters = append(provider.adapters, adapter)
+		provider.adapters = append(provider.adapters, instance)
 	}
 
 	if len(provider.adapters) == 0 {
 		return nil, aoserrors.New("no valid adapter info provided")
 	}
 
+	if config.Recording.AutoStart {
+		if err = provider.StartRecording(); err != nil {
+			return nil, aoserrors.Wrap(err)
+		}
+	}
+
 	return provider, nil
 }
 
 // Close closes data provider.
 func (provider *DataProvider) Close() {
-	for _, adapter := range provider.adapters {
-		adapter.Close()
+	for _, instance := range provider.adapters {
+		instance.close()
+	}
+
+	if provider.recorder != nil {
+		if _, err := provider.recorder.stop(); err != nil {
+			log.Errorf("Can't stop recording: %s", err)
+		}
 	}
 }
 
 // GetData returns VIS data.
 func (provider *DataProvider) GetData(path string, authInfo *AuthInfo) (data interface{}, err error) {
+	dataPoints, err := provider.GetDataPoints(path, authInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	return ConvertData(path, GetDataPointValues(dataPoints)), nil
+}
+
+// GetDataPoints returns values with sample time of all paths matched to requested path.
+func (provider *DataProvider) GetDataPoints(
+	path string, authInfo *AuthInfo,
+) (dataPoints map[string]DataPoint, err error) {
 	log.WithField("path", path).Debug("Get data")
 
 	filter, err := CreatePathFilter(path)
 	if err != nil {
-		return data, err
+		return nil, err
 	}
 
-	// Create map of pathes grouped by adapter
-	adapterDataMap := make(map[DataAdapter][]string)
-
-	for path, sensor := range provider.sensors {
-		if filter.Match(path) {
-			if err = checkPermissions(sensor.adapter, path, authInfo, "r"); err != nil {
-				return data, err
-			}
+	adapterDataMap := provider.matchSensors(filter)
 
-			if adapterDataMap[sensor.adapter] == nil {
-				adapterDataMap[sensor.adapter] = make([]string, 0, numPreallocatedPathes)
-			}
-
-			adapterDataMap[sensor.adapter] = append(adapterDataMap[sensor.adapter], path)
-		}
+	if err = checkPathPermissions(adapterDataMap, authInfo, "r"); err != nil {
+		return nil, err
 	}
 
-	// Create common data array
-	commonData := make(map[string]interface{})
+	dataPoints = make(map[string]DataPoint)
 
 	for adapter, pathList := range adapterDataMap {
-		result, err := adapter.GetData(pathList)
+		result, err := getAdapterData(adapter, pathList)
+		if err != nil {
+			return nil, aoserrors.Wrap(err)
+		}
+
+		timestamps, err := adapter.GetTimestamps(pathList)
 		if err != nil {
-			return data, aoserrors.Wrap(err)
+			return nil, aoserrors.Wrap(err)
 		}
 
 		for path, value := range result {
 			log.WithFields(log.Fields{"adapter": adapter.GetName(), "path": path, "value": value}).Debug("Data from adapter")
 
-			commonData[path] = value
+			dataPoints[path] = newDataPoint(value, timestamps[path])
 		}
 	}
 
-	if len(commonData) == 0 {
-		return data, aoserrors.New("specified data path does not exist")
+	if len(dataPoints) == 0 {
+		return nil, aoserrors.New("specified data path does not exist")
 	}
 
-	return convertData(path, commonData), nil
+	return dataPoints, nil
 }
 
 // SetData sets VIS data.
 func (provider *DataProvider) SetData(path string, data interface{}, authInfo *AuthInfo) (err error) {
-	log.WithFields(log.Fields{"path": path, "data": data}).Debug("Set data")
+	_, err = provider.setData(path, data, authInfo, false)
+
+	return err
+}
+
+// SetDataWithChanges sets data by path and returns values of set paths before and after the request sorted by path.
+// Changes are returned also if adapter fails to set them. Values before the request are read from adapters with
+// additional request which is not atomic with set, so it should be used only if old values are required.
+func (provider *DataProvider) SetDataWithChanges(
+	path string, data interface{}, authInfo *AuthInfo,
+) (changes []DataChange, err error) {
+	return provider.setData(path, data, authInfo, true)
+}
 
+// CheckPermissions checks if client has requested permissions for all paths matched to requested path.
+func (provider *DataProvider) CheckPermissions(path string, authInfo *AuthInfo, permissions string) (err error) {
 	filter, err := CreatePathFilter(path)
 	if err != nil {
-		return aoserrors.Wrap(err)
+		return err
 	}
 
-	suffixMap := provider.getSuffixMap(data)
+	return checkPathPermissions(provider.matchSensors(filter), authInfo, permissions)
+}
 
-	// adapterDataMap contains VIS data grouped by adapters
-	adapterDataMap := make(map[DataAdapter]map[string]interface{})
+// GetMetadata returns VSS metadata tree of paths matched to requested path. Paths which are not readable by client are
+// skipped.
+func (provider *DataProvider) GetMetadata(
+	path string, authInfo *AuthInfo,
+) (metadata map[string]*MetadataNode, err error) {
+	log.WithField("path", path).Debug("Get metadata")
 
-	for path, sensor := range provider.sensors {
-		if !filter.Match(path) {
-			continue
-		}
+	filter, err := CreatePathFilter(path)
+	if err != nil {
+		return nil, err
+	}
 
-		var value interface{}
+	var permissionErr error
 
-		if len(suffixMap) != 0 {
-			// if there is suffix map, try to find proper path by suffix
-			for suffix, v := range suffixMap {
-				if strings.HasSuffix(path, suffix) {
-					value = v
-					break
-				}
-			}
-		} else {
-			// For simple value set data
-			value = data
-		}
+	// Create map of pathes grouped by adapter
+	adapterPathMap := make(map[DataAdapter][]string)
 
-		if value == nil {
-			continue
-		}
+	for adapter, pathList := range provider.matchSensors(filter) {
+		for _, path := range pathList {
+			if err = checkPermissions(adapter, path, authInfo, "r"); err != nil {
+				permissionErr = err
+				continue
+			}
 
-		// Set data to adapterDataMap
-		if err = checkPermissions(sensor.adapter, path, authInfo, "w"); err != nil {
-			return aoserrors.Wrap(err)
+			adapterPathMap[adapter] = append(adapterPathMap[adapter], path)
 		}
+	}
 
-		if adapterDataMap[sensor.adapter] == nil {
-			adapterDataMap[sensor.adapter] = make(map[string]interface{})
+	if len(adapterPathMap) == 0 {
+		if permissionErr != nil {
+			return nil, permissionErr
 		}
 
-		adapterDataMap[sensor.adapter][path] = value
+		return nil, aoserrors.New("specified data path does not exist")
 	}
 
-	// If adapterMap is empty: no path found
-	if len(adapterDataMap) == 0 {
-		return aoserrors.New("server is unable to fulfil the client request because the request is malformed")
-	}
+	metadata = make(map[string]*MetadataNode)
 
-	for adapter, visData := range adapterDataMap {
-		for path, value := range visData {
-			log.WithFields(log.Fields{
-				"adapter": adapter.GetName(),
-				"path":    path, "value": value,
-			}).Debug("Set data to adapter")
+	for adapter, pathList := range adapterPathMap {
+		adapterMetadata, err := adapter.GetMetadata(pathList)
+		if err != nil {
+			return nil, aoserrors.Wrap(err)
 		}
 
-		if err = adapter.SetData(visData); err != nil {
-			return aoserrors.Wrap(err)
+		for path, signalMetadata := range adapterMetadata {
+			if catalogMetadata := provider.getCatalogMetadata(path); catalogMetadata != nil {
+				applyCatalogMetadata(signalMetadata, catalogMetadata)
+			}
+
+			provider.addMetadataNode(metadata, path, signalMetadata)
 		}
 	}
 
-	return nil
+	return metadata, nil
 }
 
-// Subscribe subscribes for data change.
+// Subscribe subscribes for data change with default subscription options.
 func (provider *DataProvider) Subscribe(
 	path string, authInfo *AuthInfo,
-) (id uint64, channel <-chan interface{}, err error) {
-	provider.Lock()
-	defer provider.Unlock()
-
-	log.WithFields(log.Fields{"subscribeID": provider.currentSubsID, "path": path}).Debug("Subscribe")
-
-	filter, err := CreatePathFilter(path)
+) (id uint64, channel <-chan *Notification, err error) {
+	subscription, err := provider.SubscribeWithOptions(path, authInfo, SubscribeOptions{})
 	if err != nil {
 		return id, channel, err
 	}
 
-	// Create map of pathes grouped by adapter
-	subscribeMap := make(map[DataAdapter][]string)
+	return subscription.ID, subscription.Channel, nil
+}
 
-	// Get data from adapter and group it by parent
-	for path, sensor := range provider.sensors {
-		if filter.Match(path) {
-			if err = checkPermissions(sensor.adapter, path, authInfo, "r"); err != nil {
-				return id, channel, err
-			}
+// SubscribeWithOptions subscribes for data change with specified options. Snapshot is sent before any change
+// received after subscription is registered, changes received while snapshot is read are merged into it.
+func (provider *DataProvider) SubscribeWithOptions(
+	path string, authInfo *AuthInfo, options SubscribeOptions,
+) (subscription *Subscription, err error) {
+	if subscription, err = newSubscription(path, options, provider.subscribeOptions); err != nil {
+		return nil, err
+	}
 
-			// Add subscribe id to subscribe list
-			sensor.subscribeIds.PushBack(provider.currentSubsID)
+	filter, err := CreatePathFilter(path)
+	if err != nil {
+		return nil, err
+	}
 
-			// Add path to subscribeMap
-			if subscribeMap[sensor.adapter] == nil {
-				subscribeMap[sensor.adapter] = make([]string, 0, numPreallocatedPathes)
-			}
+	subscribeMap := provider.matchSensors(filter)
+	numPaths := 0
 
-			subscribeMap[sensor.adapter] = append(subscribeMap[sensor.adapter], path)
-		}
+	for _, pathList := range subscribeMap {
+		numPaths += len(pathList)
 	}
 
 	if len(subscribeMap) == 0 {
-		return id, channel, aoserrors.New("specified data path does not exist")
+		return nil, aoserrors.New("specified data path does not exist")
 	}
 
-	// Subscribe for adapter data changes
-	for adapter, pathList := range subscribeMap {
-		for _, path := range pathList {
-			log.WithFields(log.Fields{"adapter": adapter.GetName(), "path": path}).Debug("Subscribe for adapter data")
-		}
-
-		if err = adapter.Subscribe(pathList); err != nil {
-			return id, channel, aoserrors.Wrap(err)
-		}
+	if err = checkPathPermissions(subscribeMap, authInfo, "r"); err != nil {
+		return nil, err
 	}
 
-	id = provider.currentSubsID
-
-	dataChannel := make(chan interface{}, subscribeChannelSize)
-	provider.subscribeInfoMap[id] = &subscribeInfo{channel: dataChannel, path: path}
-
-	provider.currentSubsID++
-
-	return id, dataChannel, nil
-}
-
-// Unsubscribe unsubscribes from data change.
-func (provider *DataProvider) Unsubscribe(id uint64, authInfo *AuthInfo) (err error) {
-	provider.Lock()
-	defer provider.Unlock()
-
-	log.WithField("subscribeID", id).Debug("Unsubscribe")
-
-	subscribeInfo, ok := provider.subscribeInfoMap[id]
-	if !ok {
-		return aoserrors.Errorf("subscribe id %v not found", id)
+	if options.MaxPaths > 0 && numPaths > options.MaxPaths {
+		return nil, aoserrors.Errorf("subscription paths limit exceeded: %d paths match, %d allowed",
+			numPaths, options.MaxPaths)
 	}
 
-	close(subscribeInfo.channel)
-
-	delete(provider.subscribeInfoMap, id)
+	// Sensors could be removed by reload after match, only existing ones are registered
+	if subscribeMap = provider.registerSubscription(subscription, subscribeMap); len(subscribeMap) == 0 {
+		return nil, aoserrors.New("specified data path does not exist")
+	}
 
-	// Create map of pathes grouped by adapter
-	unsubscribeMap := make(map[DataAdapter][]string)
+	log.WithFields(log.Fields{"subscribeID": subscription.ID, "path": path}).Debug("Subscribe")
 
-	// Go through all sensors and remove id
-	for path, sensor := range provider.sensors {
-		if sensor.subscribeIds.Len() == 0 {
-			continue
+	if err = provider.updateAdapterSubscriptions(subscribeMap); err != nil {
+		if removeErr := provider.removeSubscription(subscription.ID); removeErr != nil {
+			log.Errorf("Can't remove subscription: %s", removeErr)
 		}
 
-		var nextElement *list.Element
-
-		for idElement := sensor.subscribeIds.Front(); idElement != nil; idElement = nextElement {
-			nextElement = idElement.Next()
-
-			if idElement.Value == id {
-				sensor.subscribeIds.Remove(idElement)
-			}
-		}
+		return nil, err
+	}
 
-		if sensor.subscribeIds.Len() == 0 {
-			// Add path to unsubscribeMap
-			if unsubscribeMap[sensor.adapter] == nil {
-				unsubscribeMap[sensor.adapter] = make([]string, 0, numPreallocatedPathes)
+	if options.Snapshot {
+		if err = provider.sendSnapshot(subscription, subscribeMap); err != nil {
+			if removeErr := provider.removeSubscription(subscription.ID); removeErr != nil {
+				log.Errorf("Can't remove subscription: %s", removeErr)
 			}
 
-			unsubscribeMap[sensor.adapter] = append(unsubscribeMap[sensor.adapter], path)
+			return nil, err
 		}
 	}
 
-	// Unsubscribe from adapter data changes
-	for adapter, pathList := range unsubscribeMap {
-		for _, path := range pathList {
-			log.WithFields(log.Fields{"adapter": adapter.GetName(), "path": path}).Debug("Unsubscribe from adapter data")
-		}
+	return subscription, nil
+}
 
-		if err = adapter.Unsubscribe(pathList); err != nil {
-			return aoserrors.Wrap(err)
-		}
-	}
+// Unsubscribe unsubscribes from data change.
+func (provider *DataProvider) Unsubscribe(id uint64, authInfo *AuthInfo) (err error) {
+	log.WithField("subscribeID", id).Debug("Unsubscribe")
 
-	return nil
+	return provider.removeSubscription(id)
 }
 
 // GetSubscribeIDs returns list of active subscribe ID.
 func (provider *DataProvider) GetSubscribeIDs() (result []uint64) {
-	provider.Lock()
-	defer provider.Unlock()
+	provider.RLock()
+	defer provider.RUnlock()
 
 	result = make([]uint64, 0, len(provider.subscribeInfoMap))
 
@@ -406,173 +528,892 @@ func (provider *DataProvider) GetSubscribeIDs() (result []uint64) {
 	return result
 }
 
-/*******************************************************************************
- * Private
- ******************************************************************************/
-
-func (provider *DataProvider) createAdapter(plugin string, params json.RawMessage) (adapter DataAdapter, err error) {
-	newFunc, ok := plugins[plugin]
-	if !ok {
-		return nil, aoserrors.Errorf("plugin %s not found", plugin)
-	}
+// GetDataPointValues returns flat path/value data of data points.
+func GetDataPointValues(dataPoints map[string]DataPoint) (data map[string]interface{}) {
+	data = make(map[string]interface{})
 
-	adapter, err = newFunc(params)
-	if err != nil {
-		return nil, aoserrors.Wrap(err)
+	for path, dataPoint := range dataPoints {
+		data[path] = dataPoint.Value
 	}
 
-	pathList, err := adapter.GetPathList()
-	if err != nil {
-		return nil, aoserrors.Wrap(err)
-	}
+	return data
+}
 
-	for _, path := range pathList {
-		if _, ok := provider.sensors[path]; ok {
-			log.WithField("path", path).Warningf("Path already in adapter map")
-		} else {
-			log.WithFields(log.Fields{"path": path, "adaptor": adapter.GetName()}).Debug("Add path")
+// UnpackChanges returns values and sample times of adapter changes. Sample time is absent if change is sent without it.
+func UnpackChanges(changes map[string]interface{}) (data map[string]interface{}, timestamps map[string]time.Time) {
+	data = make(map[string]interface{}, len(changes))
+	timestamps = make(map[string]time.Time, len(changes))
 
-			provider.sensors[path] = &sensorDescription{adapter: adapter, subscribeIds: list.New()}
+	for path, change := range changes {
+		dataPoint, ok := change.(DataPoint)
+		if !ok {
+			data[path] = change
+			continue
 		}
-	}
 
-	go provider.handleSubscribeChannel(adapter)
+		data[path] = dataPoint.Value
+		timestamps[path] = dataPoint.Timestamp
+	}
 
-	return adapter, nil
+	return data, timestamps
 }
 
-func (provider *DataProvider) handleSubscribeChannel(adapter DataAdapter) {
-	for {
-		changes, more := <-adapter.GetSubscribeChannel()
-		if !more {
-			return
-		}
+// ConvertData converts flat path/value data to VIS value format.
+func ConvertData(requestedPath string, data map[string]interface{}) (result interface{}) {
+	// Group by parent map[parent] -> (map[path] -> value)
+	parentDataMap := make(map[string]map[string]interface{})
 
-		for path, value := range changes {
-			log.WithFields(log.Fields{"adapter": adapter.GetName(), "path": path, "value": value}).Debug("Adapter data changed")
+	for path, value := range data {
+		parent := getParentPath(path)
+		if parentDataMap[parent] == nil {
+			parentDataMap[parent] = make(map[string]interface{})
 		}
 
-		provider.Lock()
-
-		// Group data by subscribe ids
-		subscribeDataMap := make(map[uint64]map[string]interface{})
-
-		for path, value := range changes {
-			for idElement := provider.sensors[path].subscribeIds.Front(); idElement != nil; idElement = idElement.Next() {
-				id, ok := idElement.Value.(uint64)
-				if !ok {
-					log.Error("Wrong subscribe ID type")
-					break
-				}
+		parentDataMap[parent][path] = value
+	}
 
-				if subscribeDataMap[id] == nil {
-					subscribeDataMap[id] = make(map[string]interface{})
-				}
+	// make array from map
+	dataArray := make([]map[string]interface{}, 0, len(parentDataMap))
 
-				subscribeDataMap[id][path] = value
-			}
-		}
+	for _, value := range parentDataMap {
+		dataArray = append(dataArray, value)
+	}
 
-		// Notify subscribers by id
-		for id, data := range subscribeDataMap {
-			log.WithFields(log.Fields{"subscriberID": id, "data": data}).Debug("Notify subscribers")
+	// VIS defines 3 forms of returning result:
+	// * simple value if it is one signal
+	// * map[path]value if result belongs to same parent
+	// * []map[path]value if result belongs to different parents
+	//
+	// It is unclear from spec how to combine results in one map.
+	// By which criteria we should put data to one map or to array element.
+	// For now it is combined by parent node.
 
-			if len(provider.subscribeInfoMap[id].channel) < cap(provider.subscribeInfoMap[id].channel) {
-				provider.subscribeInfoMap[id].channel <- convertData(provider.subscribeInfoMap[id].path, data)
-			} else {
-				log.WithField("id", id).Warn("No more space in subscribe channel")
+	if len(dataArray) == 1 {
+		if len(dataArray[0]) == 1 {
+			for path, value := range dataArray[0] {
+				if path == requestedPath {
+					// return simple value
+					return value
+				}
 			}
 		}
-
-		provider.Unlock()
+		// return map of same parent
+		return dataArray[0]
 	}
+	// return array of different parents
+	return dataArray
 }
 
-func getParentPath(path string) (parent string) {
-	return path[:strings.LastIndex(path, ".")]
-}
+/*******************************************************************************
+ * Private
+ ******************************************************************************/
 
-func checkPermissions(adapter DataAdapter, path string, authInfo *AuthInfo, permissions string) (err error) {
-	if authInfo == nil {
-		return nil
-	}
+func (provider *DataProvider) setData(
+	path string, data interface{}, authInfo *AuthInfo, withOldValues bool,
+) (changes []DataChange, err error) {
+	log.WithFields(log.Fields{"path": path, "data": data}).Debug("Set data")
 
-	isPublic, err := adapter.IsPathPublic(path)
+	filter, err := CreatePathFilter(path)
 	if err != nil {
-		return aoserrors.Wrap(err)
+		return nil, aoserrors.Wrap(err)
 	}
 
-	if !authInfo.IsAuthorized && !isPublic {
-		return aoserrors.New("client is not authorized")
+	suffixMap := provider.getSuffixMap(data)
+
+	// adapterDataMap contains VIS data grouped by adapters
+	adapterDataMap := make(map[DataAdapter]map[string]interface{})
+
+	for adapter, pathList := range provider.matchSensors(filter) {
+		for _, path := range pathList {
+			var value interface{}
+
+			if len(suffixMap) != 0 {
+				// if there is suffix map, try to find proper path by suffix
+				for suffix, v := range suffixMap {
+					if strings.HasSuffix(path, suffix) {
+						value = v
+						break
+					}
+				}
+			} else {
+				// For simple value set data
+				value = data
+			}
+
+			if value == nil {
+				continue
+			}
+
+			// Set data to adapterDataMap
+			if err = checkPermissions(adapter, path, authInfo, "w"); err != nil {
+				return nil, aoserrors.Wrap(err)
+			}
+
+			if adapterDataMap[adapter] == nil {
+				adapterDataMap[adapter] = make(map[string]interface{})
+			}
+
+			adapterDataMap[adapter][path] = value
+		}
 	}
 
-	if isPublic {
+	// If adapterMap is empty: no path found
+	if len(adapterDataMap) == 0 {
+		return nil, aoserrors.New("server is unable to fulfil the client request because the request is malformed")
+	}
+
+	// Validate all values before any adapter is changed
+	for adapter, visData := range adapterDataMap {
+		if err = provider.validateData(adapter, visData); err != nil {
+			return nil, err
+		}
+	}
+
+	if withOldValues {
+		changes = getDataChanges(adapterDataMap)
+	}
+
+	for adapter, visData := range adapterDataMap {
+		for path, value := range visData {
+			log.WithFields(log.Fields{
+				"adapter": adapter.GetName(),
+				"path":    path, "value": value,
+			}).Debug("Set data to adapter")
+		}
+
+		if err = setAdapterData(adapter, visData); err != nil {
+			return changes, aoserrors.Wrap(err)
+		}
+	}
+
+	return changes, nil
+}
+
+func (provider *DataProvider) createAdapter(adapterCfg config.AdapterConfig) (instance *adapterInstance, err error) {
+	instance, pathList, err := buildAdapter(adapterCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = provider.startAdapter(instance, pathList); err != nil {
+		return nil, err
+	}
+
+	return instance, nil
+}
+
+// buildAdapter creates adapter and requests its paths. Paths are not provided until adapter is started.
+func buildAdapter(adapterCfg config.AdapterConfig) (instance *adapterInstance, pathList []string, err error) {
+	newFunc, ok := plugins[adapterCfg.Plugin]
+	if !ok {
+		return nil, nil, aoserrors.Errorf("plugin %s not found", adapterCfg.Plugin)
+	}
+
+	adapter, err := newFunc(adapterCfg.Params)
+	if err != nil {
+		return nil, nil, aoserrors.Wrap(err)
+	}
+
+	if pathList, err = adapter.GetPathList(); err != nil {
+		adapter.Close()
+
+		return nil, nil, aoserrors.Wrap(err)
+	}
+
+	return &adapterInstance{adapter: adapter, config: adapterCfg, done: make(chan struct{})}, pathList, nil
+}
+
+// startAdapter adds adapter paths and starts handling of adapter changes. Adapter is closed on error.
+func (provider *DataProvider) startAdapter(instance *adapterInstance, pathList []string) (err error) {
+	defer func() {
+		if err != nil {
+			provider.removeAdapterSensors(instance.adapter)
+			instance.adapter.Close()
+		}
+	}()
+
+	if err = provider.addAdapterSensors(instance.adapter, pathList); err != nil {
+		return err
+	}
+
+	if err = provider.initAdapterHistory(instance.adapter, pathList); err != nil {
+		return err
+	}
+
+	// Paths with history and recorded paths if recording is active should be subscribed
+	if err = provider.updateAdapterSubscriptions(
+		map[DataAdapter][]string{instance.adapter: pathList}); err != nil {
+		return err
+	}
+
+	go provider.handleSubscribeChannel(instance)
+
+	return nil
+}
+
+// addAdapterSensors adds adapter paths to sensors registry. If VSS catalog is set, paths which are not its signals
+// are ignored or, in strict mode, rejected.
+func (provider *DataProvider) addAdapterSensors(adapter DataAdapter, pathList []string) (err error) {
+	provider.Lock()
+	defer provider.Unlock()
+
+	var invalidPaths []string
+
+	catalogMetadata := make(map[string]*SignalMetadata)
+
+	if provider.catalog != nil {
+		for _, path := range pathList {
+			if metadata := provider.catalog[path]; metadata != nil && metadata.Type != NodeTypeBranch {
+				catalogMetadata[path] = metadata
+			} else {
+				invalidPaths = append(invalidPaths, path)
+			}
+		}
+	}
+
+	if len(invalidPaths) != 0 {
+		if provider.config.VSSCatalogStrict {
+			return aoserrors.Errorf("adapter %s paths are not found in VSS catalog: %s",
+				adapter.GetName(), strings.Join(invalidPaths, ", "))
+		}
+
+		log.WithFields(log.Fields{
+			"adapter": adapter.GetName(), "paths": invalidPaths,
+		}).Error("Paths are not found in VSS catalog and will be ignored")
+	}
+
+	provider.adapterMutexes[adapter] = &sync.Mutex{}
+
+	for _, path := range pathList {
+		if provider.catalog != nil && catalogMetadata[path] == nil {
+			continue
+		}
+
+		if _, ok := provider.sensors[path]; ok {
+			log.WithField("path", path).Warningf("Path already in adapter map")
+		} else {
+			log.WithFields(log.Fields{"path": path, "adaptor": adapter.GetName()}).Debug("Add path")
+
+			provider.sensors[path] = &sensorDescription{
+				adapter: adapter, subscribeIds: list.New(), metadata: catalogMetadata[path],
+				history: provider.createHistory(path), recorded: provider.recorder != nil && provider.recorder.match(path),
+			}
+			provider.sensorIndex.Add(path)
+		}
+	}
+
+	return nil
+}
+
+// registerSubscription assigns subscription ID and adds it to matched sensors which still exist. Registered paths
+// are returned.
+func (provider *DataProvider) registerSubscription(
+	subscription *Subscription, subscribeMap map[DataAdapter][]string,
+) (registeredMap map[DataAdapter][]string) {
+	provider.Lock()
+	defer provider.Unlock()
+
+	subscription.ID = provider.currentSubsID
+
+	registeredMap = make(map[DataAdapter][]string)
+
+	for adapter, pathList := range subscribeMap {
+		for _, path := range pathList {
+			sensor, ok := provider.sensors[path]
+			if !ok || sensor.adapter != adapter {
+				continue
+			}
+
+			// Add subscribe id to subscribe list
+			sensor.subscribeIds.PushBack(subscription.ID)
+
+			registeredMap[adapter] = append(registeredMap[adapter], path)
+			subscription.paths = append(subscription.paths, path)
+		}
+	}
+
+	if len(registeredMap) == 0 {
 		return nil
 	}
 
-	// Check permission
-	for mask, value := range authInfo.Permissions {
-		filter, err := CreatePathFilter(mask)
+	provider.subscribeInfoMap[subscription.ID] = subscription
+
+	provider.currentSubsID++
+
+	return registeredMap
+}
+
+// removeSubscription removes subscription and unsubscribes from adapter paths left without subscribers.
+func (provider *DataProvider) removeSubscription(id uint64) (err error) {
+	unsubscribeMap, err := provider.detachSubscription(id)
+	if err != nil {
+		return err
+	}
+
+	return provider.updateAdapterSubscriptions(unsubscribeMap)
+}
+
+// updateAdapterSubscriptions subscribes adapters for paths which require subscription and unsubscribes from paths
+// which don't require it anymore.
+func (provider *DataProvider) updateAdapterSubscriptions(adapterPathMap map[DataAdapter][]string) (err error) {
+	for adapter, pathList := range adapterPathMap {
+		if updateErr := provider.updateSubscriptions(adapter, pathList); updateErr != nil && err == nil {
+			err = updateErr
+		}
+	}
+
+	return err
+}
+
+// updateSubscriptions updates adapter subscriptions of paths. Required subscription state is taken under lock and
+// adapter is called without it. Adapter mutex serializes this sequence, so adapter subscriptions follow the latest
+// required state.
+func (provider *DataProvider) updateSubscriptions(adapter DataAdapter, pathList []string) (err error) {
+	provider.RLock()
+	adapterMutex, ok := provider.adapterMutexes[adapter]
+	provider.RUnlock()
+
+	// Adapter is removed on reload
+	if !ok {
+		return nil
+	}
+
+	adapterMutex.Lock()
+	defer adapterMutex.Unlock()
+
+	subscribeList, unsubscribeList := provider.getSubscriptionChanges(adapter, pathList)
+
+	if len(subscribeList) != 0 {
+		log.WithFields(log.Fields{"adapter": adapter.GetName(), "paths": subscribeList}).Debug("Subscribe for adapter data")
+
+		if err = adapter.Subscribe(subscribeList); err != nil {
+			provider.resetAdapterSubscribed(adapter, subscribeList)
+
+			return aoserrors.Wrap(err)
+		}
+	}
+
+	if len(unsubscribeList) != 0 {
+		log.WithFields(log.Fields{
+			"adapter": adapter.GetName(), "paths": unsubscribeList,
+		}).Debug("Unsubscribe from adapter data")
+
+		if err = adapter.Unsubscribe(unsubscribeList); err != nil {
+			return aoserrors.Wrap(err)
+		}
+	}
+
+	return nil
+}
+
+// getSubscriptionChanges returns adapter paths which should be subscribed and unsubscribed and marks them accordingly.
+func (provider *DataProvider) getSubscriptionChanges(
+	adapter DataAdapter, pathList []string,
+) (subscribeList, unsubscribeList []string) {
+	provider.Lock()
+	defer provider.Unlock()
+
+	for _, path := range pathList {
+		sensor, ok := provider.sensors[path]
+		if !ok || sensor.adapter != adapter {
+			continue
+		}
+
+		required := provider.isSubscriptionRequired(sensor)
+		if required == sensor.adapterSubscribed {
+			continue
+		}
+
+		sensor.adapterSubscribed = required
+
+		if required {
+			subscribeList = append(subscribeList, path)
+		} else {
+			unsubscribeList = append(unsubscribeList, path)
+		}
+	}
+
+	return subscribeList, unsubscribeList
+}
+
+// resetAdapterSubscribed marks paths as not subscribed if adapter fails to subscribe them.
+func (provider *DataProvider) resetAdapterSubscribed(adapter DataAdapter, pathList []string) {
+	provider.Lock()
+	defer provider.Unlock()
+
+	for _, path := range pathList {
+		if sensor, ok := provider.sensors[path]; ok && sensor.adapter == adapter {
+			sensor.adapterSubscribed = false
+		}
+	}
+}
+
+// detachSubscription removes subscription from registry and returns adapter paths which should be unsubscribed.
+func (provider *DataProvider) detachSubscription(id uint64) (unsubscribeMap map[DataAdapter][]string, err error) {
+	provider.Lock()
+	defer provider.Unlock()
+
+	subscription, ok := provider.subscribeInfoMap[id]
+	if !ok {
+		return nil, aoserrors.Errorf("subscribe id %v not found", id)
+	}
+
+	close(subscription.channel)
+
+	delete(provider.subscribeInfoMap, id)
+
+	// Create map of pathes grouped by adapter
+	unsubscribeMap = make(map[DataAdapter][]string)
+
+	// Go through subscribed sensors and remove id
+	for _, path := range subscription.paths {
+		// Sensor could be detached by reload which is in progress
+		sensor, ok := provider.sensors[path]
+		if !ok {
+			continue
+		}
+
+		var nextElement *list.Element
+
+		for idElement := sensor.subscribeIds.Front(); idElement != nil; idElement = nextElement {
+			nextElement = idElement.Next()
+
+			if idElement.Value == id {
+				sensor.subscribeIds.Remove(idElement)
+			}
+		}
+
+		if !provider.isSubscriptionRequired(sensor) {
+			// Add path to unsubscribeMap
+			if unsubscribeMap[sensor.adapter] == nil {
+				unsubscribeMap[sensor.adapter] = make([]string, 0, numPreallocatedPathes)
+			}
+
+			unsubscribeMap[sensor.adapter] = append(unsubscribeMap[sensor.adapter], path)
+		}
+	}
+
+	return unsubscribeMap, nil
+}
+
+// getDataChanges returns requested changes with current adapter values.
+func getDataChanges(adapterDataMap map[DataAdapter]map[string]interface{}) (changes []DataChange) {
+	for adapter, visData := range adapterDataMap {
+		pathList := make([]string, 0, len(visData))
+
+		for path := range visData {
+			pathList = append(pathList, path)
+		}
+
+		oldValues, err := getAdapterData(adapter, pathList)
+		if err != nil {
+			log.WithField("adapter", adapter.GetName()).Errorf("Can't get values before set: %s", err)
+		}
+
+		for path, value := range visData {
+			changes = append(changes, DataChange{Path: path, OldValue: oldValues[path], NewValue: value})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+
+	return changes
+}
+
+// getAdapterData gets adapter data and updates adapter metrics.
+func getAdapterData(adapter DataAdapter, pathList []string) (data map[string]interface{}, err error) {
+	start := time.Now()
+
+	data, err = adapter.GetData(pathList)
+
+	metrics.ObserveAdapterRequest(adapter.GetName(), metrics.OperationGetData, start, err)
+
+	return data, err //nolint:wrapcheck // error is wrapped by caller
+}
+
+// setAdapterData sets adapter data and updates adapter metrics.
+func setAdapterData(adapter DataAdapter, data map[string]interface{}) (err error) {
+	start := time.Now()
+
+	err = adapter.SetData(data)
+
+	metrics.ObserveAdapterRequest(adapter.GetName(), metrics.OperationSetData, start, err)
+
+	return err //nolint:wrapcheck // error is wrapped by caller
+}
+
+func (provider *DataProvider) terminateSubscription(id uint64) {
+	log.WithField("subscribeID", id).Warn("Terminate overflowed subscription")
+
+	provider.RLock()
+	_, ok := provider.subscribeInfoMap[id]
+	provider.RUnlock()
+
+	// Subscription could be already unsubscribed by subscriber
+	if !ok {
+		return
+	}
+
+	if err := provider.removeSubscription(id); err != nil {
+		log.Errorf("Can't terminate subscription: %s", err)
+	}
+}
+
+// close closes adapter and stops handling of its changes. Changes are handled while adapter is closing as it could
+// wait for pending changes to be sent.
+func (instance *adapterInstance) close() {
+	instance.adapter.Close()
+
+	close(instance.done)
+}
+
+func (provider *DataProvider) removeAdapterSensors(adapter DataAdapter) {
+	provider.Lock()
+	defer provider.Unlock()
+
+	for path, sensor := range provider.sensors {
+		if sensor.adapter == adapter {
+			delete(provider.sensors, path)
+			provider.sensorIndex.Remove(path)
+		}
+	}
+
+	delete(provider.adapterMutexes, adapter)
+}
+
+func (provider *DataProvider) handleSubscribeChannel(instance *adapterInstance) {
+	adapter := instance.adapter
+
+	for {
+		var (
+			changes map[string]interface{}
+			more    bool
+		)
+
+		select {
+		case changes, more = <-adapter.GetSubscribeChannel():
+			if !more {
+				return
+			}
+
+		case <-instance.done:
+			return
+		}
+
+		metrics.AddAdapterUpdates(adapter.GetName(), len(changes))
+
+		// Sample times are sent with changes as adapter could store next values before changes are handled
+		data, timestamps := UnpackChanges(changes)
+
+		for path, value := range data {
+			log.WithFields(log.Fields{"adapter": adapter.GetName(), "path": path, "value": value}).Debug("Adapter data changed")
+		}
+
+		if provider.recorder != nil {
+			provider.recorder.record(adapter.GetName(), data, timestamps)
+		}
+
+		provider.notifySubscribers(adapter, data, timestamps)
+	}
+}
+
+// notifySubscribers adds adapter changes to history and pushes them to subscribers. Subscribers of the same changed
+// paths share one notification.
+func (provider *DataProvider) notifySubscribers(
+	adapter DataAdapter, changes map[string]interface{}, timestamps map[string]time.Time,
+) {
+	provider.Lock()
+	defer provider.Unlock()
+
+	dataPoints := make(map[string]DataPoint)
+	subscribePaths := make(map[uint64][]string)
+
+	for path, value := range changes {
+		// Sensor could be moved to another adapter on reload
+		sensor, ok := provider.sensors[path]
+		if !ok || sensor.adapter != adapter {
+			continue
+		}
+
+		dataPoints[path] = newDataPoint(value, timestamps[path])
+
+		if sensor.history != nil {
+			sensor.history.add(dataPoints[path])
+		}
+
+		for idElement := sensor.subscribeIds.Front(); idElement != nil; idElement = idElement.Next() {
+			id, ok := idElement.Value.(uint64)
+			if !ok {
+				log.Error("Wrong subscribe ID type")
+				break
+			}
+
+			// Paths are appended in the same order for all subscribers, so equal path sets have equal lists
+			subscribePaths[id] = append(subscribePaths[id], path)
+		}
+	}
+
+	notifications := make(map[string]*Notification)
+
+	// Notify subscribers by id
+	for id, paths := range subscribePaths {
+		subscription := provider.subscribeInfoMap[id]
+
+		// Changes received before snapshot are sent as part of it
+		if subscription.snapshotPending {
+			for _, path := range paths {
+				subscription.pendingData[path] = dataPoints[path]
+			}
+
+			continue
+		}
+
+		key := strings.Join(paths, "\n")
+
+		notification, ok := notifications[key]
+		if !ok {
+			notification = &Notification{Data: make(map[string]DataPoint, len(paths))}
+
+			for _, path := range paths {
+				notification.Data[path] = dataPoints[path]
+			}
+
+			notifications[key] = notification
+		}
+
+		log.WithFields(log.Fields{"subscriberID": id, "data": notification.Data}).Debug("Notify subscribers")
+
+		dropped, ok := subscription.push(notification)
+
+		provider.droppedNotifications.Add(dropped)
+
+		// Subscription is terminated asynchronously as adapter unsubscribe could wait for its channel handling
+		if !ok {
+			go provider.terminateSubscription(id)
+		}
+	}
+}
+
+// sendSnapshot reads values of subscribed paths and pushes them as the first subscription notification.
+func (provider *DataProvider) sendSnapshot(
+	subscription *Subscription, subscribeMap map[DataAdapter][]string,
+) (err error) {
+	dataPoints := make(map[string]DataPoint)
+
+	for adapter, pathList := range subscribeMap {
+		data, err := getAdapterData(adapter, pathList)
 		if err != nil {
 			return aoserrors.Wrap(err)
 		}
 
-		if filter.Match(path) && strings.Contains(strings.ToLower(value), strings.ToLower(permissions)) {
-			log.WithFields(log.Fields{
-				"path":        path,
-				"permissions": value,
-			}).Debug("Data permissions")
+		timestamps, err := adapter.GetTimestamps(pathList)
+		if err != nil {
+			return aoserrors.Wrap(err)
+		}
 
-			return nil
+		for path, value := range data {
+			dataPoints[path] = newDataPoint(value, timestamps[path])
 		}
 	}
 
-	return aoserrors.New("client does not have permissions")
+	provider.Lock()
+	defer provider.Unlock()
+
+	for path, dataPoint := range subscription.pendingData {
+		dataPoints[path] = dataPoint
+	}
+
+	subscription.snapshotPending = false
+	subscription.pendingData = nil
+
+	log.WithFields(log.Fields{"subscriberID": subscription.ID, "data": dataPoints}).Debug("Send snapshot")
+
+	// Queue is empty as nothing is pushed before snapshot
+	dropped, _ := subscription.push(&Notification{Data: dataPoints, Snapshot: true})
+
+	provider.droppedNotifications.Add(dropped)
+
+	return nil
 }
 
-func convertData(requestedPath string, data map[string]interface{}) (result interface{}) {
-	// Group by parent map[parent] -> (map[path] -> value)
-	parentDataMap := make(map[string]map[string]interface{})
+func (provider *DataProvider) addMetadataNode(
+	tree map[string]*MetadataNode, path string, signalMetadata *SignalMetadata,
+) {
+	names := strings.Split(path, ".")
 
-	for path, value := range data {
-		parent := getParentPath(path)
-		if parentDataMap[parent] == nil {
-			parentDataMap[parent] = make(map[string]interface{})
+	for i, name := range names {
+		node, ok := tree[name]
+		if !ok {
+			node = &MetadataNode{SignalMetadata: SignalMetadata{Type: NodeTypeBranch}}
+
+			if branchMetadata, ok := provider.catalog[strings.Join(names[:i+1], ".")]; ok {
+				node.Description = branchMetadata.Description
+			}
+
+			tree[name] = node
 		}
 
-		parentDataMap[parent][path] = value
+		if i == len(names)-1 {
+			node.SignalMetadata = *signalMetadata
+
+			if node.Type == "" {
+				node.Type = getDefaultNodeType(path, signalMetadata.Writable)
+			}
+
+			return
+		}
+
+		if node.Children == nil {
+			node.Children = make(map[string]*MetadataNode)
+		}
+
+		tree = node.Children
 	}
+}
 
-	// make array from map
-	dataArray := make([]map[string]interface{}, 0, len(parentDataMap))
+// validateData checks values against datatype and constraints of adapter and VSS catalog metadata.
+func (provider *DataProvider) validateData(adapter DataAdapter, visData map[string]interface{}) (err error) {
+	pathList := make([]string, 0, len(visData))
 
-	for _, value := range parentDataMap {
-		dataArray = append(dataArray, value)
+	for path := range visData {
+		pathList = append(pathList, path)
 	}
 
-	// VIS defines 3 forms of returning result:
-	// * simple value if it is one signal
-	// * map[path]value if result belongs to same parent
-	// * []map[path]value if result belongs to different parents
-	//
-	// It is unclear from spec how to combine results in one map.
-	// By which criteria we should put data to one map or to array element.
-	// For now it is combined by parent node.
+	adapterMetadata, err := adapter.GetMetadata(pathList)
+	if err != nil {
+		return aoserrors.Wrap(err)
+	}
 
-	if len(dataArray) == 1 {
-		if len(dataArray[0]) == 1 {
-			for path, value := range dataArray[0] {
-				if path == requestedPath {
-					// return simple value
-					return value
-				}
+	for path, value := range visData {
+		signalMetadata := adapterMetadata[path]
+
+		if catalogMetadata := provider.getCatalogMetadata(path); catalogMetadata != nil {
+			if signalMetadata == nil {
+				signalMetadata = &SignalMetadata{}
 			}
+
+			applyCatalogMetadata(signalMetadata, catalogMetadata)
+		}
+
+		if err = validateValue(path, value, signalMetadata); err != nil {
+			return err
 		}
-		// return map of same parent
-		return dataArray[0]
 	}
-	// return array of different parents
-	return dataArray
+
+	return nil
+}
+
+// matchSensors returns paths matched to filter grouped by adapter.
+func (provider *DataProvider) matchSensors(filter *PathFilter) (adapterPathMap map[DataAdapter][]string) {
+	provider.RLock()
+	defer provider.RUnlock()
+
+	adapterPathMap = make(map[DataAdapter][]string)
+
+	for _, path := range provider.sensorIndex.Find(filter) {
+		sensor := provider.sensors[path]
+
+		if adapterPathMap[sensor.adapter] == nil {
+			adapterPathMap[sensor.adapter] = make([]string, 0, numPreallocatedPathes)
+		}
+
+		adapterPathMap[sensor.adapter] = append(adapterPathMap[sensor.adapter], path)
+	}
+
+	return adapterPathMap
+}
+
+// getCatalogMetadata returns VSS catalog metadata of sensor path.
+func (provider *DataProvider) getCatalogMetadata(path string) (metadata *SignalMetadata) {
+	provider.RLock()
+	defer provider.RUnlock()
+
+	if sensor, ok := provider.sensors[path]; ok {
+		return sensor.metadata
+	}
+
+	return nil
+}
+
+// isSubscriptionRequired returns true if adapter path should stay subscribed: paths with history or recorded paths keep
+// receiving changes without subscribers.
+func (provider *DataProvider) isSubscriptionRequired(sensor *sensorDescription) (result bool) {
+	return sensor.subscribeIds.Len() != 0 || sensor.history != nil || (sensor.recorded && provider.recorder.isActive())
+}
+
+func getDefaultNodeType(path string, writable bool) (nodeType string) {
+	switch {
+	case strings.HasPrefix(path, "Attribute."):
+		return NodeTypeAttribute
+
+	case writable:
+		return NodeTypeActuator
+
+	default:
+		return NodeTypeSensor
+	}
+}
+
+// newDataPoint creates data point. Current time is used if sample time is unknown.
+func newDataPoint(value interface{}, timestamp time.Time) (dataPoint DataPoint) {
+	if timestamp.IsZero() {
+		timestamp = time.Now()
+	}
+
+	return DataPoint{Value: value, Timestamp: timestamp}
+}
+
+func getParentPath(path string) (parent string) {
+	return path[:strings.LastIndex(path, ".")]
+}
+
+// checkPathPermissions checks client permissions for all paths grouped by adapter.
+func checkPathPermissions(adapterPathMap map[DataAdapter][]string, authInfo *AuthInfo, permissions string) (err error) {
+	for adapter, pathList := range adapterPathMap {
+		for _, path := range pathList {
+			if err = checkPermissions(adapter, path, authInfo, permissions); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func checkPermissions(adapter DataAdapter, path string, authInfo *AuthInfo, permissions string) (err error) {
+	if authInfo == nil {
+		return nil
+	}
+
+	isPublic, err := adapter.IsPathPublic(path)
+	if err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	if !authInfo.IsAuthorized && !isPublic {
+		return aoserrors.New("client is not authorized")
+	}
+
+	if isPublic {
+		return nil
+	}
+
+	pathSlice := strings.Split(path, ".")
+	permissions = strings.ToLower(permissions)
+
+	// Check permission
+	for _, mask := range authInfo.permissionMasks {
+		if mask.filter.matchSegments(pathSlice) && strings.Contains(mask.permissions, permissions) {
+			log.WithFields(log.Fields{
+				"path":        path,
+				"permissions": mask.permissions,
+			}).Debug("Data permissions")
+
+			return nil
+		}
+	}
+
+	return aoserrors.New("client does not have permissions")
 }
 
 // Create map from data. According to VIS spec data could be array of map,
@@ -20,9 +20,13 @@ package dataprovider_test
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"os"
+	"path/filepath"
 	"reflect"
+	"sort"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -47,12 +51,22 @@ func init() {
 	log.SetOutput(os.Stdout)
 }
 
+/*******************************************************************************
+ * Consts
+ ******************************************************************************/
+
+const benchmarkPathsGroups = 20
+
 /*******************************************************************************
  * Vars
  ******************************************************************************/
 
 var provider *dataprovider.DataProvider
 
+var benchmarkMasks = []string{ //nolint:gochecknoglobals // benchmark data
+	"Signal.Group1.Item1.Value1", "Signal.Group1.Item1", "Signal.*.Item1.Value1", "Signal.*",
+}
+
 /*******************************************************************************
  * Main
  ******************************************************************************/
@@ -67,17 +81,22 @@ func TestMain(m *testing.M) {
 			"Params": {
 				"Data" : {
 					"Attribute.Vehicle.VehicleIdentification.VIN":    {"Value": "TestVIN", "Public": true,"ReadOnly": true},
-					"Attribute.Aos.Subjects":     {"Value": ["Subject1", "Provider1"], "Public": true},
+					"Attribute.Aos.Subjects":     {"Value": ["Subject1", "Provider1"], "Public": true, "DataType": "string[]"},
 
-					"Signal.Drivetrain.InternalCombustionEngine.RPM": {"Value": 1000, "ReadOnly": true},
+					"Signal.Drivetrain.InternalCombustionEngine.RPM": {
+						"Value": 1000, "ReadOnly": true, "DataType": "uint16", "Unit": "rpm", "Max": 20000
+					},
 
 					"Signal.Body.Trunk.IsLocked":                     {"Value": false},
-					"Signal.Body.Trunk.IsOpen":                       {"Value": true},
+					"Signal.Body.Trunk.IsOpen":                       {"Value": true, "DataType": "boolean"},
+					"Signal.Body.Lights.Beam.Mode": {
+						"Value": "OFF", "DataType": "string", "Allowed": ["OFF", "LOW", "HIGH"]
+					},
 
 					"Signal.Cabin.Door.Row1.Right.IsLocked":          {"Value": true},
 					"Signal.Cabin.Door.Row1.Right.Window.Position":   {"Value": 50},
 					"Signal.Cabin.Door.Row1.Left.IsLocked":           {"Value": true},
-					"Signal.Cabin.Door.Row1.Left.Window.Position":    {"Value": 23},
+					"Signal.Cabin.Door.Row1.Left.Window.Position":    {"Value": 23, "DataType": "uint8", "Max": 100},
 					"Signal.Cabin.Door.Row2.Right.IsLocked":          {"Value": false},
 					"Signal.Cabin.Door.Row2.Right.Window.Position":   {"Value": 100},
 					"Signal.Cabin.Door.Row2.Left.IsLocked":           {"Value": true},
@@ -415,277 +434,1347 @@ func TestSetData(t *testing.T) {
 	}
 }
 
-func TestPermissions(t *testing.T) {
-	// Check public path for not authorized client
-	_, err := provider.GetData("Attribute.Vehicle.VehicleIdentification.VIN", &dataprovider.AuthInfo{})
+func TestGetMetadata(t *testing.T) {
+	metadata, err := provider.GetMetadata("Signal.Drivetrain.*", nil)
 	if err != nil {
-		t.Errorf("Can't get data: %s", err)
+		t.Fatalf("Can't get metadata: %s", err)
 	}
 
-	// Check private path for not authorized client
-	_, err = provider.GetData("Signal.Drivetrain.InternalCombustionEngine.RPM", &dataprovider.AuthInfo{})
-	if err == nil {
-		t.Error("Path should not be accessible")
-	} else if !strings.Contains(err.Error(), "not authorized") {
-		t.Errorf("Wrong error type: %s", err)
+	signal, ok := metadata["Signal"]
+	if !ok || signal.Type != dataprovider.NodeTypeBranch {
+		t.Fatal("Signal branch not found")
 	}
 
-	// Check authorized but not permitted
-	_, err = provider.GetData("Signal.Drivetrain.InternalCombustionEngine.RPM",
-		&dataprovider.AuthInfo{IsAuthorized: true, Permissions: map[string]string{}})
-	if err == nil {
-		t.Error("Path should not be accessible")
-	} else if !strings.Contains(err.Error(), "not have permissions") {
-		t.Errorf("Wrong error type: %s", err)
+	if len(signal.Children) != 1 {
+		t.Errorf("Wrong signal children count: %d", len(signal.Children))
 	}
 
-	// Check read permissions
-	_, err = provider.GetData("Signal.Drivetrain.InternalCombustionEngine.RPM",
-		&dataprovider.AuthInfo{
-			IsAuthorized: true,
-			Permissions:  map[string]string{"Signal.Drivetrain.InternalCombustionEngine.RPM": "r"},
-		})
-	if err != nil {
-		t.Errorf("Can't get data: %s", err)
+	engine := signal.Children["Drivetrain"].Children["InternalCombustionEngine"]
+	if engine == nil || engine.Type != dataprovider.NodeTypeBranch {
+		t.Fatal("Engine branch not found")
 	}
 
-	// Check no write permissions
-	err = provider.SetData("Signal.Cabin.Door.Row1.Right.Window.Position", 0,
-		&dataprovider.AuthInfo{IsAuthorized: true, Permissions: map[string]string{"Signal.Cabin.Door.*": "r"}})
-	if err == nil {
-		t.Error("Path should not be accessible")
-	} else if !strings.Contains(err.Error(), "not have permissions") {
-		t.Errorf("Wrong error type: %s", err)
+	rpm, ok := engine.Children["RPM"]
+	if !ok {
+		t.Fatal("RPM node not found")
 	}
 
-	// Check write permissions
-	err = provider.SetData("Signal.Cabin.Door.Row1.Right.Window.Position", 0,
-		&dataprovider.AuthInfo{IsAuthorized: true, Permissions: map[string]string{"Signal.Cabin.Door.*": "rw"}})
-	if err != nil {
-		t.Errorf("Can't set data: %s", err)
+	if rpm.Type != dataprovider.NodeTypeSensor || rpm.DataType != "uint16" || rpm.Unit != "rpm" ||
+		!rpm.Readable || rpm.Writable || rpm.Public {
+		t.Errorf("Wrong RPM metadata: %v", rpm.SignalMetadata)
 	}
-}
 
-func TestSubscribe(t *testing.T) {
-	// Clear all locks
-	if err := provider.SetData("Signal.Cabin.Door.*.IsLocked", []interface{}{
-		map[string]interface{}{"Row1.Right.IsLocked": false},
-		map[string]interface{}{"Row1.Left.IsLocked": false},
-		map[string]interface{}{"Row2.Right.IsLocked": false},
-		map[string]interface{}{"Row2.Left.IsLocked": false},
-	}, nil); err != nil {
-		t.Errorf("Can't set data: %s", err)
+	if metadata, err = provider.GetMetadata("Signal.Body.Trunk.IsLocked", nil); err != nil {
+		t.Fatalf("Can't get metadata: %s", err)
 	}
 
-	// Subscribes for all door locks
-	id1, channel1, err := provider.Subscribe("Signal.Cabin.Door.*", nil)
-	if err != nil {
-		t.Errorf("Can't subscribe: %s", err)
+	if metadata["Signal"].Children["Body"].Children["Trunk"].Children["IsLocked"].Type != dataprovider.NodeTypeActuator {
+		t.Error("Wrong trunk lock node type")
 	}
 
-	// Subscribes for row1 door locks
-	id2, channel2, err := provider.Subscribe("Signal.Cabin.Door.Row1.*", nil)
-	if err != nil {
-		t.Errorf("Can't get data: %s", err)
+	if metadata, err = provider.GetMetadata("Attribute.*", nil); err != nil {
+		t.Fatalf("Can't get metadata: %s", err)
 	}
 
-	if len(provider.GetSubscribeIDs()) != 2 {
-		t.Errorf("Wrong subscribers count: %d", len(provider.GetSubscribeIDs()))
+	if len(metadata["Attribute"].Children) != 2 {
+		t.Errorf("Wrong attribute children count: %d", len(metadata["Attribute"].Children))
 	}
 
-	// Set all locks
-	if err = provider.SetData("Signal.Cabin.Door.*.IsLocked", []interface{}{
-		map[string]interface{}{"Row1.Right.IsLocked": true},
-		map[string]interface{}{"Row1.Left.IsLocked": true},
-		map[string]interface{}{"Row2.Right.IsLocked": true},
-		map[string]interface{}{"Row2.Left.IsLocked": true},
-	}, nil); err != nil {
-		t.Errorf("Can't set data: %s", err)
+	// Not authorized client gets public paths only
+	if metadata, err = provider.GetMetadata("*", &dataprovider.AuthInfo{}); err != nil {
+		t.Fatalf("Can't get metadata: %s", err)
 	}
 
-	timeout := false
-	eventChannel1 := false
-	eventChannel2 := false
+	if _, ok := metadata["Signal"]; ok {
+		t.Error("Private signals should not be accessible")
+	}
 
-	for {
-		select {
-		case data := <-channel1:
-			data1, err := arrayToMap(data)
-			if err != nil {
-				t.Error(err)
-			}
+	if _, err = provider.GetMetadata("Signal.Body.*", &dataprovider.AuthInfo{}); err == nil ||
+		!strings.Contains(err.Error(), "not authorized") {
+		t.Errorf("Wrong error type: %v", err)
+	}
 
-			if len(data1) != 4 {
-				t.Errorf("Wrong data size: %d", len(data1))
-			}
+	if _, err = provider.GetMetadata("Body.Flux.Capacitor", nil); err == nil ||
+		!strings.Contains(err.Error(), "not exist") {
+		t.Errorf("Wrong error type: %v", err)
+	}
+}
 
-			if data1["Signal.Cabin.Door.Row1.Right.IsLocked"] != true {
-				t.Errorf("Data mistmatch: %v", false)
+func TestVSSCatalog(t *testing.T) {
+	catalogJSON := `{
+	"Signal": {
+		"type": "branch",
+		"description": "All signals",
+		"children": {
+			"Body": {
+				"type": "branch",
+				"children": {
+					"Trunk": {
+						"type": "branch",
+						"description": "Trunk status",
+						"children": {
+							"IsLocked": {"type": "actuator", "datatype": "boolean", "description": "Is trunk locked"},
+							"IsOpen": {"type": "actuator", "datatype": "boolean"}
+						}
+					}
+				}
+			},
+			"Drivetrain": {
+				"type": "branch",
+				"children": {
+					"InternalCombustionEngine": {
+						"type": "branch",
+						"children": {
+							"RPM": {"type": "sensor", "datatype": "uint16", "unit": "rpm", "min": 0, "max": 8000}
+						}
+					}
+				}
 			}
-
-			if data1["Signal.Cabin.Door.Row1.Left.IsLocked"] != true {
-				t.Errorf("Data mistmatch: %v", false)
+		}
+	},
+	"Types": {
+		"type": "branch",
+		"children": {
+			"DeliveryInfo": {
+				"type": "struct",
+				"children": {"Address": {"type": "property", "datatype": "string"}}
 			}
+		}
+	}
+}`
 
-			if data1["Signal.Cabin.Door.Row2.Right.IsLocked"] != true {
-				t.Errorf("Data mistmatch: %v", false)
-			}
+	catalogFile := filepath.Join(t.TempDir(), "vss.json")
 
-			if data1["Signal.Cabin.Door.Row2.Left.IsLocked"] != true {
-				t.Errorf("Data mistmatch: %v", false)
-			}
+	if err := os.WriteFile(catalogFile, []byte(catalogJSON), 0o600); err != nil {
+		t.Fatalf("Can't write VSS catalog: %s", err)
+	}
 
-			eventChannel1 = true
-		case data := <-channel2:
-			data2, err := arrayToMap(data)
-			if err != nil {
-				t.Error(err)
-			}
+	cfg := config.Config{
+		VSSCatalog: catalogFile,
+		Adapters: []config.AdapterConfig{{Plugin: "testadapter", Params: json.RawMessage(`{"Data": {
+			"Signal.Drivetrain.InternalCombustionEngine.RPM": {"Value": 1000, "ReadOnly": true, "Max": 20000},
+			"Signal.Body.Trunk.IsLocked":                     {"Value": false},
+			"Signal.Body.Trunk":                              {"Value": false},
+			"Signal.Cabin.Door.Row1.Right.IsLocked":          {"Value": true}
+		}}`)}},
+	}
 
-			if len(data2) != 2 {
-				t.Errorf("Wrong data size: %d", len(data2))
-			}
+	catalogProvider, err := dataprovider.New(&cfg)
+	if err != nil {
+		t.Fatalf("Can't create data provider: %s", err)
+	}
+	defer catalogProvider.Close()
 
-			if data2["Signal.Cabin.Door.Row1.Right.IsLocked"] != true {
-				t.Errorf("Data mistmatch: %v", false)
-			}
+	if _, err = catalogProvider.GetData("Signal.Body.Trunk.IsLocked", nil); err != nil {
+		t.Errorf("Can't get data: %s", err)
+	}
 
-			if data2["Signal.Cabin.Door.Row1.Left.IsLocked"] != true {
-				t.Errorf("Data mistmatch: %v", false)
-			}
+	// Path which is not in catalog should be rejected
+	if _, err = catalogProvider.GetData("Signal.Cabin.Door.Row1.Right.IsLocked", nil); err == nil ||
+		!strings.Contains(err.Error(), "not exist") {
+		t.Errorf("Wrong error type: %v", err)
+	}
 
-			eventChannel2 = true
-		case <-time.After(100 * time.Millisecond):
-			timeout = true
-		}
+	metadata, err := catalogProvider.GetMetadata("Signal.*", nil)
+	if err != nil {
+		t.Fatalf("Can't get metadata: %s", err)
+	}
 
-		if eventChannel1 && eventChannel2 {
-			break
-		}
+	if metadata["Signal"].Description != "All signals" {
+		t.Errorf("Wrong branch description: %s", metadata["Signal"].Description)
+	}
 
-		if timeout {
-			t.Error("Waiting for data change timeout")
-			break
-		}
+	// Nodes of unsupported types are skipped
+	if _, err = catalogProvider.GetMetadata("Types.*", nil); err == nil {
+		t.Error("Struct types should not be provided")
 	}
 
-	// Unsubscribes id1
-	if err = provider.Unsubscribe(id1, nil); err != nil {
-		t.Errorf("Can't unsubscribe: %s", err)
+	// Path which is branch in catalog should be rejected
+	if trunk := metadata["Signal"].Children["Body"].Children["Trunk"]; trunk.Type != dataprovider.NodeTypeBranch ||
+		trunk.Description != "Trunk status" || len(trunk.Children) != 1 {
+		t.Errorf("Wrong trunk metadata: %v", trunk.SignalMetadata)
 	}
 
-	if len(provider.GetSubscribeIDs()) != 1 {
-		t.Errorf("Wrong subscribers count: %d", len(provider.GetSubscribeIDs()))
+	rpm := metadata["Signal"].Children["Drivetrain"].Children["InternalCombustionEngine"].Children["RPM"]
+
+	if rpm.Unit != "rpm" || rpm.DataType != "uint16" || rpm.Max != json.Number("8000") {
+		t.Errorf("Wrong RPM metadata: %v", rpm.SignalMetadata)
 	}
 
-	// Clear all locks
-	if err = provider.SetData("Signal.Cabin.Door.*.IsLocked", []interface{}{
-		map[string]interface{}{"Row1.Right.IsLocked": false},
-		map[string]interface{}{"Row1.Left.IsLocked": false},
-		map[string]interface{}{"Row2.Right.IsLocked": false},
-		map[string]interface{}{"Row2.Left.IsLocked": false},
-	}, nil); err != nil {
-		t.Errorf("Can't set data: %s", err)
+	// Invalid catalog
+	if err := os.WriteFile(catalogFile, []byte(`{"Signal": {"type": "sensor"}}`), 0o600); err != nil {
+		t.Fatalf("Can't write VSS catalog: %s", err)
 	}
 
-	timeout, eventChannel1, eventChannel2 = false, false, false
+	if _, err = dataprovider.New(&cfg); err == nil {
+		t.Error("Error expected for invalid VSS catalog")
+	}
 
-	for {
-		select {
-		case _, ok := <-channel1:
-			if ok {
-				t.Error("Unexpected data received")
-			}
+	// Paths which are not in catalog are rejected in strict mode
+	if err := os.WriteFile(catalogFile, []byte(catalogJSON), 0o600); err != nil {
+		t.Fatalf("Can't write VSS catalog: %s", err)
+	}
 
-			eventChannel1 = true
-		case _, ok := <-channel2:
-			if !ok {
-				t.Error("Channel should not be closed")
-			}
+	cfg.VSSCatalogStrict = true
 
-			eventChannel2 = true
-		case <-time.After(100 * time.Millisecond):
-			timeout = true
-		}
+	if _, err = dataprovider.New(&cfg); err == nil || !strings.Contains(err.Error(), "not found in VSS catalog") {
+		t.Errorf("Wrong strict mode error: %v", err)
+	}
+}
 
-		if eventChannel1 && eventChannel2 {
-			break
-		}
+func TestGetDataPoints(t *testing.T) {
+	setTime := time.Now()
 
-		if timeout {
-			break
-		}
+	if err := provider.SetData("Signal.Cabin.Door.Row2.Left.Window.Position", 10, nil); err != nil {
+		t.Fatalf("Can't set data: %s", err)
 	}
 
-	// Unsubscribes id2
-	if err = provider.Unsubscribe(id2, nil); err != nil {
-		t.Errorf("Can't unsubscribe: %s", err)
+	dataPoints, err := provider.GetDataPoints("Signal.Cabin.Door.Row2.Left.*", nil)
+	if err != nil {
+		t.Fatalf("Can't get data points: %s", err)
 	}
 
-	if len(provider.GetSubscribeIDs()) != 0 {
-		t.Errorf("Wrong subscribers count: %d", len(provider.GetSubscribeIDs()))
+	if len(dataPoints) != 2 {
+		t.Fatalf("Wrong data points count: %d", len(dataPoints))
 	}
 
-	// Set all locks
-	if err = provider.SetData("Signal.Cabin.Door.*.IsLocked", []interface{}{
-		map[string]interface{}{"Row1.Right.IsLocked": true},
-		map[string]interface{}{"Row1.Left.IsLocked": true},
-		map[string]interface{}{"Row2.Right.IsLocked": true},
-		map[string]interface{}{"Row2.Left.IsLocked": true},
-	}, nil); err != nil {
-		t.Errorf("Can't set data: %s", err)
+	position := dataPoints["Signal.Cabin.Door.Row2.Left.Window.Position"]
+
+	if position.Value != 10 {
+		t.Errorf("Wrong value: %v", position.Value)
 	}
 
-	timeout, eventChannel1, eventChannel2 = false, false, false
+	if position.Timestamp.Before(setTime) || position.Timestamp.After(time.Now()) {
+		t.Errorf("Wrong timestamp: %v", position.Timestamp)
+	}
 
-	for {
-		select {
-		case _, ok := <-channel1:
-			if ok {
-				t.Error("Unexpected data received")
-			}
+	// Sample time of never set value is unknown, current time should be used
+	if dataPoints["Signal.Cabin.Door.Row2.Left.IsLocked"].Timestamp.IsZero() {
+		t.Error("Timestamp should be set")
+	}
 
-			eventChannel1 = true
-		case _, ok := <-channel2:
-			if ok {
-				t.Error("Unexpected data received")
+	if _, err = provider.GetDataPoints("Signal.Body.Flux", nil); err == nil ||
+		!strings.Contains(err.Error(), "not exist") {
+		t.Errorf("Wrong error type: %v", err)
+	}
+}
+
+func TestSetDataValidation(t *testing.T) {
+	type testData struct {
+		path  string
+		value interface{}
+		err   string
+	}
+
+	testItems := []testData{
+		{path: "Signal.Body.Trunk.IsOpen", value: false},
+		{path: "Signal.Body.Trunk.IsOpen", value: "open", err: "is not boolean"},
+		{path: "Signal.Cabin.Door.Row1.Left.Window.Position", value: 75},
+		{path: "Signal.Cabin.Door.Row1.Left.Window.Position", value: 12.5, err: "is not uint8"},
+		{path: "Signal.Cabin.Door.Row1.Left.Window.Position", value: -1, err: "out of uint8 range"},
+		{path: "Signal.Cabin.Door.Row1.Left.Window.Position", value: 150, err: "greater than max"},
+		{path: "Signal.Body.Lights.Beam.Mode", value: "HIGH"},
+		{path: "Signal.Body.Lights.Beam.Mode", value: "FOG", err: "not in allowed values"},
+		{path: "Attribute.Aos.Subjects", value: []interface{}{"Subject2"}},
+		{path: "Attribute.Aos.Subjects", value: []interface{}{"Subject2", 3}, err: "is not string"},
+		{path: "Attribute.Aos.Subjects", value: "Subject2", err: "is not string[]"},
+	}
+
+	for _, item := range testItems {
+		err := provider.SetData(item.path, item.value, nil)
+
+		if item.err == "" {
+			if err != nil {
+				t.Errorf("Can't set data %s: %s", item.path, err)
 			}
 
-			eventChannel2 = true
-		case <-time.After(100 * time.Millisecond):
-			timeout = true
+			continue
 		}
 
-		if eventChannel1 && eventChannel2 {
-			break
+		if err == nil {
+			t.Errorf("Error expected for %s value: %v", item.path, item.value)
+			continue
 		}
 
-		if timeout {
-			break
+		if !strings.Contains(err.Error(), item.err) || !strings.Contains(err.Error(), item.path) {
+			t.Errorf("Wrong error type: %s", err)
 		}
 	}
+
+	if err := provider.SetData("Signal.Body.Trunk.IsLocked", false, nil); err != nil {
+		t.Fatalf("Can't set data: %s", err)
+	}
+
+	// Invalid value should prevent setting of all requested pathes
+	if err := provider.SetData("Signal.Body.Trunk.*", []interface{}{
+		map[string]interface{}{"IsLocked": true},
+		map[string]interface{}{"IsOpen": 1},
+	}, nil); err == nil {
+		t.Error("Error expected for invalid value")
+	}
+
+	value, err := provider.GetData("Signal.Body.Trunk.IsLocked", nil)
+	if err != nil {
+		t.Fatalf("Can't get data: %s", err)
+	}
+
+	if value != false {
+		t.Errorf("Value should not be changed: %v", value)
+	}
 }
 
-func TestPathFilter(t *testing.T) {
-	type resultDesc struct {
-		path  string
-		match bool
+func TestSetDataWithChanges(t *testing.T) {
+	if err := provider.SetData("Signal.Body.Trunk.*", []interface{}{
+		map[string]interface{}{"IsLocked": false},
+		map[string]interface{}{"IsOpen": false},
+	}, nil); err != nil {
+		t.Fatalf("Can't set data: %s", err)
 	}
 
-	type testDesc struct {
-		filter string
-		result []resultDesc
+	changes, err := provider.SetDataWithChanges("Signal.Body.Trunk.*", []interface{}{
+		map[string]interface{}{"IsOpen": true},
+		map[string]interface{}{"IsLocked": true},
+	}, nil)
+	if err != nil {
+		t.Fatalf("Can't set data: %s", err)
 	}
 
-	testData := []testDesc{
+	expectedChanges := []dataprovider.DataChange{
+		{Path: "Signal.Body.Trunk.IsLocked", OldValue: false, NewValue: true},
+		{Path: "Signal.Body.Trunk.IsOpen", OldValue: false, NewValue: true},
+	}
+
+	if !reflect.DeepEqual(changes, expectedChanges) {
+		t.Errorf("Wrong data changes: %v", changes)
+	}
+
+	if changes, err = provider.SetDataWithChanges("Signal.Body.Trunk.IsOpen", "open", nil); err == nil {
+		t.Error("Error expected for invalid value")
+	}
+
+	if changes != nil {
+		t.Errorf("No changes expected on error: %v", changes)
+	}
+}
+
+func TestHistory(t *testing.T) {
+	configJSON := `{
+	"History": [{"Path": "Signal.Vehicle.*", "MaxCount": 3}],
+	"Adapters":[
 		{
-			filter: "Sensors.Vehicle.Door",
-			result: []resultDesc{
-				{"Sensors.Vehicle.Door", true},
-				{"Sensors.Vehicle.DoorFront", false},
-				{"Sensors.Vehicle.DoorRear", false},
-			},
-		},
+			"Plugin":"testadapter",
+			"Params": {
+				"Data" : {
+					"Signal.Vehicle.Speed":  {"Value": 0},
+					"Signal.Vehicle.Gear":   {"Value": 1},
+					"Signal.Cabin.Light":    {"Value": false}
+				}
+			}
+		}
+	]
+}`
+
+	var cfg config.Config
+
+	if err := json.Unmarshal([]byte(configJSON), &cfg); err != nil {
+		t.Fatalf("Can't parse config: %s", err)
+	}
+
+	historyProvider, err := dataprovider.New(&cfg)
+	if err != nil {
+		t.Fatalf("Can't create data provider: %s", err)
+	}
+	defer historyProvider.Close()
+
+	// Subscription should not stop history recording after unsubscribe
+	id, _, err := historyProvider.Subscribe("Signal.Vehicle.Speed", nil)
+	if err != nil {
+		t.Fatalf("Can't subscribe: %s", err)
+	}
+
+	if err = historyProvider.Unsubscribe(id, nil); err != nil {
+		t.Fatalf("Can't unsubscribe: %s", err)
+	}
+
+	for _, speed := range []int{10, 20, 30} {
+		if err = historyProvider.SetData("Signal.Vehicle.Speed", speed, nil); err != nil {
+			t.Fatalf("Can't set data: %s", err)
+		}
+	}
+
+	if err = waitHistory(historyProvider, "Signal.Vehicle.Speed", []interface{}{10, 20, 30}); err != nil {
+		t.Errorf("Wrong history: %s", err)
+	}
+
+	history, err := historyProvider.GetHistory("Signal.Vehicle.*", 0, nil)
+	if err != nil {
+		t.Fatalf("Can't get history: %s", err)
+	}
+
+	if len(history) != 2 || len(history["Signal.Vehicle.Gear"]) != 1 {
+		t.Errorf("Wrong history: %v", history)
+	}
+
+	time.Sleep(100 * time.Millisecond)
+
+	if err = historyProvider.SetData("Signal.Vehicle.Speed", 40, nil); err != nil {
+		t.Fatalf("Can't set data: %s", err)
+	}
+
+	if err = waitHistory(historyProvider, "Signal.Vehicle.Speed", []interface{}{20, 30, 40}); err != nil {
+		t.Errorf("Wrong history: %s", err)
+	}
+
+	if history, err = historyProvider.GetHistory("Signal.Vehicle.Speed", 50*time.Millisecond, nil); err != nil {
+		t.Fatalf("Can't get history: %s", err)
+	}
+
+	if len(history["Signal.Vehicle.Speed"]) != 1 || history["Signal.Vehicle.Speed"][0].Value != 40 {
+		t.Errorf("Wrong history window: %v", history)
+	}
+
+	if _, err = historyProvider.GetHistory("Signal.Cabin.Light", 0, nil); err == nil ||
+		!strings.Contains(err.Error(), "history is not enabled") {
+		t.Errorf("Wrong error type: %v", err)
+	}
+
+	if _, err = historyProvider.GetHistory("Signal.Body.Flux", 0, nil); err == nil ||
+		!strings.Contains(err.Error(), "not exist") {
+		t.Errorf("Wrong error type: %v", err)
+	}
+
+	if _, err = historyProvider.GetHistory("Signal.Vehicle.Speed", 0, &dataprovider.AuthInfo{}); err == nil ||
+		!strings.Contains(err.Error(), "not authorized") {
+		t.Errorf("Wrong error type: %v", err)
+	}
+}
+
+func TestRecording(t *testing.T) {
+	recordingDir, err := os.MkdirTemp("", "vis_recording_")
+	if err != nil {
+		t.Fatalf("Can't create tmp dir: %s", err)
+	}
+	defer os.RemoveAll(recordingDir)
+
+	configJSON := `{
+	"Recording": {"Dir": "` + recordingDir + `", "Paths": ["Signal.Vehicle.*"], "MaxFileSize": 400, "Quota": 1200},
+	"Adapters":[
+		{
+			"Plugin":"testadapter",
+			"Params": {
+				"Data" : {
+					"Signal.Vehicle.Speed":  {"Value": 0},
+					"Signal.Cabin.Light":    {"Value": false}
+				}
+			}
+		}
+	]
+}`
+
+	var cfg config.Config
+
+	if err := json.Unmarshal([]byte(configJSON), &cfg); err != nil {
+		t.Fatalf("Can't parse config: %s", err)
+	}
+
+	recordingProvider, err := dataprovider.New(&cfg)
+	if err != nil {
+		t.Fatalf("Can't create data provider: %s", err)
+	}
+	defer recordingProvider.Close()
+
+	// Changes are not recorded before start
+	if err = recordingProvider.SetData("Signal.Vehicle.Speed", 5, nil); err != nil {
+		t.Fatalf("Can't set data: %s", err)
+	}
+
+	if err = recordingProvider.StartRecording(); err != nil {
+		t.Fatalf("Can't start recording: %s", err)
+	}
+
+	if !recordingProvider.IsRecording() {
+		t.Error("Recording should be active")
+	}
+
+	for _, item := range []struct {
+		path  string
+		value interface{}
+	}{
+		{"Signal.Vehicle.Speed", 10}, {"Signal.Cabin.Light", true}, {"Signal.Vehicle.Speed", 20},
+	} {
+		if err = recordingProvider.SetData(item.path, item.value, nil); err != nil {
+			t.Fatalf("Can't set data: %s", err)
+		}
+	}
+
+	if err = waitRecording(recordingDir, []interface{}{10.0, 20.0}); err != nil {
+		t.Errorf("Wrong recording: %s", err)
+	}
+
+	if recordedValues, err := readRecording(recordingDir); err != nil || len(recordedValues) != 2 {
+		t.Errorf("Wrong recorded values: %v, err: %v", recordedValues, err)
+	}
+
+	// Files are rotated and oldest ones are removed due to quota
+	values := make([]interface{}, 0, 30)
+
+	for speed := 100; speed < 130; speed++ {
+		if err = recordingProvider.SetData("Signal.Vehicle.Speed", speed, nil); err != nil {
+			t.Fatalf("Can't set data: %s", err)
+		}
+
+		values = append(values, float64(speed))
+	}
+
+	if err = waitRecording(recordingDir, values[len(values)-3:]); err != nil {
+		t.Errorf("Wrong recording: %s", err)
+	}
+
+	entries, err := os.ReadDir(recordingDir)
+	if err != nil {
+		t.Fatalf("Can't read recording dir: %s", err)
+	}
+
+	var totalSize int64
+
+	for _, entry := range entries {
+		info, err := entry.Info()
+		if err != nil {
+			t.Fatalf("Can't get file info: %s", err)
+		}
+
+		totalSize += info.Size()
+	}
+
+	if len(entries) < 2 || totalSize > 1200 {
+		t.Errorf("Wrong recording files: count %d, size %d", len(entries), totalSize)
+	}
+
+	if err = recordingProvider.StopRecording(); err != nil {
+		t.Fatalf("Can't stop recording: %s", err)
+	}
+
+	if recordingProvider.IsRecording() {
+		t.Error("Recording should be stopped")
+	}
+
+	// Changes are not recorded after stop
+	if err = recordingProvider.SetData("Signal.Vehicle.Speed", 200, nil); err != nil {
+		t.Fatalf("Can't set data: %s", err)
+	}
+
+	time.Sleep(100 * time.Millisecond)
+
+	recordedValues, err := readRecording(recordingDir)
+	if err != nil {
+		t.Fatalf("Can't read recording: %s", err)
+	}
+
+	if recordedValues[len(recordedValues)-1] != 129.0 {
+		t.Errorf("Unexpected recorded value: %v", recordedValues[len(recordedValues)-1])
+	}
+}
+
+func TestReload(t *testing.T) {
+	configJSON := `{
+	"History": [{"Path": "Signal.Vehicle.*", "MaxCount": 5}],
+	"Adapters":[
+		{
+			"Plugin":"testadapter",
+			"Params": {"Data" : {"Signal.Vehicle.Speed": {"Value": 0}, "Signal.Cabin.Light": {"Value": false}}}
+		},
+		{
+			"Plugin":"testadapter",
+			"Params": {"Data" : {"Signal.Body.Horn": {"Value": false}}}
+		}
+	]
+}`
+
+	var cfg config.Config
+
+	if err := json.Unmarshal([]byte(configJSON), &cfg); err != nil {
+		t.Fatalf("Can't parse config: %s", err)
+	}
+
+	reloadProvider, err := dataprovider.New(&cfg)
+	if err != nil {
+		t.Fatalf("Can't create data provider: %s", err)
+	}
+	defer reloadProvider.Close()
+
+	channels := make(map[string]<-chan *dataprovider.Notification)
+	ids := make(map[string]uint64)
+
+	for _, path := range []string{"Signal.Vehicle.Speed", "Signal.Cabin.Light", "Signal.Body.Horn"} {
+		if ids[path], channels[path], err = reloadProvider.Subscribe(path, nil); err != nil {
+			t.Fatalf("Can't subscribe: %s", err)
+		}
+	}
+
+	if err = reloadProvider.SetData("Signal.Vehicle.Speed", 10, nil); err != nil {
+		t.Fatalf("Can't set data: %s", err)
+	}
+
+	if err = waitNotification(channels["Signal.Vehicle.Speed"], "Signal.Vehicle.Speed", 10); err != nil {
+		t.Errorf("Wrong notification: %s", err)
+	}
+
+	// First adapter is changed, second one is kept as its config differs by formatting only
+	configJSON = `{
+	"Adapters":[
+		{
+			"Plugin":"testadapter",
+			"Params": {"Data" : {"Signal.Vehicle.Speed": {"Value": 20}, "Signal.Vehicle.Gear": {"Value": 1}}}
+		},
+		{
+			"Plugin":"testadapter",
+			"Params": {"Data" : {"Signal.Body.Horn":{"Value":false}}}
+		},
+		{
+			"Plugin":"testadapter", "Disabled": true,
+			"Params": {"Data" : {"Signal.Cabin.Light": {"Value": false}}}
+		}
+	]
+}`
+
+	if err = json.Unmarshal([]byte(configJSON), &cfg); err != nil {
+		t.Fatalf("Can't parse config: %s", err)
+	}
+
+	removed, err := reloadProvider.Reload(&cfg)
+	if err != nil {
+		t.Fatalf("Can't reload data provider: %s", err)
+	}
+
+	expectedRemoved := map[uint64]*dataprovider.RemovedPaths{
+		ids["Signal.Cabin.Light"]: {Paths: []string{"Signal.Cabin.Light"}, Terminated: true},
+	}
+
+	if !reflect.DeepEqual(removed, expectedRemoved) {
+		t.Errorf("Wrong removed paths: %v", removed)
+	}
+
+	if _, err = reloadProvider.GetData("Signal.Cabin.Light", nil); err == nil {
+		t.Error("Removed path should not be available")
+	}
+
+	if data, err := reloadProvider.GetData("Signal.Vehicle.Gear", nil); err != nil || data != json.Number("1") {
+		t.Errorf("Wrong added path data: %v, err: %v", data, err)
+	}
+
+	// History is kept on reload
+	if err = waitHistory(reloadProvider, "Signal.Vehicle.Speed",
+		[]interface{}{json.Number("0"), 10, json.Number("20")}); err != nil {
+		t.Errorf("Wrong history: %s", err)
+	}
+
+	// Subscriptions are kept on reload
+	for path, value := range map[string]interface{}{"Signal.Vehicle.Speed": 30, "Signal.Body.Horn": true} {
+		if err = reloadProvider.SetData(path, value, nil); err != nil {
+			t.Fatalf("Can't set data: %s", err)
+		}
+
+		if err = waitNotification(channels[path], path, value); err != nil {
+			t.Errorf("Wrong notification: %s", err)
+		}
+	}
+
+	// Configuration is not applied if any adapter can't be created
+	failedCfg := config.Config{Adapters: []config.AdapterConfig{
+		{Plugin: "testadapter", Params: json.RawMessage(`{"Data": {"Signal.Vehicle.Mileage": {"Value": 0}}}`)},
+		{Plugin: "unknownadapter"},
+	}}
+
+	if _, err = reloadProvider.Reload(&failedCfg); err == nil {
+		t.Error("Error expected")
+	}
+
+	if _, err = reloadProvider.GetData("Signal.Vehicle.Gear", nil); err != nil {
+		t.Errorf("Can't get data: %s", err)
+	}
+
+	if _, err = reloadProvider.GetData("Signal.Vehicle.Mileage", nil); err == nil {
+		t.Error("Path of not applied configuration should not be available")
+	}
+
+	// Empty adapters set is rejected
+	for i := range cfg.Adapters {
+		cfg.Adapters[i].Disabled = true
+	}
+
+	if _, err = reloadProvider.Reload(&cfg); err == nil {
+		t.Error("Error expected")
+	}
+
+	if _, err = reloadProvider.GetData("Signal.Body.Horn", nil); err != nil {
+		t.Errorf("Can't get data: %s", err)
+	}
+}
+
+func TestSubscriptionOverflow(t *testing.T) {
+	configJSON := `{
+	"Adapters":[
+		{
+			"Plugin":"testadapter",
+			"Params": {"Data" : {"Signal.Vehicle.Speed": {"Value": 0}}}
+		}
+	]
+}`
+
+	var cfg config.Config
+
+	if err := json.Unmarshal([]byte(configJSON), &cfg); err != nil {
+		t.Fatalf("Can't parse config: %s", err)
+	}
+
+	overflowProvider, err := dataprovider.New(&cfg)
+	if err != nil {
+		t.Fatalf("Can't create data provider: %s", err)
+	}
+	defer overflowProvider.Close()
+
+	if _, err = overflowProvider.SubscribeWithOptions(
+		"Signal.Vehicle.Speed", nil, dataprovider.SubscribeOptions{Overflow: "unknown"}); err == nil {
+		t.Error("Error expected")
+	}
+
+	testData := []struct {
+		overflow string
+		values   []interface{}
+		dropped  uint64
+		lost     uint64
+	}{
+		{overflow: dataprovider.OverflowDropNewest, values: []interface{}{1, 2}, dropped: 3, lost: 3},
+		{overflow: dataprovider.OverflowDropOldest, values: []interface{}{4, 5}, dropped: 3, lost: 3},
+		{overflow: dataprovider.OverflowCoalesce, values: []interface{}{5}, dropped: 4, lost: 0},
+		{overflow: dataprovider.OverflowDisconnect, values: []interface{}{1, 2}, dropped: 1, lost: 1},
+	}
+
+	var totalDropped uint64
+
+	for _, item := range testData {
+		if err = overflowProvider.SetData("Signal.Vehicle.Speed", 0, nil); err != nil {
+			t.Fatalf("Can't set data: %s", err)
+		}
+
+		subscription, err := overflowProvider.SubscribeWithOptions("Signal.Vehicle.Speed", nil,
+			dataprovider.SubscribeOptions{QueueSize: 2, Overflow: item.overflow})
+		if err != nil {
+			t.Fatalf("Can't subscribe: %s", err)
+		}
+
+		// Subscriber doesn't read notifications until all values are set
+		for speed := 1; speed <= 5; speed++ {
+			if err = overflowProvider.SetData("Signal.Vehicle.Speed", speed, nil); err != nil {
+				t.Fatalf("Can't set data: %s", err)
+			}
+		}
+
+		totalDropped += item.dropped
+
+		if err = waitDropped(overflowProvider, totalDropped); err != nil {
+			t.Errorf("Wrong %s dropped notifications: %s", item.overflow, err)
+		}
+
+		if subscription.Dropped() != item.dropped {
+			t.Errorf("Wrong %s subscription dropped notifications: %d", item.overflow, subscription.Dropped())
+		}
+
+		if lost := subscription.TakeLost(); lost != item.lost {
+			t.Errorf("Wrong %s lost notifications: %d", item.overflow, lost)
+		}
+
+		values := make([]interface{}, 0, len(item.values))
+
+	readLoop:
+		for range item.values {
+			select {
+			case notification := <-subscription.Channel:
+				values = append(values, notification.Data["Signal.Vehicle.Speed"].Value)
+
+			case <-time.After(time.Second):
+				break readLoop
+			}
+		}
+
+		if !reflect.DeepEqual(values, item.values) {
+			t.Errorf("Wrong %s notifications: %v", item.overflow, values)
+		}
+
+		if item.overflow != dataprovider.OverflowDisconnect {
+			if stats := overflowProvider.GetSubscriptionStats(); len(stats) != 1 ||
+				stats[0].ID != subscription.ID || stats[0].Dropped != item.dropped || stats[0].Queued != 0 {
+				t.Errorf("Wrong %s subscription stats: %v", item.overflow, stats)
+			}
+
+			if err = overflowProvider.Unsubscribe(subscription.ID, nil); err != nil {
+				t.Errorf("Can't unsubscribe: %s", err)
+			}
+
+			continue
+		}
+
+		// Overflowed subscription is terminated
+		select {
+		case _, more := <-subscription.Channel:
+			if more || !subscription.Overflowed() {
+				t.Error("Subscription should be terminated")
+			}
+
+		case <-time.After(time.Second):
+			t.Error("Wait subscription termination timeout")
+		}
+
+		if len(overflowProvider.GetSubscribeIDs()) != 0 {
+			t.Errorf("Wrong subscribe IDs: %v", overflowProvider.GetSubscribeIDs())
+		}
+	}
+}
+
+func TestPermissions(t *testing.T) {
+	// Check public path for not authorized client
+	_, err := provider.GetData("Attribute.Vehicle.VehicleIdentification.VIN", &dataprovider.AuthInfo{})
+	if err != nil {
+		t.Errorf("Can't get data: %s", err)
+	}
+
+	// Check private path for not authorized client
+	_, err = provider.GetData("Signal.Drivetrain.InternalCombustionEngine.RPM", &dataprovider.AuthInfo{})
+	if err == nil {
+		t.Error("Path should not be accessible")
+	} else if !strings.Contains(err.Error(), "not authorized") {
+		t.Errorf("Wrong error type: %s", err)
+	}
+
+	// Check authorized but not permitted
+	_, err = provider.GetData("Signal.Drivetrain.InternalCombustionEngine.RPM",
+		newAuthInfo(t, map[string]string{}))
+	if err == nil {
+		t.Error("Path should not be accessible")
+	} else if !strings.Contains(err.Error(), "not have permissions") {
+		t.Errorf("Wrong error type: %s", err)
+	}
+
+	// Check read permissions
+	_, err = provider.GetData("Signal.Drivetrain.InternalCombustionEngine.RPM",
+		newAuthInfo(t, map[string]string{"Signal.Drivetrain.InternalCombustionEngine.RPM": "r"}))
+	if err != nil {
+		t.Errorf("Can't get data: %s", err)
+	}
+
+	// Check no write permissions
+	err = provider.SetData("Signal.Cabin.Door.Row1.Right.Window.Position", 0,
+		newAuthInfo(t, map[string]string{"Signal.Cabin.Door.*": "r"}))
+	if err == nil {
+		t.Error("Path should not be accessible")
+	} else if !strings.Contains(err.Error(), "not have permissions") {
+		t.Errorf("Wrong error type: %s", err)
+	}
+
+	// Check write permissions
+	err = provider.SetData("Signal.Cabin.Door.Row1.Right.Window.Position", 0,
+		newAuthInfo(t, map[string]string{"Signal.Cabin.Door.*": "rw"}))
+	if err != nil {
+		t.Errorf("Can't set data: %s", err)
+	}
+
+	// Check permissions are compiled on auth info creation
+	permissions := map[string]string{"Signal.Cabin.Door.*": "r"}
+	authInfo := newAuthInfo(t, permissions)
+
+	delete(permissions, "Signal.Cabin.Door.*")
+	permissions["Signal.Body.*"] = "r"
+
+	if err = provider.CheckPermissions("Signal.Cabin.Door.*", authInfo, "r"); err != nil {
+		t.Errorf("Path should be accessible: %s", err)
+	}
+
+	if err = provider.CheckPermissions("Signal.Body.*", authInfo, "r"); err == nil {
+		t.Error("Path should not be accessible")
+	}
+}
+
+func TestSubscribe(t *testing.T) {
+	// Clear all locks
+	if err := provider.SetData("Signal.Cabin.Door.*.IsLocked", []interface{}{
+		map[string]interface{}{"Row1.Right.IsLocked": false},
+		map[string]interface{}{"Row1.Left.IsLocked": false},
+		map[string]interface{}{"Row2.Right.IsLocked": false},
+		map[string]interface{}{"Row2.Left.IsLocked": false},
+	}, nil); err != nil {
+		t.Errorf("Can't set data: %s", err)
+	}
+
+	// Subscribes for all door locks
+	id1, channel1, err := provider.Subscribe("Signal.Cabin.Door.*", nil)
+	if err != nil {
+		t.Errorf("Can't subscribe: %s", err)
+	}
+
+	// Subscribes for row1 door locks
+	id2, channel2, err := provider.Subscribe("Signal.Cabin.Door.Row1.*", nil)
+	if err != nil {
+		t.Errorf("Can't get data: %s", err)
+	}
+
+	if len(provider.GetSubscribeIDs()) != 2 {
+		t.Errorf("Wrong subscribers count: %d", len(provider.GetSubscribeIDs()))
+	}
+
+	// Set all locks
+	if err = provider.SetData("Signal.Cabin.Door.*.IsLocked", []interface{}{
+		map[string]interface{}{"Row1.Right.IsLocked": true},
+		map[string]interface{}{"Row1.Left.IsLocked": true},
+		map[string]interface{}{"Row2.Right.IsLocked": true},
+		map[string]interface{}{"Row2.Left.IsLocked": true},
+	}, nil); err != nil {
+		t.Errorf("Can't set data: %s", err)
+	}
+
+	timeout := false
+	eventChannel1 := false
+	eventChannel2 := false
+
+	for {
+		select {
+		case notification := <-channel1:
+			data1 := dataprovider.GetDataPointValues(notification.Data)
+
+			if len(data1) != 4 {
+				t.Errorf("Wrong data size: %d", len(data1))
+			}
+
+			if data1["Signal.Cabin.Door.Row1.Right.IsLocked"] != true {
+				t.Errorf("Data mistmatch: %v", false)
+			}
+
+			if data1["Signal.Cabin.Door.Row1.Left.IsLocked"] != true {
+				t.Errorf("Data mistmatch: %v", false)
+			}
+
+			if data1["Signal.Cabin.Door.Row2.Right.IsLocked"] != true {
+				t.Errorf("Data mistmatch: %v", false)
+			}
+
+			if data1["Signal.Cabin.Door.Row2.Left.IsLocked"] != true {
+				t.Errorf("Data mistmatch: %v", false)
+			}
+
+			eventChannel1 = true
+		case notification := <-channel2:
+			data2 := dataprovider.GetDataPointValues(notification.Data)
+
+			if len(data2) != 2 {
+				t.Errorf("Wrong data size: %d", len(data2))
+			}
+
+			if data2["Signal.Cabin.Door.Row1.Right.IsLocked"] != true {
+				t.Errorf("Data mistmatch: %v", false)
+			}
+
+			if data2["Signal.Cabin.Door.Row1.Left.IsLocked"] != true {
+				t.Errorf("Data mistmatch: %v", false)
+			}
+
+			eventChannel2 = true
+		case <-time.After(100 * time.Millisecond):
+			timeout = true
+		}
+
+		if eventChannel1 && eventChannel2 {
+			break
+		}
+
+		if timeout {
+			t.Error("Waiting for data change timeout")
+			break
+		}
+	}
+
+	// Unsubscribes id1
+	if err = provider.Unsubscribe(id1, nil); err != nil {
+		t.Errorf("Can't unsubscribe: %s", err)
+	}
+
+	if len(provider.GetSubscribeIDs()) != 1 {
+		t.Errorf("Wrong subscribers count: %d", len(provider.GetSubscribeIDs()))
+	}
+
+	// Clear all locks
+	if err = provider.SetData("Signal.Cabin.Door.*.IsLocked", []interface{}{
+		map[string]interface{}{"Row1.Right.IsLocked": false},
+		map[string]interface{}{"Row1.Left.IsLocked": false},
+		map[string]interface{}{"Row2.Right.IsLocked": false},
+		map[string]interface{}{"Row2.Left.IsLocked": false},
+	}, nil); err != nil {
+		t.Errorf("Can't set data: %s", err)
+	}
+
+	timeout, eventChannel1, eventChannel2 = false, false, false
+
+	for {
+		select {
+		case _, ok := <-channel1:
+			if ok {
+				t.Error("Unexpected data received")
+			}
+
+			eventChannel1 = true
+		case _, ok := <-channel2:
+			if !ok {
+				t.Error("Channel should not be closed")
+			}
+
+			eventChannel2 = true
+		case <-time.After(100 * time.Millisecond):
+			timeout = true
+		}
+
+		if eventChannel1 && eventChannel2 {
+			break
+		}
+
+		if timeout {
+			break
+		}
+	}
+
+	// Unsubscribes id2
+	if err = provider.Unsubscribe(id2, nil); err != nil {
+		t.Errorf("Can't unsubscribe: %s", err)
+	}
+
+	if len(provider.GetSubscribeIDs()) != 0 {
+		t.Errorf("Wrong subscribers count: %d", len(provider.GetSubscribeIDs()))
+	}
+
+	// Set all locks
+	if err = provider.SetData("Signal.Cabin.Door.*.IsLocked", []interface{}{
+		map[string]interface{}{"Row1.Right.IsLocked": true},
+		map[string]interface{}{"Row1.Left.IsLocked": true},
+		map[string]interface{}{"Row2.Right.IsLocked": true},
+		map[string]interface{}{"Row2.Left.IsLocked": true},
+	}, nil); err != nil {
+		t.Errorf("Can't set data: %s", err)
+	}
+
+	timeout, eventChannel1, eventChannel2 = false, false, false
+
+	for {
+		select {
+		case _, ok := <-channel1:
+			if ok {
+				t.Error("Unexpected data received")
+			}
+
+			eventChannel1 = true
+		case _, ok := <-channel2:
+			if ok {
+				t.Error("Unexpected data received")
+			}
+
+			eventChannel2 = true
+		case <-time.After(100 * time.Millisecond):
+			timeout = true
+		}
+
+		if eventChannel1 && eventChannel2 {
+			break
+		}
+
+		if timeout {
+			break
+		}
+	}
+}
+
+func TestSubscribeMaxPaths(t *testing.T) {
+	if _, err := provider.SubscribeWithOptions(
+		"Signal.Cabin.Door.*.IsLocked", nil, dataprovider.SubscribeOptions{MaxPaths: 3}); err == nil ||
+		!strings.Contains(err.Error(), "limit exceeded") {
+		t.Errorf("Paths limit error expected: %v", err)
+	}
+
+	subscription, err := provider.SubscribeWithOptions(
+		"Signal.Cabin.Door.*.IsLocked", nil, dataprovider.SubscribeOptions{MaxPaths: 4})
+	if err != nil {
+		t.Fatalf("Can't subscribe: %s", err)
+	}
+
+	if err = provider.Unsubscribe(subscription.ID, nil); err != nil {
+		t.Errorf("Can't unsubscribe: %s", err)
+	}
+}
+
+func TestSubscribeSnapshot(t *testing.T) {
+	if err := provider.SetData("Signal.Cabin.Door.Row2.*", map[string]interface{}{
+		"Right.IsLocked": false, "Right.Window.Position": 10, "Left.IsLocked": false, "Left.Window.Position": 20,
+	}, nil); err != nil {
+		t.Fatalf("Can't set data: %s", err)
+	}
+
+	subscriptions := make([]*dataprovider.Subscription, 0, 2)
+
+	defer func() {
+		for _, subscription := range subscriptions {
+			if err := provider.Unsubscribe(subscription.ID, nil); err != nil {
+				t.Errorf("Can't unsubscribe: %s", err)
+			}
+		}
+	}()
+
+	for i := 0; i < 2; i++ {
+		subscription, err := provider.SubscribeWithOptions(
+			"Signal.Cabin.Door.Row2.*", nil, dataprovider.SubscribeOptions{Snapshot: true})
+		if err != nil {
+			t.Fatalf("Can't subscribe: %s", err)
+		}
+
+		subscriptions = append(subscriptions, subscription)
+	}
+
+	expectedSnapshot := map[string]interface{}{
+		"Signal.Cabin.Door.Row2.Right.IsLocked": false, "Signal.Cabin.Door.Row2.Right.Window.Position": 10,
+		"Signal.Cabin.Door.Row2.Left.IsLocked": false, "Signal.Cabin.Door.Row2.Left.Window.Position": 20,
+	}
+
+	for _, subscription := range subscriptions {
+		select {
+		case notification := <-subscription.Channel:
+			if !notification.Snapshot {
+				t.Error("Snapshot notification expected")
+			}
+
+			if data := dataprovider.GetDataPointValues(notification.Data); !reflect.DeepEqual(data, expectedSnapshot) {
+				t.Errorf("Wrong snapshot: %v", data)
+			}
+
+		case <-time.After(time.Second):
+			t.Fatal("Snapshot is not received")
+		}
+	}
+
+	if err := provider.SetData("Signal.Cabin.Door.Row2.Left.IsLocked", true, nil); err != nil {
+		t.Fatalf("Can't set data: %s", err)
+	}
+
+	notifications := make([]*dataprovider.Notification, 0, len(subscriptions))
+
+	for _, subscription := range subscriptions {
+		select {
+		case notification := <-subscription.Channel:
+			if notification.Snapshot {
+				t.Error("Change notification expected")
+			}
+
+			if value := notification.GetValue("Signal.Cabin.Door.Row2.Left.IsLocked"); value != true {
+				t.Errorf("Wrong notification value: %v", value)
+			}
+
+			if value, ok := notification.GetValue("Signal.Cabin.Door.Row2.*").(map[string]interface{}); !ok ||
+				value["Signal.Cabin.Door.Row2.Left.IsLocked"] != true {
+				t.Errorf("Wrong notification value: %v", value)
+			}
+
+			notifications = append(notifications, notification)
+
+		case <-time.After(time.Second):
+			t.Fatal("Notification is not received")
+		}
+	}
+
+	// Subscribers of the same paths share notification
+	if notifications[0] != notifications[1] {
+		t.Error("Notification should be shared")
+	}
+}
+
+func TestConcurrentSubscribe(t *testing.T) {
+	const (
+		path       = "Signal.Cabin.Door.Row2.Right.IsLocked"
+		numClients = 20
+		iterations = 20
+	)
+
+	if err := provider.SetData(path, false, nil); err != nil {
+		t.Fatalf("Can't set data: %s", err)
+	}
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < numClients; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for j := 0; j < iterations; j++ {
+				id, _, err := provider.Subscribe(path, nil)
+				if err != nil {
+					t.Errorf("Can't subscribe: %s", err)
+					return
+				}
+
+				if err = provider.Unsubscribe(id, nil); err != nil {
+					t.Errorf("Can't unsubscribe: %s", err)
+					return
+				}
+			}
+		}()
+	}
+
+	// Concurrent unsubscribes should not unsubscribe adapter from path of active subscription
+	id, channel, err := provider.Subscribe(path, nil)
+	if err != nil {
+		t.Fatalf("Can't subscribe: %s", err)
+	}
+
+	wg.Wait()
+
+	if err = provider.SetData(path, true, nil); err != nil {
+		t.Fatalf("Can't set data: %s", err)
+	}
+
+	if err = waitNotification(channel, path, true); err != nil {
+		t.Error(err)
+	}
+
+	if err = provider.Unsubscribe(id, nil); err != nil {
+		t.Errorf("Can't unsubscribe: %s", err)
+	}
+}
+
+func TestBaseAdapterNotificationOrder(t *testing.T) {
+	const (
+		path       = "Signal.Test.Value"
+		numSetters = 8
+		iterations = 100
+	)
+
+	adapter, err := dataprovider.NewBaseAdapter()
+	if err != nil {
+		t.Fatalf("Can't create adapter: %s", err)
+	}
+
+	adapter.Data[path] = &dataprovider.BaseData{Value: -1}
+
+	if err = adapter.Subscribe([]string{path}); err != nil {
+		t.Fatalf("Can't subscribe: %s", err)
+	}
+
+	var (
+		wg        sync.WaitGroup
+		lastValue interface{}
+		lastTime  time.Time
+		done      = make(chan struct{})
+	)
+
+	go func() {
+		defer close(done)
+
+		for changes := range adapter.GetSubscribeChannel() {
+			data, timestamps := dataprovider.UnpackChanges(changes)
+
+			lastValue, lastTime = data[path], timestamps[path]
+		}
+	}()
+
+	for i := 0; i < numSetters; i++ {
+		wg.Add(1)
+
+		go func(setter int) {
+			defer wg.Done()
+
+			for j := 0; j < iterations; j++ {
+				if err := adapter.SetData(map[string]interface{}{path: setter*iterations + j}); err != nil {
+					t.Errorf("Can't set data: %s", err)
+				}
+			}
+		}(i)
+	}
+
+	wg.Wait()
+	close(adapter.SubscribeChannel)
+	<-done
+
+	data, err := adapter.GetData([]string{path})
+	if err != nil {
+		t.Fatalf("Can't get data: %s", err)
+	}
+
+	// The last notification should contain the stored value
+	if lastValue != data[path] {
+		t.Errorf("Wrong last notification value: %v, stored value: %v", lastValue, data[path])
+	}
+
+	timestamps, err := adapter.GetTimestamps([]string{path})
+	if err != nil {
+		t.Fatalf("Can't get timestamps: %s", err)
+	}
+
+	// The last notification should contain the stored sample time
+	if !lastTime.Equal(timestamps[path]) {
+		t.Errorf("Wrong last notification time: %v, stored time: %v", lastTime, timestamps[path])
+	}
+}
+
+func TestBaseAdapterReadOnly(t *testing.T) {
+	const path = "Signal.Test.Value"
+
+	adapter, err := dataprovider.NewBaseAdapter()
+	if err != nil {
+		t.Fatalf("Can't create adapter: %s", err)
+	}
+
+	adapter.Data[path] = &dataprovider.BaseData{Value: 0, ReadOnly: true}
+
+	if err = adapter.CheckSetData(map[string]interface{}{path: 1}); err == nil {
+		t.Error("Error expected for read only path")
+	}
+
+	if err = adapter.CheckSetData(map[string]interface{}{"Signal.Test.Unknown": 1}); err == nil {
+		t.Error("Error expected for unknown path")
+	}
+
+	if err = adapter.SetData(map[string]interface{}{path: 1}); err == nil {
+		t.Error("Error expected for read only path")
+	}
+
+	// Read only value is updated by adapter
+	if err = adapter.UpdateData(map[string]interface{}{path: 2}); err != nil {
+		t.Fatalf("Can't update data: %s", err)
+	}
+
+	data, err := adapter.GetData([]string{path})
+	if err != nil {
+		t.Fatalf("Can't get data: %s", err)
+	}
+
+	if data[path] != 2 {
+		t.Errorf("Wrong updated value: %v", data[path])
+	}
+}
+
+func TestPathFilter(t *testing.T) {
+	type resultDesc struct {
+		path  string
+		match bool
+	}
+
+	type testDesc struct {
+		filter string
+		result []resultDesc
+	}
+
+	testData := []testDesc{
+		{
+			filter: "Sensors.Vehicle.Door",
+			result: []resultDesc{
+				{"Sensors.Vehicle.Door", true},
+				{"Sensors.Vehicle.DoorFront", false},
+				{"Sensors.Vehicle.DoorRear", false},
+			},
+		},
 		{
 			filter: "Sensors.Vehicle.Door",
 			result: []resultDesc{
@@ -737,6 +1826,141 @@ func TestPathFilter(t *testing.T) {
 	}
 }
 
+func TestPathIndex(t *testing.T) {
+	paths := generatePaths(4)
+	paths = append(paths, "Sensors.Vehicle.Door", "Sensors.Vehicle.Door.Front", "Sensors.Vehicle.Door.Front.Open",
+		"Sensors.Vehicle.Window.Front.Position", "Sensors.Vehicles", "Sensors.Engine.RPM", "Sensors.Door.Door.Front")
+
+	index := dataprovider.NewPathIndex()
+
+	for _, path := range paths {
+		index.Add(path)
+	}
+
+	if index.Len() != len(paths) {
+		t.Errorf("Wrong index len: %d", index.Len())
+	}
+
+	masks := []string{
+		"*", "Signal", "Signal.*", "Signal.Group1", "Signal.Group1.*", "Signal.*.Value2", "Signal.*.Item3.*",
+		"*.Group2.Item1.Value0", "*.*.Value1", "Signal.Group1.Item1.Value1", "Signal.Group1.Item1.Value1.Unknown",
+		"Signal.*.Unknown", "Sensors.Vehicle.Door", "Sensors.Vehicle.*.Front", "Sensors.*.Front.*", "Sensors.*.Door",
+		"Sensors.Vehicle.*.*", "Sensors.*.Door.Front", "Sensors.*.*.Front", "Unknown",
+	}
+
+	for _, mask := range masks {
+		if err := checkIndexFind(index, paths, mask); err != nil {
+			t.Errorf("Wrong result of mask %s: %s", mask, err)
+		}
+	}
+
+	removed := paths[:len(paths)/2]
+
+	for _, path := range removed {
+		index.Remove(path)
+	}
+
+	index.Remove("Unknown.Path")
+
+	if index.Len() != len(paths)-len(removed) {
+		t.Errorf("Wrong index len: %d", index.Len())
+	}
+
+	for _, mask := range masks {
+		if err := checkIndexFind(index, paths[len(removed):], mask); err != nil {
+			t.Errorf("Wrong result of mask %s after remove: %s", mask, err)
+		}
+	}
+}
+
+/*******************************************************************************
+ * Benchmarks
+ ******************************************************************************/
+
+func BenchmarkPathFilterMatch(b *testing.B) {
+	paths := generatePaths(benchmarkPathsGroups)
+
+	for _, mask := range benchmarkMasks {
+		filter, err := dataprovider.CreatePathFilter(mask)
+		if err != nil {
+			b.Fatalf("Can't create path filter: %s", err)
+		}
+
+		b.Run(mask, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				var result []string
+
+				for _, path := range paths {
+					if filter.Match(path) {
+						result = append(result, path)
+					}
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkPathIndexFind(b *testing.B) {
+	index := dataprovider.NewPathIndex()
+
+	for _, path := range generatePaths(benchmarkPathsGroups) {
+		index.Add(path)
+	}
+
+	for _, mask := range benchmarkMasks {
+		filter, err := dataprovider.CreatePathFilter(mask)
+		if err != nil {
+			b.Fatalf("Can't create path filter: %s", err)
+		}
+
+		b.Run(mask, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				index.Find(filter)
+			}
+		})
+	}
+}
+
+func BenchmarkGetData(b *testing.B) {
+	log.SetLevel(log.InfoLevel)
+	defer log.SetLevel(log.DebugLevel)
+
+	data := make(map[string]*dataprovider.BaseData)
+
+	for _, path := range generatePaths(benchmarkPathsGroups) {
+		data[path] = &dataprovider.BaseData{Value: 0}
+	}
+
+	params, err := json.Marshal(struct {
+		Data map[string]*dataprovider.BaseData `json:"data"`
+	}{Data: data})
+	if err != nil {
+		b.Fatalf("Can't marshal adapter params: %s", err)
+	}
+
+	benchmarkProvider, err := dataprovider.New(&config.Config{
+		Adapters: []config.AdapterConfig{{Plugin: "testadapter", Params: params}},
+	})
+	if err != nil {
+		b.Fatalf("Can't create data provider: %s", err)
+	}
+	defer benchmarkProvider.Close()
+
+	authInfo := newAuthInfo(b, map[string]string{
+		"Signal.Group0.*": "rw", "Signal.Group1.*": "rw", "Signal.*.Item1.*": "r", "Signal.*": "r",
+	})
+
+	for _, mask := range benchmarkMasks {
+		b.Run(mask, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := benchmarkProvider.GetDataPoints(mask, authInfo); err != nil {
+					b.Fatalf("Can't get data: %s", err)
+				}
+			}
+		})
+	}
+}
+
 /*******************************************************************************
  * Private
  ******************************************************************************/
@@ -758,3 +1982,178 @@ func arrayToMap(data interface{}) (result map[string]interface{}, err error) {
 
 	return result, nil
 }
+
+func waitHistory(provider *dataprovider.DataProvider, path string, values []interface{}) (err error) {
+	timeout := time.After(time.Second)
+
+	for {
+		history, err := provider.GetHistory(path, 0, nil)
+		if err != nil {
+			return aoserrors.Wrap(err)
+		}
+
+		historyValues := make([]interface{}, 0, len(history[path]))
+
+		for _, dataPoint := range history[path] {
+			historyValues = append(historyValues, dataPoint.Value)
+		}
+
+		if reflect.DeepEqual(historyValues, values) {
+			return nil
+		}
+
+		select {
+		case <-timeout:
+			return aoserrors.Errorf("history values %v, expected %v", historyValues, values)
+
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
+
+// readRecording returns recorded values of Signal.Vehicle.Speed in order of recording.
+func readRecording(dir string) (values []interface{}, err error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	for _, entry := range entries {
+		content, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, aoserrors.Wrap(err)
+		}
+
+		for _, line := range strings.Split(strings.TrimSpace(string(content)), "\n") {
+			if line == "" {
+				continue
+			}
+
+			var record struct {
+				Timestamp time.Time   `json:"timestamp"`
+				Path      string      `json:"path"`
+				Value     interface{} `json:"value"`
+			}
+
+			if err = json.Unmarshal([]byte(line), &record); err != nil {
+				return nil, aoserrors.Wrap(err)
+			}
+
+			if record.Path != "Signal.Vehicle.Speed" || record.Timestamp.IsZero() {
+				return nil, aoserrors.Errorf("unexpected record: %s", line)
+			}
+
+			values = append(values, record.Value)
+		}
+	}
+
+	return values, nil
+}
+
+// waitRecording waits until recording ends with expected values.
+func waitRecording(dir string, values []interface{}) (err error) {
+	timeout := time.After(time.Second)
+
+	for {
+		recordedValues, err := readRecording(dir)
+		if err != nil {
+			return err
+		}
+
+		if len(recordedValues) >= len(values) &&
+			reflect.DeepEqual(recordedValues[len(recordedValues)-len(values):], values) {
+			return nil
+		}
+
+		select {
+		case <-timeout:
+			return aoserrors.Errorf("recorded values %v, expected %v", recordedValues, values)
+
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
+
+func newAuthInfo(tb testing.TB, permissions map[string]string) (authInfo *dataprovider.AuthInfo) {
+	tb.Helper()
+
+	authInfo, err := dataprovider.NewAuthInfo(permissions, nil)
+	if err != nil {
+		tb.Fatalf("Can't create auth info: %s", err)
+	}
+
+	return authInfo
+}
+
+func waitNotification(
+	channel <-chan *dataprovider.Notification, path string, value interface{},
+) (err error) {
+	timeout := time.After(time.Second)
+
+	for {
+		select {
+		case notification := <-channel:
+			if dataPoint, ok := notification.Data[path]; ok && dataPoint.Value == value {
+				return nil
+			}
+
+		case <-timeout:
+			return aoserrors.Errorf("notification %s: %v not received", path, value)
+		}
+	}
+}
+
+func waitDropped(provider *dataprovider.DataProvider, dropped uint64) (err error) {
+	timeout := time.After(time.Second)
+
+	for provider.GetDroppedNotifications() != dropped {
+		select {
+		case <-timeout:
+			return aoserrors.Errorf("dropped notifications %d, expected %d", provider.GetDroppedNotifications(), dropped)
+
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+
+	return nil
+}
+
+// generatePaths generates groups*groups*groups paths.
+func generatePaths(groups int) (paths []string) {
+	for group := 0; group < groups; group++ {
+		for item := 0; item < groups; item++ {
+			for value := 0; value < groups; value++ {
+				paths = append(paths, fmt.Sprintf("Signal.Group%d.Item%d.Value%d", group, item, value))
+			}
+		}
+	}
+
+	return paths
+}
+
+// checkIndexFind compares index find result with paths matched by filter.
+func checkIndexFind(index *dataprovider.PathIndex, paths []string, mask string) (err error) {
+	filter, err := dataprovider.CreatePathFilter(mask)
+	if err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	expected := make([]string, 0)
+
+	for _, path := range paths {
+		if filter.Match(path) {
+			expected = append(expected, path)
+		}
+	}
+
+	result := append(make([]string, 0), index.Find(filter)...)
+
+	sort.Strings(expected)
+	sort.Strings(result)
+
+	if !reflect.DeepEqual(result, expected) {
+		return aoserrors.Errorf("expected %d paths, found %d", len(expected), len(result))
+	}
+
+	return nil
+}
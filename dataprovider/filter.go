@@ -41,8 +41,12 @@ func CreatePathFilter(path string) (filter *PathFilter, err error) {
 
 // Match returns true is path matches the filter.
 func (filter *PathFilter) Match(path string) (result bool) {
+	return filter.matchSegments(strings.Split(path, "."))
+}
+
+// matchSegments returns true if path split by segments matches the filter.
+func (filter *PathFilter) matchSegments(pathSlice []string) (result bool) {
 	maskIndex, pathIndex := 0, 0
-	pathSlice := strings.Split(path, ".")
 	maskSlice := filter.mask
 
 	for maskIndex < len(maskSlice) && pathIndex < len(pathSlice) {
@@ -0,0 +1,238 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2021 Renesas Electronics Corporation.
+// Copyright (C) 2021 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dataprovider
+
+import (
+	"time"
+
+	"github.com/aosedge/aos_common/aoserrors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/aosedge/aos_vis/config"
+)
+
+/*******************************************************************************
+ * Consts
+ ******************************************************************************/
+
+const defaultHistoryCount = 100
+
+/*******************************************************************************
+ * Types
+ ******************************************************************************/
+
+// historyBuffer ring buffer of signal data points bounded by count and duration.
+type historyBuffer struct {
+	dataPoints  []DataPoint
+	head        int
+	size        int
+	maxDuration time.Duration
+}
+
+type historyRule struct {
+	filter *PathFilter
+	config config.HistoryConfig
+}
+
+/*******************************************************************************
+ * Public
+ ******************************************************************************/
+
+// GetHistory returns data points of all paths matched to requested path sampled within the window. All stored
+// data points are returned if window is zero.
+func (provider *DataProvider) GetHistory(
+	path string, window time.Duration, authInfo *AuthInfo,
+) (history map[string][]DataPoint, err error) {
+	log.WithFields(log.Fields{"path": path, "window": window}).Debug("Get history")
+
+	filter, err := CreatePathFilter(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var since time.Time
+
+	if window > 0 {
+		since = time.Now().Add(-window)
+	}
+
+	adapterPathMap := make(map[DataAdapter][]string)
+	history = make(map[string][]DataPoint)
+
+	provider.RLock()
+
+	for _, path := range provider.sensorIndex.Find(filter) {
+		sensor := provider.sensors[path]
+
+		adapterPathMap[sensor.adapter] = append(adapterPathMap[sensor.adapter], path)
+
+		if sensor.history != nil {
+			history[path] = sensor.history.get(since)
+		}
+	}
+
+	provider.RUnlock()
+
+	if len(adapterPathMap) == 0 {
+		return nil, aoserrors.New("specified data path does not exist")
+	}
+
+	if err = checkPathPermissions(adapterPathMap, authInfo, "r"); err != nil {
+		return nil, err
+	}
+
+	if len(history) == 0 {
+		return nil, aoserrors.New("history is not enabled for specified data path")
+	}
+
+	return history, nil
+}
+
+/*******************************************************************************
+ * Private
+ ******************************************************************************/
+
+func createHistoryRules(historyConfigs []config.HistoryConfig) (rules []historyRule, err error) {
+	for _, historyConfig := range historyConfigs {
+		if historyConfig.MaxCount < 0 || historyConfig.MaxDuration < 0 {
+			return nil, aoserrors.Errorf("invalid history config for path %s: negative limit", historyConfig.Path)
+		}
+
+		filter, err := CreatePathFilter(historyConfig.Path)
+		if err != nil {
+			return nil, err
+		}
+
+		rules = append(rules, historyRule{filter: filter, config: historyConfig})
+	}
+
+	return rules, nil
+}
+
+// createHistory creates history buffer for path according to first matched rule. Nil is returned if path has no
+// history.
+func (provider *DataProvider) createHistory(path string) (history *historyBuffer) {
+	for _, rule := range provider.historyRules {
+		if !rule.filter.Match(path) {
+			continue
+		}
+
+		maxCount := rule.config.MaxCount
+		if maxCount == 0 {
+			maxCount = defaultHistoryCount
+		}
+
+		return &historyBuffer{
+			dataPoints:  make([]DataPoint, maxCount),
+			maxDuration: time.Duration(rule.config.MaxDuration) * time.Second,
+		}
+	}
+
+	return nil
+}
+
+// initAdapterHistory stores current values of adapter paths with history.
+func (provider *DataProvider) initAdapterHistory(adapter DataAdapter, pathList []string) (err error) {
+	var historyPaths []string
+
+	provider.RLock()
+
+	for _, path := range pathList {
+		if sensor, ok := provider.sensors[path]; ok && sensor.adapter == adapter && sensor.history != nil {
+			historyPaths = append(historyPaths, path)
+		}
+	}
+
+	provider.RUnlock()
+
+	if len(historyPaths) == 0 {
+		return nil
+	}
+
+	data, err := getAdapterData(adapter, historyPaths)
+	if err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	timestamps, err := adapter.GetTimestamps(historyPaths)
+	if err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	provider.Lock()
+
+	for path, value := range data {
+		if sensor, ok := provider.sensors[path]; ok && sensor.history != nil {
+			sensor.history.add(newDataPoint(value, timestamps[path]))
+		}
+	}
+
+	provider.Unlock()
+
+	return nil
+}
+
+func (history *historyBuffer) add(dataPoint DataPoint) {
+	index := (history.head + history.size) % len(history.dataPoints)
+
+	if history.size == len(history.dataPoints) {
+		history.head = (history.head + 1) % len(history.dataPoints)
+	} else {
+		history.size++
+	}
+
+	history.dataPoints[index] = dataPoint
+}
+
+// get returns data points sampled not before since time in chronological order.
+func (history *historyBuffer) get(since time.Time) (dataPoints []DataPoint) {
+	if history.maxDuration > 0 {
+		if expired := time.Now().Add(-history.maxDuration); expired.After(since) {
+			since = expired
+		}
+	}
+
+	dataPoints = make([]DataPoint, 0, history.size)
+
+	for i := 0; i < history.size; i++ {
+		dataPoint := history.dataPoints[(history.head+i)%len(history.dataPoints)]
+
+		if dataPoint.Timestamp.Before(since) {
+			continue
+		}
+
+		dataPoints = append(dataPoints, dataPoint)
+	}
+
+	return dataPoints
+}
+
+// merge appends data points of other history which are newer than stored ones.
+func (history *historyBuffer) merge(other *historyBuffer) {
+	var last time.Time
+
+	if history.size != 0 {
+		last = history.dataPoints[(history.head+history.size-1)%len(history.dataPoints)].Timestamp
+	}
+
+	for _, dataPoint := range other.get(time.Time{}) {
+		if dataPoint.Timestamp.After(last) {
+			history.add(dataPoint)
+		}
+	}
+}
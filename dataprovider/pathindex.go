@@ -0,0 +1,177 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2021 Renesas Electronics Corporation.
+// Copyright (C) 2021 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dataprovider
+
+import (
+	"strings"
+)
+
+/*******************************************************************************
+ * Types
+ ******************************************************************************/
+
+// PathIndex tree of paths split by segments. It finds paths matched to path filter without checking every path.
+type PathIndex struct {
+	root  pathNode
+	count int
+}
+
+type pathNode struct {
+	name     string
+	path     string
+	children map[string]*pathNode
+}
+
+/*******************************************************************************
+ * Public
+ ******************************************************************************/
+
+// NewPathIndex creates empty path index.
+func NewPathIndex() (index *PathIndex) {
+	return &PathIndex{}
+}
+
+// Add adds path to index.
+func (index *PathIndex) Add(path string) {
+	node := &index.root
+
+	for _, name := range strings.Split(path, ".") {
+		child, ok := node.children[name]
+		if !ok {
+			if node.children == nil {
+				node.children = make(map[string]*pathNode)
+			}
+
+			child = &pathNode{name: name}
+			node.children[name] = child
+		}
+
+		node = child
+	}
+
+	if node.path == "" {
+		node.path = path
+		index.count++
+	}
+}
+
+// Remove removes path from index.
+func (index *PathIndex) Remove(path string) {
+	if index.root.remove(strings.Split(path, ".")) {
+		index.count--
+	}
+}
+
+// Len returns number of paths in index.
+func (index *PathIndex) Len() (count int) {
+	return index.count
+}
+
+// Find returns paths matched to filter in no particular order. Result is the same as checking each path with filter
+// Match.
+func (index *PathIndex) Find(filter *PathFilter) (paths []string) {
+	index.root.findChildren(filter.mask, 0, false, &paths)
+
+	return paths
+}
+
+/*******************************************************************************
+ * Private
+ ******************************************************************************/
+
+// remove removes path given by names from node subtree and drops empty nodes. True is returned if path is removed.
+func (node *pathNode) remove(names []string) (removed bool) {
+	if len(names) == 0 {
+		if node.path == "" {
+			return false
+		}
+
+		node.path = ""
+
+		return true
+	}
+
+	child, ok := node.children[names[0]]
+	if !ok {
+		return false
+	}
+
+	if removed = child.remove(names[1:]); removed && child.path == "" && len(child.children) == 0 {
+		delete(node.children, names[0])
+	}
+
+	return removed
+}
+
+// find collects paths of node subtree matched to mask when node is matched to mask[maskIndex]. It follows the
+// PathFilter Match algorithm: wildcard consumes path segments until the next one equals to the next mask segment.
+func (node *pathNode) find(mask []string, maskIndex int, paths *[]string) {
+	if node.name != mask[maskIndex] && mask[maskIndex] != "*" {
+		return
+	}
+
+	if node.path != "" && maskIndex == len(mask)-1 {
+		*paths = append(*paths, node.path)
+	}
+
+	stay := mask[maskIndex] == "*" && maskIndex < len(mask)-1 && mask[maskIndex+1] != "*"
+
+	node.findChildren(mask, maskIndex+1, stay, paths)
+}
+
+// findChildren collects paths of node children matched to mask[maskIndex]. If stay is set, children which don't
+// match mask[maskIndex] are matched to previous wildcard mask segment.
+func (node *pathNode) findChildren(mask []string, maskIndex int, stay bool, paths *[]string) {
+	// Paths longer than mask are matched
+	if maskIndex == len(mask) {
+		for _, child := range node.children {
+			child.collect(paths)
+		}
+
+		return
+	}
+
+	if !stay && mask[maskIndex] != "*" {
+		if child, ok := node.children[mask[maskIndex]]; ok {
+			child.find(mask, maskIndex, paths)
+		}
+
+		return
+	}
+
+	for _, child := range node.children {
+		if stay && child.name != mask[maskIndex] {
+			child.find(mask, maskIndex-1, paths)
+
+			continue
+		}
+
+		child.find(mask, maskIndex, paths)
+	}
+}
+
+// collect collects all paths of node subtree.
+func (node *pathNode) collect(paths *[]string) {
+	if node.path != "" {
+		*paths = append(*paths, node.path)
+	}
+
+	for _, child := range node.children {
+		child.collect(paths)
+	}
+}
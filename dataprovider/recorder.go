@@ -0,0 +1,358 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2021 Renesas Electronics Corporation.
+// Copyright (C) 2021 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dataprovider
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aosedge/aos_common/aoserrors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/aosedge/aos_vis/config"
+)
+
+/*******************************************************************************
+ * Consts
+ ******************************************************************************/
+
+const (
+	defaultRecordingFileSize = 10 * 1024 * 1024
+	defaultRecordingQuota    = 100 * 1024 * 1024
+	recordingFilePrefix      = "vis_"
+	recordingFileSuffix      = ".jsonl"
+	recordingTimeFormat      = "20060102T150405.000000000Z"
+)
+
+/*******************************************************************************
+ * Types
+ ******************************************************************************/
+
+// recorder writes adapter changes to rotating JSONL files bounded by disk quota.
+type recorder struct {
+	sync.Mutex
+	config   config.RecordingConfig
+	filters  []*PathFilter
+	file     *os.File
+	fileSize int64
+}
+
+// recordEntry JSONL recording line.
+type recordEntry struct {
+	Timestamp time.Time   `json:"timestamp"`
+	Adapter   string      `json:"adapter"`
+	Path      string      `json:"path"`
+	Value     interface{} `json:"value"`
+}
+
+/*******************************************************************************
+ * Public
+ ******************************************************************************/
+
+// StartRecording starts recording of adapter changes to new file.
+func (provider *DataProvider) StartRecording() (err error) {
+	if provider.recorder == nil {
+		return aoserrors.New("recording is not configured")
+	}
+
+	started, err := provider.recorder.start()
+	if err != nil || !started {
+		return err
+	}
+
+	// Recorded paths should be subscribed to receive their changes
+	return provider.updateAdapterSubscriptions(provider.getRecordedPaths())
+}
+
+// StopRecording stops recording of adapter changes.
+func (provider *DataProvider) StopRecording() (err error) {
+	if provider.recorder == nil {
+		return aoserrors.New("recording is not configured")
+	}
+
+	stopped, err := provider.recorder.stop()
+	if err != nil || !stopped {
+		return err
+	}
+
+	return provider.updateAdapterSubscriptions(provider.getRecordedPaths())
+}
+
+// IsRecording returns true if recording is active.
+func (provider *DataProvider) IsRecording() (result bool) {
+	if provider.recorder == nil {
+		return false
+	}
+
+	return provider.recorder.isActive()
+}
+
+/*******************************************************************************
+ * Private
+ ******************************************************************************/
+
+// getRecordedPaths returns recorded paths grouped by adapter.
+func (provider *DataProvider) getRecordedPaths() (adapterPathMap map[DataAdapter][]string) {
+	provider.RLock()
+	defer provider.RUnlock()
+
+	adapterPathMap = make(map[DataAdapter][]string)
+
+	for path, sensor := range provider.sensors {
+		if sensor.recorded {
+			adapterPathMap[sensor.adapter] = append(adapterPathMap[sensor.adapter], path)
+		}
+	}
+
+	return adapterPathMap
+}
+
+func newRecorder(recordingConfig config.RecordingConfig) (rec *recorder, err error) {
+	if recordingConfig.Dir == "" {
+		return nil, nil
+	}
+
+	if recordingConfig.MaxFileSize == 0 {
+		recordingConfig.MaxFileSize = defaultRecordingFileSize
+	}
+
+	if recordingConfig.Quota == 0 {
+		recordingConfig.Quota = defaultRecordingQuota
+	}
+
+	if recordingConfig.MaxFileSize < 0 || recordingConfig.Quota < recordingConfig.MaxFileSize {
+		return nil, aoserrors.New("invalid recording config: quota should not be less than max file size")
+	}
+
+	rec = &recorder{config: recordingConfig}
+
+	for _, path := range recordingConfig.Paths {
+		filter, err := CreatePathFilter(path)
+		if err != nil {
+			return nil, err
+		}
+
+		rec.filters = append(rec.filters, filter)
+	}
+
+	if err = os.MkdirAll(recordingConfig.Dir, 0o755); err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	return rec, nil
+}
+
+// match returns true if path should be recorded. All paths are recorded if no path is configured.
+func (rec *recorder) match(path string) (result bool) {
+	if len(rec.filters) == 0 {
+		return true
+	}
+
+	for _, filter := range rec.filters {
+		if filter.Match(path) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (rec *recorder) isActive() (result bool) {
+	rec.Lock()
+	defer rec.Unlock()
+
+	return rec.file != nil
+}
+
+func (rec *recorder) start() (started bool, err error) {
+	rec.Lock()
+	defer rec.Unlock()
+
+	if rec.file != nil {
+		return false, nil
+	}
+
+	log.WithField("dir", rec.config.Dir).Info("Start recording")
+
+	if err = rec.openFile(); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (rec *recorder) stop() (stopped bool, err error) {
+	rec.Lock()
+	defer rec.Unlock()
+
+	if rec.file == nil {
+		return false, nil
+	}
+
+	log.Info("Stop recording")
+
+	return true, rec.closeFile()
+}
+
+// record writes changes of recorded paths. Recording is stopped on write error.
+func (rec *recorder) record(adapterName string, changes map[string]interface{}, timestamps map[string]time.Time) {
+	rec.Lock()
+	defer rec.Unlock()
+
+	if rec.file == nil {
+		return
+	}
+
+	now := time.Now()
+
+	for path, value := range changes {
+		if !rec.match(path) {
+			continue
+		}
+
+		entry := recordEntry{Timestamp: timestamps[path], Adapter: adapterName, Path: path, Value: value}
+		if entry.Timestamp.IsZero() {
+			entry.Timestamp = now
+		}
+
+		if err := rec.write(entry); err != nil {
+			log.Errorf("Can't write recording, recording stopped: %s", err)
+
+			if err = rec.closeFile(); err != nil {
+				log.Errorf("Can't close recording file: %s", err)
+			}
+
+			return
+		}
+	}
+}
+
+func (rec *recorder) write(entry recordEntry) (err error) {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	line = append(line, '\n')
+
+	if rec.fileSize > 0 && rec.fileSize+int64(len(line)) > rec.config.MaxFileSize {
+		if err = rec.closeFile(); err != nil {
+			return err
+		}
+
+		if err = rec.openFile(); err != nil {
+			return err
+		}
+	}
+
+	if _, err = rec.file.Write(line); err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	rec.fileSize += int64(len(line))
+
+	return nil
+}
+
+// openFile creates new recording file after removing oldest files which exceed quota.
+func (rec *recorder) openFile() (err error) {
+	if err = rec.enforceQuota(); err != nil {
+		return err
+	}
+
+	fileName := filepath.Join(rec.config.Dir,
+		recordingFilePrefix+time.Now().UTC().Format(recordingTimeFormat)+recordingFileSuffix)
+
+	if rec.file, err = os.OpenFile(fileName, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600); err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	rec.fileSize = 0
+
+	log.WithField("file", fileName).Debug("Recording file created")
+
+	return nil
+}
+
+func (rec *recorder) closeFile() (err error) {
+	file := rec.file
+
+	rec.file = nil
+
+	if err = file.Sync(); err != nil {
+		file.Close()
+
+		return aoserrors.Wrap(err)
+	}
+
+	return aoserrors.Wrap(file.Close())
+}
+
+// enforceQuota removes oldest recording files to keep space for new file within quota.
+func (rec *recorder) enforceQuota() (err error) {
+	entries, err := os.ReadDir(rec.config.Dir)
+	if err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	var (
+		fileNames []string
+		sizes     = make(map[string]int64)
+		totalSize int64
+	)
+
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasPrefix(entry.Name(), recordingFilePrefix) ||
+			!strings.HasSuffix(entry.Name(), recordingFileSuffix) {
+			continue
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return aoserrors.Wrap(err)
+		}
+
+		fileNames = append(fileNames, entry.Name())
+		sizes[entry.Name()] = info.Size()
+		totalSize += info.Size()
+	}
+
+	// File names contain creation time, so they are sorted from oldest to newest
+	sort.Strings(fileNames)
+
+	for _, fileName := range fileNames {
+		if totalSize+rec.config.MaxFileSize <= rec.config.Quota {
+			break
+		}
+
+		log.WithField("file", fileName).Debug("Remove recording file due to quota")
+
+		if err = os.Remove(filepath.Join(rec.config.Dir, fileName)); err != nil {
+			return aoserrors.Wrap(err)
+		}
+
+		totalSize -= sizes[fileName]
+	}
+
+	return nil
+}
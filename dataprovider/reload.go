@@ -0,0 +1,338 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2021 Renesas Electronics Corporation.
+// Copyright (C) 2021 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dataprovider
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"sort"
+
+	"github.com/aosedge/aos_common/aoserrors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/aosedge/aos_vis/config"
+)
+
+/*******************************************************************************
+ * Types
+ ******************************************************************************/
+
+// RemovedPaths subscribed paths which disappeared on reload. Terminated is set if subscription has no paths left.
+type RemovedPaths struct {
+	Paths      []string
+	Terminated bool
+}
+
+/*******************************************************************************
+ * Public
+ ******************************************************************************/
+
+// Reload applies adapters configuration: adapters with changed or removed config are closed, new ones are created.
+// Configuration is not applied if any new adapter fails or no adapters are left. Subscriptions on paths provided by
+// new adapters are kept. Paths which disappeared are returned per subscription.
+func (provider *DataProvider) Reload(cfg *config.Config) (removed map[uint64]*RemovedPaths, err error) {
+	log.Info("Reload adapters")
+
+	provider.reloadMutex.Lock()
+	defer provider.reloadMutex.Unlock()
+
+	provider.warnIgnoredChanges(cfg)
+
+	// Adapters list is changed by reload only, so it could be read without lock here
+	keptInstances, removedInstances, newConfigs := provider.diffAdapters(cfg.Adapters)
+	if len(removedInstances) == 0 && len(newConfigs) == 0 {
+		log.Debug("Adapters are not changed")
+
+		return nil, nil
+	}
+
+	if len(keptInstances) == 0 && len(newConfigs) == 0 {
+		return nil, aoserrors.New("no valid adapter info provided")
+	}
+
+	// New adapters are built before current ones are closed, so configuration is not applied if any of them fails
+	newInstances, pathLists, err := buildAdapters(newConfigs)
+	if err != nil {
+		return nil, err
+	}
+
+	provider.Lock()
+	detached := provider.detachAdapters(removedInstances)
+	provider.adapters = keptInstances
+	provider.Unlock()
+
+	// Adapters are closed without lock as their pending changes are handled under lock
+	closeAdapters(removedInstances)
+
+	if err = provider.startAdapters(newInstances, pathLists); err != nil {
+		log.Errorf("Can't start adapters, restore previous ones: %s", err)
+
+		provider.restoreAdapters(removedInstances)
+	}
+
+	provider.Lock()
+	removed, subscribeMap := provider.restoreSubscriptions(detached)
+	provider.Unlock()
+
+	if subscribeErr := provider.updateAdapterSubscriptions(subscribeMap); subscribeErr != nil {
+		log.Errorf("Can't restore subscription: %s", subscribeErr)
+	}
+
+	return removed, err
+}
+
+/*******************************************************************************
+ * Private
+ ******************************************************************************/
+
+// warnIgnoredChanges logs configuration changes which are not applied on reload.
+func (provider *DataProvider) warnIgnoredChanges(cfg *config.Config) {
+	for _, item := range []struct {
+		name    string
+		changed bool
+	}{
+		{"VSSCatalog", cfg.VSSCatalog != provider.config.VSSCatalog},
+		{"VSSCatalogStrict", cfg.VSSCatalogStrict != provider.config.VSSCatalogStrict},
+		{"History", !reflect.DeepEqual(cfg.History, provider.config.History)},
+		{"Recording", !reflect.DeepEqual(cfg.Recording, provider.config.Recording)},
+		{"Subscription", !reflect.DeepEqual(cfg.Subscription, provider.config.Subscription)},
+	} {
+		if item.changed {
+			log.WithField("parameter", item.name).Warn("Config parameter change is ignored on reload")
+		}
+	}
+}
+
+// buildAdapters builds adapters of configs. Built adapters are closed if any of them fails.
+func buildAdapters(
+	adapterConfigs []config.AdapterConfig,
+) (instances []*adapterInstance, pathLists [][]string, err error) {
+	for _, adapterCfg := range adapterConfigs {
+		log.WithField("plugin", adapterCfg.Plugin).Debug("Create adapter")
+
+		instance, pathList, buildErr := buildAdapter(adapterCfg)
+		if buildErr != nil {
+			log.WithField("plugin", adapterCfg.Plugin).Errorf("Can't create adapter: %s", buildErr)
+
+			for _, built := range instances {
+				built.adapter.Close()
+			}
+
+			return nil, nil, buildErr
+		}
+
+		instances = append(instances, instance)
+		pathLists = append(pathLists, pathList)
+	}
+
+	return instances, pathLists, nil
+}
+
+// startAdapters starts built adapters. All adapters are closed if any of them fails.
+func (provider *DataProvider) startAdapters(instances []*adapterInstance, pathLists [][]string) (err error) {
+	for i, instance := range instances {
+		if err = provider.startAdapter(instance, pathLists[i]); err != nil {
+			for _, started := range instances[:i] {
+				provider.removeAdapterSensors(started.adapter)
+				started.close()
+			}
+
+			for _, notStarted := range instances[i+1:] {
+				notStarted.adapter.Close()
+			}
+
+			return err
+		}
+	}
+
+	provider.Lock()
+	provider.adapters = append(provider.adapters, instances...)
+	provider.Unlock()
+
+	return nil
+}
+
+// restoreAdapters recreates closed adapters to roll back failed reload.
+func (provider *DataProvider) restoreAdapters(instances []*adapterInstance) {
+	for _, closed := range instances {
+		instance, err := provider.createAdapter(closed.config)
+		if err != nil {
+			log.WithField("plugin", closed.config.Plugin).Errorf("Can't restore adapter: %s", err)
+
+			continue
+		}
+
+		provider.Lock()
+		provider.adapters = append(provider.adapters, instance)
+		provider.Unlock()
+	}
+}
+
+// diffAdapters splits current adapters to kept and removed ones and returns configs of adapters to be created.
+func (provider *DataProvider) diffAdapters(adapterConfigs []config.AdapterConfig) (
+	keptInstances, removedInstances []*adapterInstance, newConfigs []config.AdapterConfig,
+) {
+	kept := make([]bool, len(provider.adapters))
+
+	for _, adapterCfg := range adapterConfigs {
+		if adapterCfg.Disabled {
+			continue
+		}
+
+		found := false
+
+		for i, instance := range provider.adapters {
+			if !kept[i] && isSameAdapterConfig(instance.config, adapterCfg) {
+				kept[i], found = true, true
+				keptInstances = append(keptInstances, instance)
+
+				break
+			}
+		}
+
+		if !found {
+			newConfigs = append(newConfigs, adapterCfg)
+		}
+	}
+
+	for i, instance := range provider.adapters {
+		if !kept[i] {
+			removedInstances = append(removedInstances, instance)
+		}
+	}
+
+	return keptInstances, removedInstances, newConfigs
+}
+
+// detachAdapters removes sensors of adapters and returns them to restore subscriptions and history.
+func (provider *DataProvider) detachAdapters(instances []*adapterInstance) (detached map[string]*sensorDescription) {
+	detached = make(map[string]*sensorDescription)
+
+	for _, instance := range instances {
+		for path, sensor := range provider.sensors {
+			if sensor.adapter == instance.adapter {
+				detached[path] = sensor
+
+				delete(provider.sensors, path)
+				provider.sensorIndex.Remove(path)
+			}
+		}
+
+		delete(provider.adapterMutexes, instance.adapter)
+	}
+
+	return detached
+}
+
+// restoreSubscriptions moves subscriptions and history of detached sensors to recreated ones and returns paths
+// which are not recreated per subscription and adapter paths which should be subscribed.
+func (provider *DataProvider) restoreSubscriptions(
+	detached map[string]*sensorDescription,
+) (removed map[uint64]*RemovedPaths, subscribeMap map[DataAdapter][]string) {
+	removed = make(map[uint64]*RemovedPaths)
+	subscribeMap = make(map[DataAdapter][]string)
+
+	for path, oldSensor := range detached {
+		sensor, ok := provider.sensors[path]
+		if ok && oldSensor.history != nil && sensor.history != nil {
+			oldSensor.history.merge(sensor.history)
+			sensor.history = oldSensor.history
+		}
+
+		for idElement := oldSensor.subscribeIds.Front(); idElement != nil; idElement = idElement.Next() {
+			id, _ := idElement.Value.(uint64)
+
+			// Subscription could be removed while adapters were closed
+			if _, ok := provider.subscribeInfoMap[id]; !ok {
+				continue
+			}
+
+			if sensor == nil {
+				if removed[id] == nil {
+					removed[id] = &RemovedPaths{}
+				}
+
+				removed[id].Paths = append(removed[id].Paths, path)
+
+				continue
+			}
+
+			sensor.subscribeIds.PushBack(id)
+		}
+
+		if sensor != nil && sensor.subscribeIds.Len() != 0 {
+			subscribeMap[sensor.adapter] = append(subscribeMap[sensor.adapter], path)
+		}
+	}
+
+	for id, removedPaths := range removed {
+		sort.Strings(removedPaths.Paths)
+
+		subscription := provider.subscribeInfoMap[id]
+		subscription.paths = removeSubscriptionPaths(subscription.paths, removedPaths.Paths)
+		removedPaths.Terminated = len(subscription.paths) == 0
+	}
+
+	return removed, subscribeMap
+}
+
+// removeSubscriptionPaths returns subscription paths without removed ones.
+func removeSubscriptionPaths(paths, removedPaths []string) (result []string) {
+	removedSet := make(map[string]struct{}, len(removedPaths))
+
+	for _, path := range removedPaths {
+		removedSet[path] = struct{}{}
+	}
+
+	result = make([]string, 0, len(paths))
+
+	for _, path := range paths {
+		if _, ok := removedSet[path]; !ok {
+			result = append(result, path)
+		}
+	}
+
+	return result
+}
+
+func closeAdapters(instances []*adapterInstance) {
+	for _, instance := range instances {
+		log.WithField("plugin", instance.config.Plugin).Debug("Close adapter")
+
+		instance.close()
+	}
+}
+
+func isSameAdapterConfig(config1, config2 config.AdapterConfig) (result bool) {
+	if config1.Plugin != config2.Plugin {
+		return false
+	}
+
+	return bytes.Equal(compactJSON(config1.Params), compactJSON(config2.Params))
+}
+
+func compactJSON(data json.RawMessage) (result []byte) {
+	var buffer bytes.Buffer
+
+	if err := json.Compact(&buffer, data); err != nil {
+		return data
+	}
+
+	return buffer.Bytes()
+}
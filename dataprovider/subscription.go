@@ -0,0 +1,303 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2021 Renesas Electronics Corporation.
+// Copyright (C) 2021 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dataprovider
+
+import (
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"github.com/aosedge/aos_common/aoserrors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/aosedge/aos_vis/config"
+	"github.com/aosedge/aos_vis/metrics"
+)
+
+/*******************************************************************************
+ * Consts
+ ******************************************************************************/
+
+// Subscription overflow policies.
+const (
+	// OverflowDropNewest drops new notification if subscription queue is full
+	OverflowDropNewest = "dropNewest"
+	// OverflowDropOldest drops the oldest queued notification to put new one
+	OverflowDropOldest = "dropOldest"
+	// OverflowCoalesce merges queued notifications keeping the latest value per path
+	OverflowCoalesce = "coalesce"
+	// OverflowDisconnect terminates subscription
+	OverflowDisconnect = "disconnect"
+)
+
+/*******************************************************************************
+ * Types
+ ******************************************************************************/
+
+// SubscribeOptions subscription options. Data provider defaults are used for empty queue size and overflow policy.
+// MaxPaths limits number of paths matched by subscription, 0 means no limit. If Snapshot is set, values of all
+// matched paths are sent as the first notification.
+type SubscribeOptions struct {
+	QueueSize int
+	Overflow  string
+	MaxPaths  int
+	Snapshot  bool
+}
+
+// Subscription data changes subscription.
+type Subscription struct {
+	ID uint64
+	// Channel is closed on unsubscribe or on overflow with disconnect policy
+	Channel <-chan *Notification
+
+	channel    chan *Notification
+	path       string
+	overflow   string
+	sent       atomic.Uint64
+	dropped    atomic.Uint64
+	lost       atomic.Uint64
+	overflowed atomic.Bool
+	// snapshotPending and pendingData are accessed under data provider write lock: changes are collected to pendingData
+	// until snapshot is sent
+	snapshotPending bool
+	pendingData     map[string]DataPoint
+	// paths subscribed sensor paths, accessed under data provider lock
+	paths []string
+}
+
+// Notification data change notification. It is shared by subscribers notified with the same paths and should not be
+// modified.
+type Notification struct {
+	Data map[string]DataPoint
+	// Snapshot is set for notification with values of all subscribed paths
+	Snapshot bool
+
+	valueOnce sync.Once
+	value     interface{}
+}
+
+/*******************************************************************************
+ * Public
+ ******************************************************************************/
+
+// GetValue returns notification data in VIS value format. Value is converted once and shared by subscribers.
+func (notification *Notification) GetValue(requestedPath string) (value interface{}) {
+	// Only single requested path is converted to simple value, see ConvertData
+	if len(notification.Data) == 1 {
+		if dataPoint, ok := notification.Data[requestedPath]; ok {
+			return dataPoint.Value
+		}
+	}
+
+	notification.valueOnce.Do(func() {
+		notification.value = ConvertData("", GetDataPointValues(notification.Data))
+	})
+
+	return notification.value
+}
+
+// NotificationSent counts notification sent to subscriber.
+func (subscription *Subscription) NotificationSent() {
+	subscription.sent.Add(1)
+}
+
+// Dropped returns total number of notifications dropped or coalesced due to queue overflow.
+func (subscription *Subscription) Dropped() (count uint64) {
+	return subscription.dropped.Load()
+}
+
+// TakeLost returns number of notifications lost since previous call. Coalesced notifications are not counted as lost.
+func (subscription *Subscription) TakeLost() (count uint64) {
+	return subscription.lost.Swap(0)
+}
+
+// Overflowed returns true if subscription is terminated due to overflow.
+func (subscription *Subscription) Overflowed() (result bool) {
+	return subscription.overflowed.Load()
+}
+
+// GetSubscriptionStats returns notification counters of active subscriptions sorted by ID.
+func (provider *DataProvider) GetSubscriptionStats() (stats []metrics.SubscriptionStats) {
+	provider.RLock()
+	defer provider.RUnlock()
+
+	stats = make([]metrics.SubscriptionStats, 0, len(provider.subscribeInfoMap))
+
+	for id, subscription := range provider.subscribeInfoMap {
+		stats = append(stats, metrics.SubscriptionStats{
+			ID: id, Path: subscription.path, Queued: len(subscription.channel),
+			Sent: subscription.sent.Load(), Dropped: subscription.dropped.Load(),
+		})
+	}
+
+	sort.Slice(stats, func(i, j int) bool { return stats[i].ID < stats[j].ID })
+
+	return stats
+}
+
+// GetDroppedNotifications returns total number of notifications dropped or coalesced for all subscriptions.
+func (provider *DataProvider) GetDroppedNotifications() (count uint64) {
+	return provider.droppedNotifications.Load()
+}
+
+/*******************************************************************************
+ * Private
+ ******************************************************************************/
+
+func newSubscribeOptions(cfg config.SubscriptionConfig) (options SubscribeOptions, err error) {
+	options = SubscribeOptions{QueueSize: cfg.QueueSize, Overflow: cfg.Overflow}
+
+	if options.QueueSize <= 0 {
+		options.QueueSize = subscribeChannelSize
+	}
+
+	if options.Overflow == "" {
+		options.Overflow = OverflowDropNewest
+	}
+
+	if err = validateOverflow(options.Overflow); err != nil {
+		return options, err
+	}
+
+	return options, nil
+}
+
+func validateOverflow(overflow string) (err error) {
+	switch overflow {
+	case OverflowDropNewest, OverflowDropOldest, OverflowCoalesce, OverflowDisconnect:
+		return nil
+
+	default:
+		return aoserrors.Errorf("unsupported overflow policy: %s", overflow)
+	}
+}
+
+// newSubscription creates subscription. ID is assigned on registration.
+func newSubscription(path string, options, defaults SubscribeOptions) (subscription *Subscription, err error) {
+	if options.QueueSize <= 0 {
+		options.QueueSize = defaults.QueueSize
+	}
+
+	if options.Overflow == "" {
+		options.Overflow = defaults.Overflow
+	}
+
+	if err = validateOverflow(options.Overflow); err != nil {
+		return nil, err
+	}
+
+	subscription = &Subscription{
+		channel: make(chan *Notification, options.QueueSize), path: path, overflow: options.Overflow,
+		snapshotPending: options.Snapshot,
+	}
+
+	if options.Snapshot {
+		subscription.pendingData = make(map[string]DataPoint)
+	}
+
+	subscription.Channel = subscription.channel
+
+	return subscription, nil
+}
+
+// push puts notification to subscription queue without blocking. It should be called under data provider write lock
+// as queue relies on single sender. False is returned once if subscription should be terminated.
+func (subscription *Subscription) push(notification *Notification) (dropped uint64, ok bool) {
+	// Overflowed subscription is not notified until it is terminated
+	if subscription.overflowed.Load() {
+		return 0, true
+	}
+
+	select {
+	case subscription.channel <- notification:
+		return 0, true
+
+	default:
+	}
+
+	switch subscription.overflow {
+	case OverflowDropOldest:
+		// Queue could be read meanwhile, then there is nothing to drop
+		select {
+		case <-subscription.channel:
+			dropped = 1
+
+		default:
+		}
+
+		subscription.channel <- notification
+
+		subscription.addLost(dropped)
+
+	case OverflowCoalesce:
+		merged := &Notification{Data: make(map[string]DataPoint), Snapshot: notification.Snapshot}
+
+		for queued := true; queued; {
+			select {
+			case queuedNotification := <-subscription.channel:
+				for path, dataPoint := range queuedNotification.Data {
+					merged.Data[path] = dataPoint
+				}
+
+				merged.Snapshot = merged.Snapshot || queuedNotification.Snapshot
+				dropped++
+
+			default:
+				queued = false
+			}
+		}
+
+		for path, dataPoint := range notification.Data {
+			merged.Data[path] = dataPoint
+		}
+
+		// Merged notification replaces queued ones and the new one
+		subscription.channel <- merged
+
+		subscription.dropped.Add(dropped)
+
+	case OverflowDisconnect:
+		dropped = 1
+
+		subscription.addLost(dropped)
+		subscription.overflowed.Store(true)
+
+		return dropped, false
+
+	default:
+		dropped = 1
+
+		subscription.addLost(dropped)
+	}
+
+	return dropped, true
+}
+
+func (subscription *Subscription) addLost(count uint64) {
+	if count == 0 {
+		return
+	}
+
+	subscription.dropped.Add(count)
+
+	if subscription.lost.Add(count) == count {
+		log.WithFields(log.Fields{
+			"subscribeID": subscription.ID, "overflow": subscription.overflow,
+		}).Warn("Subscription queue overflow")
+	}
+}
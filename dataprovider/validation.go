@@ -0,0 +1,211 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2021 Renesas Electronics Corporation.
+// Copyright (C) 2021 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dataprovider
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+	"strings"
+
+	"github.com/aosedge/aos_common/aoserrors"
+)
+
+/*******************************************************************************
+ * Consts
+ ******************************************************************************/
+
+// ArraySuffix suffix of array datatypes.
+const ArraySuffix = "[]"
+
+/*******************************************************************************
+ * Types
+ ******************************************************************************/
+
+type numericRange struct {
+	min float64
+	max float64
+}
+
+/*******************************************************************************
+ * Vars
+ ******************************************************************************/
+
+//nolint:gochecknoglobals // constant table
+var integerRanges = map[string]numericRange{
+	"int8":   {math.MinInt8, math.MaxInt8},
+	"uint8":  {0, math.MaxUint8},
+	"int16":  {math.MinInt16, math.MaxInt16},
+	"uint16": {0, math.MaxUint16},
+	"int32":  {math.MinInt32, math.MaxInt32},
+	"uint32": {0, math.MaxUint32},
+	"int64":  {math.MinInt64, math.MaxInt64},
+	"uint64": {0, math.MaxUint64},
+}
+
+/*******************************************************************************
+ * Public
+ ******************************************************************************/
+
+// GetNumericValue returns float value of numeric VIS value.
+func GetNumericValue(value interface{}) (result float64, ok bool) {
+	if number, ok := value.(json.Number); ok {
+		floatValue, err := number.Float64()
+		if err != nil {
+			return 0, false
+		}
+
+		return floatValue, true
+	}
+
+	reflectValue := reflect.ValueOf(value)
+
+	switch reflectValue.Kind() { //nolint:exhaustive // other kinds are not numeric
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(reflectValue.Int()), true
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(reflectValue.Uint()), true
+
+	case reflect.Float32, reflect.Float64:
+		return reflectValue.Float(), true
+
+	default:
+		return 0, false
+	}
+}
+
+/*******************************************************************************
+ * Private
+ ******************************************************************************/
+
+func isDataTypeSupported(dataType string) (result bool) {
+	switch strings.TrimSuffix(dataType, ArraySuffix) {
+	case "boolean", "string", "float", "double":
+		return true
+
+	default:
+		_, ok := integerRanges[strings.TrimSuffix(dataType, ArraySuffix)]
+
+		return ok
+	}
+}
+
+// validateValue checks value against signal datatype, min, max and allowed values constraints.
+func validateValue(path string, value interface{}, metadata *SignalMetadata) (err error) {
+	if metadata == nil {
+		return nil
+	}
+
+	if metadata.DataType != "" && !isDataTypeSupported(metadata.DataType) {
+		return aoserrors.Errorf("invalid value for path %s: unsupported datatype %s", path, metadata.DataType)
+	}
+
+	if !strings.HasSuffix(metadata.DataType, ArraySuffix) {
+		return validateItem(path, value, metadata.DataType, metadata)
+	}
+
+	reflectValue := reflect.ValueOf(value)
+
+	if reflectValue.Kind() != reflect.Slice && reflectValue.Kind() != reflect.Array {
+		return aoserrors.Errorf("invalid value for path %s: %v is not %s", path, value, metadata.DataType)
+	}
+
+	itemType := strings.TrimSuffix(metadata.DataType, ArraySuffix)
+
+	for i := 0; i < reflectValue.Len(); i++ {
+		if err = validateItem(path, reflectValue.Index(i).Interface(), itemType, metadata); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validateItem(path string, value interface{}, dataType string, metadata *SignalMetadata) (err error) {
+	if err = validateType(path, value, dataType); err != nil {
+		return err
+	}
+
+	if numValue, ok := GetNumericValue(value); ok {
+		if minValue, ok := GetNumericValue(metadata.Min); ok && numValue < minValue {
+			return aoserrors.Errorf("invalid value for path %s: %v is less than min %v", path, value, metadata.Min)
+		}
+
+		if maxValue, ok := GetNumericValue(metadata.Max); ok && numValue > maxValue {
+			return aoserrors.Errorf("invalid value for path %s: %v is greater than max %v", path, value, metadata.Max)
+		}
+	}
+
+	if len(metadata.Allowed) == 0 {
+		return nil
+	}
+
+	for _, allowedValue := range metadata.Allowed {
+		if isValueEqual(value, allowedValue) {
+			return nil
+		}
+	}
+
+	return aoserrors.Errorf("invalid value for path %s: %v is not in allowed values %v", path, value, metadata.Allowed)
+}
+
+func validateType(path string, value interface{}, dataType string) (err error) {
+	switch dataType {
+	case "":
+		return nil
+
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return aoserrors.Errorf("invalid value for path %s: %v is not boolean", path, value)
+		}
+
+	case "string":
+		if _, ok := value.(string); !ok {
+			return aoserrors.Errorf("invalid value for path %s: %v is not string", path, value)
+		}
+
+	case "float", "double":
+		if _, ok := GetNumericValue(value); !ok {
+			return aoserrors.Errorf("invalid value for path %s: %v is not %s", path, value, dataType)
+		}
+
+	default:
+		numValue, ok := GetNumericValue(value)
+		if !ok || numValue != math.Trunc(numValue) {
+			return aoserrors.Errorf("invalid value for path %s: %v is not %s", path, value, dataType)
+		}
+
+		if typeRange := integerRanges[dataType]; numValue < typeRange.min || numValue > typeRange.max {
+			return aoserrors.Errorf("invalid value for path %s: %v is out of %s range", path, value, dataType)
+		}
+	}
+
+	return nil
+}
+
+func isValueEqual(value1, value2 interface{}) (result bool) {
+	numValue1, ok1 := GetNumericValue(value1)
+	numValue2, ok2 := GetNumericValue(value2)
+
+	if ok1 && ok2 {
+		return numValue1 == numValue2
+	}
+
+	return reflect.DeepEqual(value1, value2)
+}
@@ -0,0 +1,150 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2021 Renesas Electronics Corporation.
+// Copyright (C) 2021 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dataprovider
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/aosedge/aos_common/aoserrors"
+	log "github.com/sirupsen/logrus"
+)
+
+/*******************************************************************************
+ * Types
+ ******************************************************************************/
+
+// vssNode node of COVESA VSS JSON export.
+type vssNode struct {
+	Type        string              `json:"type"`
+	DataType    string              `json:"datatype"`
+	Unit        string              `json:"unit"`
+	Min         interface{}         `json:"min"`
+	Max         interface{}         `json:"max"`
+	Allowed     []interface{}       `json:"allowed"`
+	Description string              `json:"description"`
+	Children    map[string]*vssNode `json:"children"`
+}
+
+/*******************************************************************************
+ * Private
+ ******************************************************************************/
+
+// loadVSSCatalog loads VSS JSON export and returns metadata of all catalog nodes by path.
+func loadVSSCatalog(fileName string) (catalog map[string]*SignalMetadata, err error) {
+	file, err := os.Open(fileName)
+	if err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+	defer file.Close()
+
+	var root map[string]*vssNode
+
+	decoder := json.NewDecoder(file)
+	decoder.UseNumber()
+
+	if err = decoder.Decode(&root); err != nil {
+		return nil, aoserrors.Errorf("can't parse VSS catalog %s: %v", fileName, err)
+	}
+
+	catalog = make(map[string]*SignalMetadata)
+
+	for name, node := range root {
+		if err = addVSSNode(catalog, name, node); err != nil {
+			return nil, err
+		}
+	}
+
+	return catalog, nil
+}
+
+func addVSSNode(catalog map[string]*SignalMetadata, path string, node *vssNode) (err error) {
+	if node == nil {
+		return aoserrors.Errorf("invalid VSS node %s", path)
+	}
+
+	switch node.Type {
+	case NodeTypeBranch:
+		if len(node.Children) == 0 {
+			return aoserrors.Errorf("VSS branch %s has no children", path)
+		}
+
+	case NodeTypeSensor, NodeTypeActuator, NodeTypeAttribute:
+		if node.DataType == "" {
+			return aoserrors.Errorf("VSS node %s has no datatype", path)
+		}
+
+		if !isDataTypeSupported(node.DataType) {
+			return aoserrors.Errorf("VSS node %s has unsupported datatype: %s", path, node.DataType)
+		}
+
+		if len(node.Children) != 0 {
+			return aoserrors.Errorf("VSS node %s of type %s can't have children", path, node.Type)
+		}
+
+	default:
+		// Catalog could contain struct type definitions and other nodes which don't describe signals
+		log.WithFields(log.Fields{"path": path, "type": node.Type}).Debug("Skip VSS node of unsupported type")
+
+		return nil
+	}
+
+	catalog[path] = &SignalMetadata{
+		Type:        node.Type,
+		DataType:    node.DataType,
+		Unit:        node.Unit,
+		Min:         node.Min,
+		Max:         node.Max,
+		Allowed:     node.Allowed,
+		Description: node.Description,
+	}
+
+	for name, child := range node.Children {
+		if err = addVSSNode(catalog, path+"."+name, child); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// applyCatalogMetadata overrides signal metadata with VSS catalog one.
+func applyCatalogMetadata(signalMetadata *SignalMetadata, catalogMetadata *SignalMetadata) {
+	signalMetadata.Type = catalogMetadata.Type
+	signalMetadata.DataType = catalogMetadata.DataType
+
+	if catalogMetadata.Unit != "" {
+		signalMetadata.Unit = catalogMetadata.Unit
+	}
+
+	if catalogMetadata.Min != nil {
+		signalMetadata.Min = catalogMetadata.Min
+	}
+
+	if catalogMetadata.Max != nil {
+		signalMetadata.Max = catalogMetadata.Max
+	}
+
+	if catalogMetadata.Allowed != nil {
+		signalMetadata.Allowed = catalogMetadata.Allowed
+	}
+
+	if catalogMetadata.Description != "" {
+		signalMetadata.Description = catalogMetadata.Description
+	}
+}
@@ -0,0 +1,258 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2021 Renesas Electronics Corporation.
+// Copyright (C) 2021 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package metrics collects VIS server and adapters metrics and exposes them in Prometheus format.
+package metrics
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/aosedge/aos_common/aoserrors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	log "github.com/sirupsen/logrus"
+)
+
+/*******************************************************************************
+ * Consts
+ ******************************************************************************/
+
+const namespace = "vis"
+
+// Request protocols.
+const (
+	ProtocolWS   = "ws"
+	ProtocolREST = "rest"
+	ProtocolGRPC = "grpc"
+)
+
+// Adapter operations.
+const (
+	OperationGetData = "getData"
+	OperationSetData = "setData"
+)
+
+/*******************************************************************************
+ * Types
+ ******************************************************************************/
+
+// SubscriptionStats subscription notification counters.
+type SubscriptionStats struct {
+	ID      uint64
+	Path    string
+	Queued  int
+	Sent    uint64
+	Dropped uint64
+}
+
+// StatsProvider provides current server state which is collected on each scrape.
+type StatsProvider interface {
+	GetConnectedClients() (count int)
+	GetSubscriptionStats() (stats []SubscriptionStats)
+}
+
+// Server HTTP server which exposes metrics.
+type Server struct {
+	httpServer *http.Server
+	collector  *statsCollector
+}
+
+type statsCollector struct {
+	statsProvider        StatsProvider
+	connectedClients     *prometheus.Desc
+	activeSubscriptions  *prometheus.Desc
+	queuedNotifications  *prometheus.Desc
+	sentNotifications    *prometheus.Desc
+	droppedNotifications *prometheus.Desc
+}
+
+/*******************************************************************************
+ * Vars
+ ******************************************************************************/
+
+//nolint:gochecknoglobals // metrics are collected by all server parts
+var (
+	registry = prometheus.NewRegistry()
+
+	requestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace, Name: "requests_total", Help: "Number of processed requests.",
+	}, []string{"protocol", "action"})
+
+	requestErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace, Name: "request_errors_total", Help: "Number of failed requests by error number.",
+	}, []string{"protocol", "action", "error"})
+
+	requestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace, Name: "request_duration_seconds", Help: "Request processing time.",
+		Buckets: prometheus.ExponentialBuckets(0.0001, 4, 9), //nolint:gomnd // 100us..6.5s
+	}, []string{"protocol", "action"})
+
+	adapterDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace, Name: "adapter_request_duration_seconds", Help: "Adapter request processing time.",
+		Buckets: prometheus.ExponentialBuckets(0.00001, 4, 9), //nolint:gomnd // 10us..650ms
+	}, []string{"adapter", "operation"})
+
+	adapterErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace, Name: "adapter_errors_total", Help: "Number of failed adapter requests.",
+	}, []string{"adapter", "operation"})
+
+	adapterUpdates = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace, Name: "adapter_updates_total", Help: "Number of values changed by adapter.",
+	}, []string{"adapter"})
+)
+
+/*******************************************************************************
+ * Init
+ ******************************************************************************/
+
+func init() {
+	registry.MustRegister(
+		collectors.NewGoCollector(), collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		requestsTotal, requestErrors, requestDuration, adapterDuration, adapterErrors, adapterUpdates)
+}
+
+/*******************************************************************************
+ * Public
+ ******************************************************************************/
+
+// New creates metrics server listening on url. Server state is requested from stats provider on each scrape.
+func New(url string, statsProvider StatsProvider) (server *Server, err error) {
+	log.WithField("url", url).Debug("Create metrics server")
+
+	collector := newStatsCollector(statsProvider)
+
+	if err = registry.Register(collector); err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	listener, err := net.Listen("tcp", url)
+	if err != nil {
+		registry.Unregister(collector)
+
+		return nil, aoserrors.Wrap(err)
+	}
+
+	serveMux := http.NewServeMux()
+	serveMux.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+
+	server = &Server{
+		httpServer: &http.Server{Addr: url, Handler: serveMux, ReadHeaderTimeout: time.Second},
+		collector:  collector,
+	}
+
+	go func() {
+		if err := server.httpServer.Serve(listener); !errors.Is(err, http.ErrServerClosed) {
+			log.Error("Metrics server listening error: ", aoserrors.Wrap(err))
+		}
+	}()
+
+	return server, nil
+}
+
+// Close closes metrics server.
+func (server *Server) Close() {
+	if err := server.httpServer.Close(); err != nil {
+		log.Errorf("Can't close metrics server: %s", err)
+	}
+
+	registry.Unregister(server.collector)
+}
+
+// ObserveRequest updates request metrics. Error number is zero for successful request.
+func ObserveRequest(protocol, action string, start time.Time, errorNumber int) {
+	requestsTotal.WithLabelValues(protocol, action).Inc()
+	requestDuration.WithLabelValues(protocol, action).Observe(time.Since(start).Seconds())
+
+	if errorNumber != 0 {
+		requestErrors.WithLabelValues(protocol, action, strconv.Itoa(errorNumber)).Inc()
+	}
+}
+
+// ObserveAdapterRequest updates adapter request metrics.
+func ObserveAdapterRequest(adapter, operation string, start time.Time, err error) {
+	adapterDuration.WithLabelValues(adapter, operation).Observe(time.Since(start).Seconds())
+
+	if err != nil {
+		adapterErrors.WithLabelValues(adapter, operation).Inc()
+	}
+}
+
+// AddAdapterUpdates counts values changed by adapter.
+func AddAdapterUpdates(adapter string, count int) {
+	adapterUpdates.WithLabelValues(adapter).Add(float64(count))
+}
+
+// Describe implements prometheus.Collector interface.
+func (collector *statsCollector) Describe(descs chan<- *prometheus.Desc) {
+	descs <- collector.connectedClients
+	descs <- collector.activeSubscriptions
+	descs <- collector.queuedNotifications
+	descs <- collector.sentNotifications
+	descs <- collector.droppedNotifications
+}
+
+// Collect implements prometheus.Collector interface.
+func (collector *statsCollector) Collect(metrics chan<- prometheus.Metric) {
+	metrics <- prometheus.MustNewConstMetric(collector.connectedClients, prometheus.GaugeValue,
+		float64(collector.statsProvider.GetConnectedClients()))
+
+	stats := collector.statsProvider.GetSubscriptionStats()
+
+	metrics <- prometheus.MustNewConstMetric(collector.activeSubscriptions, prometheus.GaugeValue, float64(len(stats)))
+
+	for _, subscription := range stats {
+		labels := []string{strconv.FormatUint(subscription.ID, 10), subscription.Path}
+
+		metrics <- prometheus.MustNewConstMetric(collector.queuedNotifications, prometheus.GaugeValue,
+			float64(subscription.Queued), labels...)
+		metrics <- prometheus.MustNewConstMetric(collector.sentNotifications, prometheus.CounterValue,
+			float64(subscription.Sent), labels...)
+		metrics <- prometheus.MustNewConstMetric(collector.droppedNotifications, prometheus.CounterValue,
+			float64(subscription.Dropped), labels...)
+	}
+}
+
+/*******************************************************************************
+ * Private
+ ******************************************************************************/
+
+func newStatsCollector(statsProvider StatsProvider) (collector *statsCollector) {
+	subscriptionLabels := []string{"subscription", "path"}
+
+	return &statsCollector{
+		statsProvider: statsProvider,
+		connectedClients: prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "connected_clients"),
+			"Number of connected websocket clients.", nil, nil),
+		activeSubscriptions: prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "active_subscriptions"),
+			"Number of active subscriptions.", nil, nil),
+		queuedNotifications: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "subscription", "queued_notifications"),
+			"Number of notifications waiting in subscription queue.", subscriptionLabels, nil),
+		sentNotifications: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "subscription", "notifications_sent_total"),
+			"Number of notifications sent to subscriber.", subscriptionLabels, nil),
+		droppedNotifications: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "subscription", "notifications_dropped_total"),
+			"Number of notifications dropped or coalesced due to subscription queue overflow.",
+			subscriptionLabels, nil),
+	}
+}
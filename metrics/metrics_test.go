@@ -0,0 +1,148 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2021 Renesas Electronics Corporation.
+// Copyright (C) 2021 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics_test
+
+import (
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/aosedge/aos_vis/metrics"
+)
+
+/*******************************************************************************
+ * Consts
+ ******************************************************************************/
+
+const metricsServerURL = "localhost:9091"
+
+/*******************************************************************************
+ * Types
+ ******************************************************************************/
+
+type testStatsProvider struct{}
+
+/*******************************************************************************
+ * Init
+ ******************************************************************************/
+
+func init() {
+	log.SetFormatter(&log.TextFormatter{
+		DisableTimestamp: false,
+		TimestampFormat:  "2006-01-02 15:04:05.000",
+		FullTimestamp:    true,
+	})
+	log.SetLevel(log.DebugLevel)
+	log.SetOutput(os.Stdout)
+}
+
+/*******************************************************************************
+ * Tests
+ ******************************************************************************/
+
+func TestMetrics(t *testing.T) {
+	server, err := metrics.New(metricsServerURL, &testStatsProvider{})
+	if err != nil {
+		t.Fatalf("Can't create metrics server: %s", err)
+	}
+	defer server.Close()
+
+	metrics.ObserveRequest(metrics.ProtocolWS, "get", time.Now(), 0)
+	metrics.ObserveRequest(metrics.ProtocolWS, "get", time.Now(), 404)
+	metrics.ObserveAdapterRequest("TestAdapter", metrics.OperationSetData, time.Now(), errors.New("test error"))
+	metrics.AddAdapterUpdates("TestAdapter", 3)
+
+	body, err := getMetrics()
+	if err != nil {
+		t.Fatalf("Can't get metrics: %s", err)
+	}
+
+	for _, line := range []string{
+		`vis_requests_total{action="get",protocol="ws"} 2`,
+		`vis_request_errors_total{action="get",error="404",protocol="ws"} 1`,
+		`vis_request_duration_seconds_count{action="get",protocol="ws"} 2`,
+		`vis_adapter_request_duration_seconds_count{adapter="TestAdapter",operation="setData"} 1`,
+		`vis_adapter_errors_total{adapter="TestAdapter",operation="setData"} 1`,
+		`vis_adapter_updates_total{adapter="TestAdapter"} 3`,
+		`vis_connected_clients 2`,
+		`vis_active_subscriptions 1`,
+		`vis_subscription_queued_notifications{path="Signal.Vehicle.*",subscription="5"} 1`,
+		`vis_subscription_notifications_sent_total{path="Signal.Vehicle.*",subscription="5"} 10`,
+		`vis_subscription_notifications_dropped_total{path="Signal.Vehicle.*",subscription="5"} 4`,
+	} {
+		if !strings.Contains(body, line+"\n") {
+			t.Errorf("Metric not found: %s", line)
+		}
+	}
+}
+
+func TestListenError(t *testing.T) {
+	listener, err := net.Listen("tcp", metricsServerURL)
+	if err != nil {
+		t.Fatalf("Can't listen: %s", err)
+	}
+	defer listener.Close()
+
+	if _, err = metrics.New(metricsServerURL, &testStatsProvider{}); err == nil {
+		t.Error("Error expected for busy address")
+	}
+}
+
+/*******************************************************************************
+ * Private
+ ******************************************************************************/
+
+func (provider *testStatsProvider) GetConnectedClients() (count int) {
+	return 2
+}
+
+func (provider *testStatsProvider) GetSubscriptionStats() (stats []metrics.SubscriptionStats) {
+	return []metrics.SubscriptionStats{{ID: 5, Path: "Signal.Vehicle.*", Queued: 1, Sent: 10, Dropped: 4}}
+}
+
+func getMetrics() (body string, err error) {
+	var response *http.Response
+
+	// Wait for server is started
+	for i := 0; i < 10; i++ {
+		if response, err = http.Get("http://" + metricsServerURL + "/metrics"); err == nil {
+			break
+		}
+
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	if err != nil {
+		return "", err
+	}
+	defer response.Body.Close()
+
+	data, err := io.ReadAll(response.Body)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
@@ -0,0 +1,114 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2021 Renesas Electronics Corporation.
+// Copyright (C) 2021 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package permissionprovider
+
+import (
+	"sync"
+	"time"
+
+	"github.com/aosedge/aos_vis/dataprovider"
+)
+
+/*******************************************************************************
+ * Types
+ ******************************************************************************/
+
+// permissionsCache caches permissions by token. Failed requests are cached with negative TTL.
+type permissionsCache struct {
+	sync.Mutex
+	ttl         time.Duration
+	negativeTTL time.Duration
+	maxEntries  int
+	entries     map[string]permissionsCacheEntry
+}
+
+type permissionsCacheEntry struct {
+	permissions map[string]string
+	identity    *dataprovider.ClientIdentity
+	err         error
+	expiresAt   time.Time
+}
+
+/*******************************************************************************
+ * Private
+ ******************************************************************************/
+
+func newPermissionsCache(ttl, negativeTTL time.Duration, maxEntries int) (cache *permissionsCache) {
+	return &permissionsCache{
+		ttl: ttl, negativeTTL: negativeTTL, maxEntries: maxEntries,
+		entries: make(map[string]permissionsCacheEntry),
+	}
+}
+
+func (cache *permissionsCache) get(token string) (entry permissionsCacheEntry, ok bool) {
+	cache.Lock()
+	defer cache.Unlock()
+
+	if entry, ok = cache.entries[token]; !ok {
+		return entry, false
+	}
+
+	if !time.Now().Before(entry.expiresAt) {
+		delete(cache.entries, token)
+
+		return entry, false
+	}
+
+	return entry, true
+}
+
+// put caches entry. Zero or negative TTL disables caching.
+func (cache *permissionsCache) put(token string, entry permissionsCacheEntry) {
+	ttl := cache.ttl
+	if entry.err != nil {
+		ttl = cache.negativeTTL
+	}
+
+	if ttl <= 0 {
+		return
+	}
+
+	cache.Lock()
+	defer cache.Unlock()
+
+	now := time.Now()
+
+	if len(cache.entries) >= cache.maxEntries {
+		cache.removeExpired(now)
+	}
+
+	// Arbitrary entry is evicted if all entries are still valid
+	for evictToken := range cache.entries {
+		if len(cache.entries) < cache.maxEntries {
+			break
+		}
+
+		delete(cache.entries, evictToken)
+	}
+
+	entry.expiresAt = now.Add(ttl)
+	cache.entries[token] = entry
+}
+
+func (cache *permissionsCache) removeExpired(now time.Time) {
+	for token, entry := range cache.entries {
+		if !now.Before(entry.expiresAt) {
+			delete(cache.entries, token)
+		}
+	}
+}
@@ -0,0 +1,385 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2021 Renesas Electronics Corporation.
+// Copyright (C) 2021 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package permissionprovider
+
+import (
+	"crypto"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/aosedge/aos_common/aoserrors"
+
+	"github.com/aosedge/aos_vis/config"
+	"github.com/aosedge/aos_vis/dataprovider"
+)
+
+/*******************************************************************************
+ * Consts
+ ******************************************************************************/
+
+// Supported JWT signature algorithms.
+const (
+	AlgorithmRS256 = "RS256"
+	AlgorithmES256 = "ES256"
+)
+
+const (
+	defaultPermissionsClaim = "vis"
+	es256SignatureSize      = 64
+)
+
+/*******************************************************************************
+ * Types
+ ******************************************************************************/
+
+// JWTProvider validates JWT tokens locally and provides permissions from their claims.
+type JWTProvider struct {
+	keys             []jwtKey
+	permissionsClaim string
+}
+
+type jwtKey struct {
+	id  string
+	key crypto.PublicKey
+}
+
+type jwtHeader struct {
+	Algorithm string `json:"alg"`
+	KeyID     string `json:"kid"`
+}
+
+type jwtClaims struct {
+	Subject   string   `json:"sub"`
+	ExpiresAt *float64 `json:"exp"`
+	NotBefore *float64 `json:"nbf"`
+}
+
+type jwkSet struct {
+	Keys []jwk `json:"keys"`
+}
+
+type jwk struct {
+	KeyType string `json:"kty"`
+	KeyID   string `json:"kid"`
+	N       string `json:"n"`
+	E       string `json:"e"`
+	Curve   string `json:"crv"`
+	X       string `json:"x"`
+	Y       string `json:"y"`
+}
+
+/*******************************************************************************
+ * Public
+ ******************************************************************************/
+
+// NewJWT creates JWT permission provider. Token signature is verified with public key from PEM file or with JWKS
+// file key selected by token kid. Token must have exp claim and permissions claim which maps VIS path to access
+// mode: {"sub": "subject1", "exp": 1700000000, "vis": {"Signal.*": "rw"}}.
+func NewJWT(cfg config.PermissionProviderConfig) (provider *JWTProvider, err error) {
+	provider = &JWTProvider{permissionsClaim: cfg.PermissionsClaim}
+
+	if provider.permissionsClaim == "" {
+		provider.permissionsClaim = defaultPermissionsClaim
+	}
+
+	switch {
+	case cfg.JWKSFile != "":
+		if provider.keys, err = loadJWKS(cfg.JWKSFile); err != nil {
+			return nil, err
+		}
+
+	case cfg.PublicKey != "":
+		key, err := loadPublicKey(cfg.PublicKey)
+		if err != nil {
+			return nil, err
+		}
+
+		provider.keys = []jwtKey{{key: key}}
+
+	default:
+		return nil, aoserrors.New("JWT public key or JWKS file is not specified")
+	}
+
+	return provider, nil
+}
+
+// GetVisPermissionByToken get vis permission by token.
+func (provider *JWTProvider) GetVisPermissionByToken(token string) (permissions map[string]string, err error) {
+	permissions, _, _, err = provider.GetVisIdentityByToken(token)
+
+	return permissions, err
+}
+
+// GetVisIdentityByToken validates token and returns its permissions, subject and expiration time.
+func (provider *JWTProvider) GetVisIdentityByToken(
+	token string,
+) (permissions map[string]string, identity *dataprovider.ClientIdentity, expiresAt time.Time, err error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 { //nolint:gomnd // header, payload and signature
+		return nil, nil, time.Time{}, aoserrors.New("malformed JWT token")
+	}
+
+	var header jwtHeader
+
+	if err = decodeJWTPart(parts[0], &header); err != nil {
+		return nil, nil, time.Time{}, err
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, nil, time.Time{}, aoserrors.Wrap(err)
+	}
+
+	if err = provider.verifySignature(header, parts[0]+"."+parts[1], signature); err != nil {
+		return nil, nil, time.Time{}, err
+	}
+
+	var claims jwtClaims
+
+	if err = decodeJWTPart(parts[1], &claims); err != nil {
+		return nil, nil, time.Time{}, err
+	}
+
+	now := float64(time.Now().Unix())
+
+	if claims.ExpiresAt == nil {
+		return nil, nil, time.Time{}, aoserrors.New("JWT token has no expiration time")
+	}
+
+	if now >= *claims.ExpiresAt {
+		return nil, nil, time.Time{}, aoserrors.New("JWT token is expired")
+	}
+
+	if claims.NotBefore != nil && now < *claims.NotBefore {
+		return nil, nil, time.Time{}, aoserrors.New("JWT token is not valid yet")
+	}
+
+	var permissionsClaims map[string]json.RawMessage
+
+	if err = decodeJWTPart(parts[1], &permissionsClaims); err != nil {
+		return nil, nil, time.Time{}, err
+	}
+
+	permissionsClaim, ok := permissionsClaims[provider.permissionsClaim]
+	if !ok {
+		return nil, nil, time.Time{}, aoserrors.Errorf("JWT token has no %s claim", provider.permissionsClaim)
+	}
+
+	if err = json.Unmarshal(permissionsClaim, &permissions); err != nil {
+		return nil, nil, time.Time{}, aoserrors.Errorf("invalid %s claim: %v", provider.permissionsClaim, err)
+	}
+
+	if claims.Subject != "" {
+		identity = &dataprovider.ClientIdentity{SubjectID: claims.Subject}
+	}
+
+	return permissions, identity, time.Unix(int64(*claims.ExpiresAt), 0), nil
+}
+
+// Close closes JWT permission provider.
+func (provider *JWTProvider) Close() {}
+
+/*******************************************************************************
+ * Private
+ ******************************************************************************/
+
+func (provider *JWTProvider) verifySignature(header jwtHeader, signed string, signature []byte) (err error) {
+	hash := sha256.Sum256([]byte(signed))
+
+	for _, key := range provider.keys {
+		if header.KeyID != "" && key.id != "" && header.KeyID != key.id {
+			continue
+		}
+
+		switch publicKey := key.key.(type) {
+		case *rsa.PublicKey:
+			if header.Algorithm == AlgorithmRS256 &&
+				rsa.VerifyPKCS1v15(publicKey, crypto.SHA256, hash[:], signature) == nil {
+				return nil
+			}
+
+		case *ecdsa.PublicKey:
+			if header.Algorithm == AlgorithmES256 && len(signature) == es256SignatureSize &&
+				ecdsa.Verify(publicKey, hash[:], new(big.Int).SetBytes(signature[:es256SignatureSize/2]),
+					new(big.Int).SetBytes(signature[es256SignatureSize/2:])) {
+				return nil
+			}
+		}
+	}
+
+	if header.Algorithm != AlgorithmRS256 && header.Algorithm != AlgorithmES256 {
+		return aoserrors.Errorf("unsupported JWT algorithm: %s", header.Algorithm)
+	}
+
+	return aoserrors.New("invalid JWT signature")
+}
+
+func decodeJWTPart(part string, value interface{}) (err error) {
+	data, err := base64.RawURLEncoding.DecodeString(part)
+	if err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	if err = json.Unmarshal(data, value); err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	return nil
+}
+
+func loadPublicKey(fileName string) (key crypto.PublicKey, err error) {
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, aoserrors.Errorf("no PEM data in %s", fileName)
+	}
+
+	if block.Type == "CERTIFICATE" {
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, aoserrors.Wrap(err)
+		}
+
+		key = cert.PublicKey
+	} else if key, err = x509.ParsePKIXPublicKey(block.Bytes); err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	if err = checkKeyType(key); err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
+
+func loadJWKS(fileName string) (keys []jwtKey, err error) {
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	var keySet jwkSet
+
+	if err = json.Unmarshal(data, &keySet); err != nil {
+		return nil, aoserrors.Errorf("can't parse JWKS file %s: %v", fileName, err)
+	}
+
+	for _, item := range keySet.Keys {
+		key, err := item.publicKey()
+		if err != nil {
+			return nil, aoserrors.Errorf("invalid JWKS key %s: %v", item.KeyID, err)
+		}
+
+		keys = append(keys, jwtKey{id: item.KeyID, key: key})
+	}
+
+	if len(keys) == 0 {
+		return nil, aoserrors.Errorf("no keys in JWKS file %s", fileName)
+	}
+
+	return keys, nil
+}
+
+func (item *jwk) publicKey() (key crypto.PublicKey, err error) {
+	switch item.KeyType {
+	case "RSA":
+		n, err := decodeBigInt(item.N)
+		if err != nil {
+			return nil, err
+		}
+
+		e, err := decodeBigInt(item.E)
+		if err != nil {
+			return nil, err
+		}
+
+		if !e.IsInt64() {
+			return nil, aoserrors.New("invalid RSA exponent")
+		}
+
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+
+	case "EC":
+		if item.Curve != "P-256" {
+			return nil, aoserrors.Errorf("unsupported curve: %s", item.Curve)
+		}
+
+		x, err := base64.RawURLEncoding.DecodeString(item.X)
+		if err != nil {
+			return nil, aoserrors.Wrap(err)
+		}
+
+		y, err := base64.RawURLEncoding.DecodeString(item.Y)
+		if err != nil {
+			return nil, aoserrors.Wrap(err)
+		}
+
+		// Uncompressed point is validated by ecdh as IsOnCurve is deprecated
+		if _, err = ecdh.P256().NewPublicKey(append(append([]byte{4}, x...), y...)); err != nil {
+			return nil, aoserrors.Wrap(err)
+		}
+
+		return &ecdsa.PublicKey{
+			Curve: elliptic.P256(), X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y),
+		}, nil
+
+	default:
+		return nil, aoserrors.Errorf("unsupported key type: %s", item.KeyType)
+	}
+}
+
+func decodeBigInt(value string) (result *big.Int, err error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	return new(big.Int).SetBytes(data), nil
+}
+
+func checkKeyType(key crypto.PublicKey) (err error) {
+	switch publicKey := key.(type) {
+	case *rsa.PublicKey:
+		return nil
+
+	case *ecdsa.PublicKey:
+		if publicKey.Curve != elliptic.P256() {
+			return aoserrors.New("unsupported ECDSA curve")
+		}
+
+		return nil
+
+	default:
+		return aoserrors.Errorf("unsupported public key type: %T", key)
+	}
+}
@@ -19,31 +19,50 @@ package permissionprovider
 
 import (
 	"context"
+	"sync"
 	"time"
 
+	log "github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
 
 	"github.com/aosedge/aos_common/aoserrors"
 	pb "github.com/aosedge/aos_common/api/iamanager"
 	"github.com/aosedge/aos_common/utils/cryptutils"
 
 	"github.com/aosedge/aos_vis/config"
+	"github.com/aosedge/aos_vis/dataprovider"
 )
 
 /*******************************************************************************
  * Types
  ******************************************************************************/
 
-// PermissionProvider vis permission provider.
+// Provider vis permission provider interface. GetVisIdentityByToken returns zero expiresAt if token validity is not
+// limited by provider.
+type Provider interface {
+	GetVisPermissionByToken(token string) (permissions map[string]string, err error)
+	GetVisIdentityByToken(token string) (
+		permissions map[string]string, identity *dataprovider.ClientIdentity, expiresAt time.Time, err error)
+	Close()
+}
+
+// PermissionProvider vis permission provider of Aos IAM. IAM responses are cached per token. Connection is closed
+// on transport failure and reconnected with exponential backoff.
 type PermissionProvider struct {
-	serverURL     string
-	rootCert      string
-	insecure      bool
-	cryptoContext *cryptutils.CryptoContext
-	iamClient     pb.IAMPublicPermissionsServiceClient
-	connection    *grpc.ClientConn
+	sync.Mutex
+	serverURL      string
+	rootCert       string
+	insecure       bool
+	cryptoContext  *cryptutils.CryptoContext
+	iamClient      pb.IAMPublicPermissionsServiceClient
+	connection     *grpc.ClientConn
+	cache          *permissionsCache
+	reconnectDelay time.Duration
+	reconnectTime  time.Time
 }
 
 /*******************************************************************************
@@ -51,20 +70,69 @@ type PermissionProvider struct {
  ******************************************************************************/
 
 const (
-	iamRequestTimeout = 30 * time.Second
+	iamRequestTimeout     = 30 * time.Second
+	minReconnectDelay     = 1 * time.Second
+	maxReconnectDelay     = 1 * time.Minute
+	defaultCacheTTL       = 60 // seconds
+	defaultNegativeTTL    = 5  // seconds
+	maxPermissionsEntries = 1024
 )
 
 const visFunctionalServerID = "vis"
 
+// Permission provider types.
+const (
+	ProviderTypeIAM    = "iam"
+	ProviderTypeStatic = "static"
+	ProviderTypeJWT    = "jwt"
+)
+
 /*******************************************************************************
  * Public
  ******************************************************************************/
 
-// New creates new permission provider.
+// NewProvider creates permission provider of configured type.
+func NewProvider(config *config.Config, insecure bool) (provider Provider, err error) {
+	// Typed providers are checked for error to not return non nil interface with nil value
+	switch config.PermissionProvider.Type {
+	case "", ProviderTypeIAM:
+		iamProvider, err := New(config, insecure)
+		if err != nil {
+			return nil, err
+		}
+
+		return iamProvider, nil
+
+	case ProviderTypeStatic:
+		staticProvider, err := NewStatic(config.PermissionProvider.TokensFile)
+		if err != nil {
+			return nil, err
+		}
+
+		return staticProvider, nil
+
+	case ProviderTypeJWT:
+		jwtProvider, err := NewJWT(config.PermissionProvider)
+		if err != nil {
+			return nil, err
+		}
+
+		return jwtProvider, nil
+
+	default:
+		return nil, aoserrors.Errorf("unsupported permission provider type: %s", config.PermissionProvider.Type)
+	}
+}
+
+// New creates new IAM permission provider.
 func New(config *config.Config, insecure bool) (provider *PermissionProvider, err error) {
 	provider = &PermissionProvider{
 		serverURL: config.PermissionServerURL,
 		rootCert:  config.CACert, iamClient: nil, insecure: insecure, connection: nil,
+		cache: newPermissionsCache(
+			getCacheTTL(config.PermissionProvider.CacheTTL, defaultCacheTTL),
+			getCacheTTL(config.PermissionProvider.NegativeCacheTTL, defaultNegativeTTL), maxPermissionsEntries),
+		reconnectDelay: minReconnectDelay,
 	}
 
 	if provider.cryptoContext, err = cryptutils.NewCryptoContext(config.CACert); err != nil {
@@ -76,10 +144,23 @@ func New(config *config.Config, insecure bool) (provider *PermissionProvider, er
 
 // GetVisPermissionByToken get vis permission by token.
 func (provider *PermissionProvider) GetVisPermissionByToken(token string) (permissions map[string]string, err error) {
-	if provider.connection == nil {
-		if err = provider.connect(); err != nil {
-			return permissions, err
-		}
+	permissions, _, _, err = provider.GetVisIdentityByToken(token)
+
+	return permissions, err
+}
+
+// GetVisIdentityByToken get vis permission and identity of service instance by token. IAM permissions response
+// doesn't contain token validity, so zero expiresAt is returned and authorization TTL is defined by config.
+func (provider *PermissionProvider) GetVisIdentityByToken(
+	token string,
+) (permissions map[string]string, identity *dataprovider.ClientIdentity, expiresAt time.Time, err error) {
+	if entry, ok := provider.cache.get(token); ok {
+		return entry.permissions, entry.identity, time.Time{}, entry.err
+	}
+
+	iamClient, err := provider.getIAMClient()
+	if err != nil {
+		return nil, nil, time.Time{}, err
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), iamRequestTimeout)
@@ -87,18 +168,43 @@ func (provider *PermissionProvider) GetVisPermissionByToken(token string) (permi
 
 	req := &pb.PermissionsRequest{Secret: token, FunctionalServerId: visFunctionalServerID}
 
-	response, err := provider.iamClient.GetPermissions(ctx, req)
+	response, err := iamClient.GetPermissions(ctx, req)
 	if err != nil {
-		return permissions, aoserrors.Wrap(err)
+		if isConnectionError(err) {
+			provider.connectionFailed(err)
+
+			return nil, nil, time.Time{}, aoserrors.Wrap(err)
+		}
+
+		// Rejected tokens are cached to not request IAM on each retry
+		provider.cache.put(token, permissionsCacheEntry{err: aoserrors.Wrap(err)})
+
+		return nil, nil, time.Time{}, aoserrors.Wrap(err)
 	}
 
-	return response.GetPermissions().GetPermissions(), nil
+	provider.connectionSucceeded()
+
+	if instance := response.GetInstance(); instance != nil {
+		identity = &dataprovider.ClientIdentity{
+			ServiceID: instance.GetServiceId(), SubjectID: instance.GetSubjectId(), Instance: instance.GetInstance(),
+		}
+	}
+
+	permissions = response.GetPermissions().GetPermissions()
+
+	provider.cache.put(token, permissionsCacheEntry{permissions: permissions, identity: identity})
+
+	return permissions, identity, time.Time{}, nil
 }
 
 // Close close connection with permission provider grpc server.
 func (provider *PermissionProvider) Close() {
+	provider.Lock()
+	defer provider.Unlock()
+
 	if provider.connection != nil {
 		provider.connection.Close()
+		provider.connection = nil
 	}
 }
 
@@ -106,6 +212,61 @@ func (provider *PermissionProvider) Close() {
  * Private
  ******************************************************************************/
 
+// getIAMClient returns IAM client. Connection is established on first request and after reconnect delay expires.
+func (provider *PermissionProvider) getIAMClient() (iamClient pb.IAMPublicPermissionsServiceClient, err error) {
+	provider.Lock()
+	defer provider.Unlock()
+
+	if provider.connection == nil {
+		if delay := time.Until(provider.reconnectTime); delay > 0 {
+			return nil, aoserrors.Errorf("IAM is not available, reconnect in %v", delay.Round(time.Millisecond))
+		}
+
+		if err = provider.connect(); err != nil {
+			provider.scheduleReconnect()
+
+			return nil, err
+		}
+	}
+
+	return provider.iamClient, nil
+}
+
+// connectionFailed closes failed connection and schedules reconnect.
+func (provider *PermissionProvider) connectionFailed(err error) {
+	provider.Lock()
+	defer provider.Unlock()
+
+	// Connection could be already closed by concurrent request
+	if provider.connection == nil {
+		return
+	}
+
+	log.WithField("url", provider.serverURL).Warnf("IAM connection failed: %s", err)
+
+	provider.connection.Close()
+	provider.connection = nil
+
+	provider.scheduleReconnect()
+}
+
+func (provider *PermissionProvider) connectionSucceeded() {
+	provider.Lock()
+	defer provider.Unlock()
+
+	provider.reconnectDelay = minReconnectDelay
+}
+
+func (provider *PermissionProvider) scheduleReconnect() {
+	provider.reconnectTime = time.Now().Add(provider.reconnectDelay)
+
+	log.WithField("delay", provider.reconnectDelay).Debug("Schedule IAM reconnect")
+
+	if provider.reconnectDelay *= 2; provider.reconnectDelay > maxReconnectDelay {
+		provider.reconnectDelay = maxReconnectDelay
+	}
+}
+
 func (provider *PermissionProvider) connect() (err error) {
 	var secureOpt grpc.DialOption
 
@@ -128,3 +289,22 @@ func (provider *PermissionProvider) connect() (err error) {
 
 	return nil
 }
+
+// isConnectionError returns true if IAM request is failed due to transport or IAM unavailability.
+func isConnectionError(err error) (result bool) {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.Canceled:
+		return true
+
+	default:
+		return false
+	}
+}
+
+func getCacheTTL(ttl, defaultTTL int64) (duration time.Duration) {
+	if ttl == 0 {
+		ttl = defaultTTL
+	}
+
+	return time.Duration(ttl) * time.Second
+}
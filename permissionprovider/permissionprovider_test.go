@@ -19,18 +19,35 @@ package permissionprovider_test
 
 import (
 	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
 	"net"
 	"os"
+	"path/filepath"
 	"reflect"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
 
 	"github.com/aosedge/aos_common/aoserrors"
+	pbcommon "github.com/aosedge/aos_common/api/common"
 	pb "github.com/aosedge/aos_common/api/iamanager"
 
 	"github.com/aosedge/aos_vis/config"
+	"github.com/aosedge/aos_vis/dataprovider"
 	"github.com/aosedge/aos_vis/permissionprovider"
 )
 
@@ -41,6 +58,7 @@ import (
 type testServer struct {
 	grpcServer  *grpc.Server
 	permissions map[string]map[string]string
+	requests    atomic.Int32
 	pb.UnimplementedIAMPublicPermissionsServiceServer
 }
 
@@ -50,6 +68,7 @@ type testServer struct {
 
 const (
 	serverURL             = "localhost:8090"
+	cacheServerURL        = "localhost:8092"
 	visFunctionalServerID = "vis"
 	secret                = "secret_ID"
 )
@@ -100,6 +119,283 @@ func TestGetPermissions(t *testing.T) {
 	if !reflect.DeepEqual(origPermissions, permissions) {
 		t.Errorf("Incorrect permissions: %s", err)
 	}
+
+	permissions, identity, _, err := permissionProvider.GetVisIdentityByToken(secret)
+	if err != nil {
+		t.Errorf("Can't get identity: %s", err)
+	}
+
+	if !reflect.DeepEqual(origPermissions, permissions) {
+		t.Errorf("Incorrect permissions: %v", permissions)
+	}
+
+	expectedIdentity := &dataprovider.ClientIdentity{ServiceID: "service1", SubjectID: "subject1", Instance: 1}
+
+	if !reflect.DeepEqual(identity, expectedIdentity) {
+		t.Errorf("Incorrect identity: %v", identity)
+	}
+}
+
+func TestPermissionsCache(t *testing.T) {
+	server, err := newTestServer(cacheServerURL)
+	if err != nil {
+		t.Fatalf("Can't create test server: %s", err)
+	}
+
+	server.SetPermissions(secret, map[string]string{"Signal.*": "r"})
+
+	permissionProvider, err := permissionprovider.New(&config.Config{
+		PermissionServerURL: cacheServerURL,
+		PermissionProvider:  config.PermissionProviderConfig{CacheTTL: 60, NegativeCacheTTL: 1},
+	}, true)
+	if err != nil {
+		t.Fatalf("Can't create permission provider: %s", err)
+	}
+
+	defer permissionProvider.Close()
+
+	for i := 0; i < 3; i++ {
+		if _, err = permissionProvider.GetVisPermissionByToken(secret); err != nil {
+			t.Errorf("Can't get permissions: %s", err)
+		}
+
+		if _, err = permissionProvider.GetVisPermissionByToken("unknown"); err == nil {
+			t.Error("Error expected for unknown token")
+		}
+	}
+
+	if requests := server.requests.Load(); requests != 2 {
+		t.Errorf("Wrong IAM requests count: %d", requests)
+	}
+
+	// Negative cache entry expires
+	time.Sleep(time.Second)
+
+	if _, err = permissionProvider.GetVisPermissionByToken("unknown"); err == nil {
+		t.Error("Error expected for unknown token")
+	}
+
+	if requests := server.requests.Load(); requests != 3 {
+		t.Errorf("Wrong IAM requests count: %d", requests)
+	}
+
+	// IAM is unavailable: reconnect is scheduled and requests fail without waiting for IAM
+	server.close()
+
+	if _, err = permissionProvider.GetVisPermissionByToken("token1"); err == nil {
+		t.Error("Error expected for unavailable IAM")
+	}
+
+	if _, err = permissionProvider.GetVisPermissionByToken("token1"); err == nil ||
+		!strings.Contains(err.Error(), "reconnect") {
+		t.Errorf("Reconnect error expected: %v", err)
+	}
+
+	// Cached permissions are still provided
+	if _, err = permissionProvider.GetVisPermissionByToken(secret); err != nil {
+		t.Errorf("Can't get permissions: %s", err)
+	}
+
+	if server, err = newTestServer(cacheServerURL); err != nil {
+		t.Fatalf("Can't create test server: %s", err)
+	}
+	defer server.close()
+
+	server.SetPermissions("token1", map[string]string{"Signal.*": "rw"})
+
+	time.Sleep(time.Second)
+
+	if _, err = permissionProvider.GetVisPermissionByToken("token1"); err != nil {
+		t.Errorf("Can't get permissions after reconnect: %s", err)
+	}
+}
+
+func TestStaticProvider(t *testing.T) {
+	tokensFile := filepath.Join(t.TempDir(), "tokens.json")
+
+	if err := os.WriteFile(tokensFile, []byte(`{
+		"token1": {"permissions": {"Signal.*": "rw"}, "identity": {"serviceId": "service1", "subjectId": "subject1"}},
+		"token2": {"permissions": {"Attribute.*": "r"}}
+	}`), 0o600); err != nil {
+		t.Fatalf("Can't write tokens file: %s", err)
+	}
+
+	provider, err := permissionprovider.NewProvider(&config.Config{
+		PermissionProvider: config.PermissionProviderConfig{Type: "static", TokensFile: tokensFile},
+	}, false)
+	if err != nil {
+		t.Fatalf("Can't create permission provider: %s", err)
+	}
+	defer provider.Close()
+
+	permissions, identity, _, err := provider.GetVisIdentityByToken("token1")
+	if err != nil {
+		t.Fatalf("Can't get identity: %s", err)
+	}
+
+	if !reflect.DeepEqual(permissions, map[string]string{"Signal.*": "rw"}) {
+		t.Errorf("Incorrect permissions: %v", permissions)
+	}
+
+	if !reflect.DeepEqual(identity, &dataprovider.ClientIdentity{ServiceID: "service1", SubjectID: "subject1"}) {
+		t.Errorf("Incorrect identity: %v", identity)
+	}
+
+	if permissions, err = provider.GetVisPermissionByToken("token2"); err != nil {
+		t.Fatalf("Can't get permissions: %s", err)
+	}
+
+	if !reflect.DeepEqual(permissions, map[string]string{"Attribute.*": "r"}) {
+		t.Errorf("Incorrect permissions: %v", permissions)
+	}
+
+	if _, err = provider.GetVisPermissionByToken("token3"); err == nil {
+		t.Error("Error expected for unknown token")
+	}
+}
+
+func TestJWTProvider(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Can't generate RSA key: %s", err)
+	}
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Can't generate ECDSA key: %s", err)
+	}
+
+	publicKeyFile := filepath.Join(tmpDir, "public.pem")
+
+	if err = writePublicKey(publicKeyFile, &rsaKey.PublicKey); err != nil {
+		t.Fatalf("Can't write public key: %s", err)
+	}
+
+	jwksFile := filepath.Join(tmpDir, "jwks.json")
+
+	if err = writeJWKS(jwksFile, "ec1", &ecKey.PublicKey); err != nil {
+		t.Fatalf("Can't write JWKS: %s", err)
+	}
+
+	rsaProvider, err := permissionprovider.NewProvider(&config.Config{
+		PermissionProvider: config.PermissionProviderConfig{Type: "jwt", PublicKey: publicKeyFile},
+	}, false)
+	if err != nil {
+		t.Fatalf("Can't create permission provider: %s", err)
+	}
+	defer rsaProvider.Close()
+
+	ecProvider, err := permissionprovider.NewProvider(&config.Config{
+		PermissionProvider: config.PermissionProviderConfig{
+			Type: "jwt", JWKSFile: jwksFile, PermissionsClaim: "permissions",
+		},
+	}, false)
+	if err != nil {
+		t.Fatalf("Can't create permission provider: %s", err)
+	}
+	defer ecProvider.Close()
+
+	exp := time.Now().Add(time.Hour).Unix()
+	permissions := map[string]string{"Signal.*": "rw"}
+
+	type testData struct {
+		provider permissionprovider.Provider
+		token    string
+		err      string
+	}
+
+	testItems := []testData{
+		{
+			provider: rsaProvider,
+			token:    createRS256Token(t, rsaKey, "", map[string]interface{}{"sub": "subject1", "exp": exp, "vis": permissions}),
+		},
+		{
+			provider: ecProvider,
+			token: createES256Token(t, ecKey, "ec1",
+				map[string]interface{}{"sub": "subject1", "exp": exp, "permissions": permissions}),
+		},
+		{
+			provider: rsaProvider,
+			token: createRS256Token(t, rsaKey, "", map[string]interface{}{
+				"sub": "subject1", "exp": time.Now().Add(-time.Minute).Unix(), "vis": permissions,
+			}),
+			err: "expired",
+		},
+		{
+			provider: rsaProvider,
+			token:    createRS256Token(t, rsaKey, "", map[string]interface{}{"sub": "subject1", "vis": permissions}),
+			err:      "no expiration time",
+		},
+		{
+			provider: ecProvider,
+			token:    createES256Token(t, ecKey, "ec1", map[string]interface{}{"sub": "subject1", "exp": exp}),
+			err:      "no permissions claim",
+		},
+		{
+			provider: ecProvider,
+			token: createES256Token(t, ecKey, "ec2",
+				map[string]interface{}{"sub": "subject1", "exp": exp, "permissions": permissions}),
+			err: "invalid JWT signature",
+		},
+		{
+			provider: ecProvider,
+			token: createRS256Token(t, rsaKey, "ec1",
+				map[string]interface{}{"sub": "subject1", "exp": exp, "permissions": permissions}),
+			err: "invalid JWT signature",
+		},
+		{
+			provider: rsaProvider,
+			token: encodeJWTPart(t, map[string]interface{}{"alg": "none"}) + "." +
+				encodeJWTPart(t, map[string]interface{}{"exp": exp, "vis": permissions}) + ".",
+			err: "unsupported JWT algorithm",
+		},
+		{provider: rsaProvider, token: "malformed", err: "malformed"},
+	}
+
+	for i, item := range testItems {
+		tokenPermissions, identity, expiresAt, err := item.provider.GetVisIdentityByToken(item.token)
+
+		if item.err != "" {
+			if err == nil || !strings.Contains(err.Error(), item.err) {
+				t.Errorf("Token %d: error %q expected: %v", i, item.err, err)
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("Token %d: can't get identity: %s", i, err)
+			continue
+		}
+
+		if !reflect.DeepEqual(tokenPermissions, permissions) {
+			t.Errorf("Token %d: incorrect permissions: %v", i, tokenPermissions)
+		}
+
+		if identity == nil || identity.SubjectID != "subject1" {
+			t.Errorf("Token %d: incorrect identity: %v", i, identity)
+		}
+
+		if expiresAt.Unix() != exp {
+			t.Errorf("Token %d: incorrect expiration time: %v", i, expiresAt)
+		}
+	}
+}
+
+func TestUnsupportedProvider(t *testing.T) {
+	if _, err := permissionprovider.NewProvider(&config.Config{
+		PermissionProvider: config.PermissionProviderConfig{Type: "unknown"},
+	}, false); err == nil {
+		t.Error("Error expected for unsupported provider type")
+	}
+
+	if _, err := permissionprovider.NewProvider(&config.Config{
+		PermissionProvider: config.PermissionProviderConfig{Type: "jwt"},
+	}, false); err == nil {
+		t.Error("Error expected for JWT provider without keys")
+	}
 }
 
 /*******************************************************************************
@@ -136,6 +432,8 @@ func (server *testServer) close() {
 func (server *testServer) GetPermissions(
 	ctx context.Context, req *pb.PermissionsRequest,
 ) (rsp *pb.PermissionsResponse, err error) {
+	server.requests.Add(1)
+
 	rsp = &pb.PermissionsResponse{}
 
 	if req.GetFunctionalServerId() != visFunctionalServerID {
@@ -147,6 +445,7 @@ func (server *testServer) GetPermissions(
 		return rsp, aoserrors.New("secret not found")
 	}
 
+	rsp.Instance = &pbcommon.InstanceIdent{ServiceId: "service1", SubjectId: "subject1", Instance: 1}
 	rsp.Permissions = &pb.Permissions{Permissions: servicePermissions}
 
 	return rsp, nil
@@ -155,3 +454,68 @@ func (server *testServer) GetPermissions(
 func (server *testServer) SetPermissions(secret string, permissions map[string]string) {
 	server.permissions[secret] = permissions
 }
+
+func writePublicKey(fileName string, key crypto.PublicKey) (err error) {
+	der, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	return aoserrors.Wrap(os.WriteFile(fileName, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0o600))
+}
+
+func writeJWKS(fileName, keyID string, key *ecdsa.PublicKey) (err error) {
+	encodeCoordinate := func(value *big.Int) string {
+		return base64.RawURLEncoding.EncodeToString(value.FillBytes(make([]byte, 32)))
+	}
+
+	data, err := json.Marshal(map[string]interface{}{"keys": []map[string]string{{
+		"kty": "EC", "kid": keyID, "crv": "P-256", "x": encodeCoordinate(key.X), "y": encodeCoordinate(key.Y),
+	}}})
+	if err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	return aoserrors.Wrap(os.WriteFile(fileName, data, 0o600))
+}
+
+func encodeJWTPart(t *testing.T, value interface{}) (part string) {
+	t.Helper()
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		t.Fatalf("Can't marshal JWT part: %s", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func createRS256Token(t *testing.T, key *rsa.PrivateKey, keyID string, claims map[string]interface{}) (token string) {
+	t.Helper()
+
+	signed := encodeJWTPart(t, map[string]interface{}{"alg": "RS256", "kid": keyID}) + "." + encodeJWTPart(t, claims)
+	hash := sha256.Sum256([]byte(signed))
+
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, hash[:])
+	if err != nil {
+		t.Fatalf("Can't sign token: %s", err)
+	}
+
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func createES256Token(t *testing.T, key *ecdsa.PrivateKey, keyID string, claims map[string]interface{}) (token string) {
+	t.Helper()
+
+	signed := encodeJWTPart(t, map[string]interface{}{"alg": "ES256", "kid": keyID}) + "." + encodeJWTPart(t, claims)
+	hash := sha256.Sum256([]byte(signed))
+
+	r, s, err := ecdsa.Sign(rand.Reader, key, hash[:])
+	if err != nil {
+		t.Fatalf("Can't sign token: %s", err)
+	}
+
+	signature := append(r.FillBytes(make([]byte, 32)), s.FillBytes(make([]byte, 32))...)
+
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
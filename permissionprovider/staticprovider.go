@@ -0,0 +1,85 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2021 Renesas Electronics Corporation.
+// Copyright (C) 2021 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package permissionprovider
+
+import (
+	"encoding/json"
+	"os"
+	"time"
+
+	"github.com/aosedge/aos_common/aoserrors"
+
+	"github.com/aosedge/aos_vis/dataprovider"
+)
+
+/*******************************************************************************
+ * Types
+ ******************************************************************************/
+
+// StaticProvider provides permissions of tokens listed in file.
+type StaticProvider struct {
+	tokens map[string]staticToken
+}
+
+type staticToken struct {
+	Permissions map[string]string            `json:"permissions"`
+	Identity    *dataprovider.ClientIdentity `json:"identity"`
+}
+
+/*******************************************************************************
+ * Public
+ ******************************************************************************/
+
+// NewStatic creates static permission provider. Tokens file contains object which maps token to its permissions and
+// optional identity: {"token1": {"permissions": {"Signal.*": "rw"}, "identity": {"subjectId": "subject1"}}}.
+func NewStatic(tokensFile string) (provider *StaticProvider, err error) {
+	data, err := os.ReadFile(tokensFile)
+	if err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	provider = &StaticProvider{}
+
+	if err = json.Unmarshal(data, &provider.tokens); err != nil {
+		return nil, aoserrors.Errorf("can't parse tokens file %s: %v", tokensFile, err)
+	}
+
+	return provider, nil
+}
+
+// GetVisPermissionByToken get vis permission by token.
+func (provider *StaticProvider) GetVisPermissionByToken(token string) (permissions map[string]string, err error) {
+	permissions, _, _, err = provider.GetVisIdentityByToken(token)
+
+	return permissions, err
+}
+
+// GetVisIdentityByToken get vis permission and identity by token.
+func (provider *StaticProvider) GetVisIdentityByToken(
+	token string,
+) (permissions map[string]string, identity *dataprovider.ClientIdentity, expiresAt time.Time, err error) {
+	staticToken, ok := provider.tokens[token]
+	if !ok {
+		return nil, nil, time.Time{}, aoserrors.New("unknown token")
+	}
+
+	return staticToken.Permissions, staticToken.Identity, time.Time{}, nil
+}
+
+// Close closes static permission provider.
+func (provider *StaticProvider) Close() {}
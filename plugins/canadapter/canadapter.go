@@ -0,0 +1,481 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2021 Renesas Electronics Corporation.
+// Copyright (C) 2021 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package canadapter
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/aosedge/aos_common/aoserrors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/aosedge/aos_vis/dataprovider"
+	"github.com/aosedge/aos_vis/plugins/canadapter/dbc"
+)
+
+/*******************************************************************************
+ * Consts
+ ******************************************************************************/
+
+const defaultDataType = "double"
+
+/*******************************************************************************
+ * Types
+ ******************************************************************************/
+
+// CANAdapter SocketCAN adapter.
+type CANAdapter struct {
+	sync.Mutex
+	baseAdapter *dataprovider.BaseAdapter
+	bus         canBus
+	signals     map[string]*signalMapping
+	messages    map[uint32][]*signalMapping
+	frames      map[uint32][]byte
+	wg          sync.WaitGroup
+}
+
+type adapterConfig struct {
+	Interface string         `json:"interface"`
+	DBCFile   string         `json:"dbcFile"`
+	Signals   []signalConfig `json:"signals"`
+}
+
+type signalConfig struct {
+	Path     string   `json:"path"`
+	Message  string   `json:"message"`
+	Signal   string   `json:"signal"`
+	Scale    *float64 `json:"scale"`
+	Offset   float64  `json:"offset"`
+	Unit     *string  `json:"unit"`
+	DataType string   `json:"dataType"`
+	Writable bool     `json:"writable"`
+	Public   bool     `json:"public"`
+}
+
+// signalMapping maps DBC signal to VIS path: VIS value = DBC physical value * scale + offset.
+type signalMapping struct {
+	path     string
+	message  *dbc.Message
+	signal   *dbc.Signal
+	scale    float64
+	offset   float64
+	dataType string
+}
+
+type canFrame struct {
+	id       uint32
+	extended bool
+	data     []byte
+}
+
+type canBus interface {
+	readFrame() (frame canFrame, err error)
+	writeFrame(frame canFrame) (err error)
+	close() (err error)
+}
+
+/*******************************************************************************
+ * Vars
+ ******************************************************************************/
+
+var errBusClosed = errors.New("bus closed")
+
+/*******************************************************************************
+ * Public
+ ******************************************************************************/
+
+// New creates adapter instance.
+func New(configJSON json.RawMessage) (adapter dataprovider.DataAdapter, err error) {
+	return newAdapter(configJSON, openSocketCAN)
+}
+
+// Close closes adapter.
+func (adapter *CANAdapter) Close() {
+	log.Info("Close CAN adapter")
+
+	if err := adapter.bus.close(); err != nil {
+		log.Errorf("Can't close CAN bus: %s", err)
+	}
+
+	adapter.wg.Wait()
+
+	adapter.baseAdapter.Close()
+}
+
+// GetName returns adapter name.
+func (adapter *CANAdapter) GetName() (name string) {
+	return adapter.baseAdapter.GetName()
+}
+
+// GetPathList returns list of all pathes for this adapter.
+func (adapter *CANAdapter) GetPathList() (pathList []string, err error) {
+	pathList, err = adapter.baseAdapter.GetPathList()
+	if err != nil {
+		return pathList, aoserrors.Wrap(err)
+	}
+
+	return pathList, nil
+}
+
+// IsPathPublic returns true if requested data accessible without authorization.
+func (adapter *CANAdapter) IsPathPublic(path string) (result bool, err error) {
+	result, err = adapter.baseAdapter.IsPathPublic(path)
+	if err != nil {
+		return result, aoserrors.Wrap(err)
+	}
+
+	return result, nil
+}
+
+// GetMetadata returns metadata by path.
+func (adapter *CANAdapter) GetMetadata(pathList []string) (
+	metadata map[string]*dataprovider.SignalMetadata, err error,
+) {
+	metadata, err = adapter.baseAdapter.GetMetadata(pathList)
+	if err != nil {
+		return metadata, aoserrors.Wrap(err)
+	}
+
+	return metadata, nil
+}
+
+// GetData returns data by path.
+func (adapter *CANAdapter) GetData(pathList []string) (data map[string]interface{}, err error) {
+	data, err = adapter.baseAdapter.GetData(pathList)
+	if err != nil {
+		return data, aoserrors.Wrap(err)
+	}
+
+	return data, nil
+}
+
+// GetTimestamps returns time when data was sampled.
+func (adapter *CANAdapter) GetTimestamps(pathList []string) (timestamps map[string]time.Time, err error) {
+	timestamps, err = adapter.baseAdapter.GetTimestamps(pathList)
+	if err != nil {
+		return timestamps, aoserrors.Wrap(err)
+	}
+
+	return timestamps, nil
+}
+
+// SetData encodes values of writable signals and transmits their frames.
+func (adapter *CANAdapter) SetData(data map[string]interface{}) (err error) {
+	adapter.Lock()
+	defer adapter.Unlock()
+
+	if err = adapter.baseAdapter.CheckSetData(data); err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	frames := make(map[*dbc.Message][]byte)
+
+	for path, value := range data {
+		mapping := adapter.signals[path]
+
+		frame, ok := frames[mapping.message]
+		if !ok {
+			frame = make([]byte, mapping.message.Length)
+			copy(frame, adapter.frames[mapping.message.ID])
+
+			frames[mapping.message] = frame
+		}
+
+		if err = mapping.encode(frame, value); err != nil {
+			return err
+		}
+	}
+
+	// Store values as they will be received back to not report change on next frame
+	transmittedData := make(map[*dbc.Message]map[string]interface{})
+	decodedValues := make(map[*dbc.Message]map[string]float64)
+
+	for path := range data {
+		mapping := adapter.signals[path]
+
+		values, ok := decodedValues[mapping.message]
+		if !ok {
+			if values, err = mapping.message.Decode(frames[mapping.message]); err != nil {
+				return aoserrors.Wrap(err)
+			}
+
+			decodedValues[mapping.message] = values
+			transmittedData[mapping.message] = make(map[string]interface{})
+		}
+
+		value, ok := values[mapping.signal.Name]
+		if !ok {
+			return aoserrors.Errorf("signal %s is not encoded in message %s", mapping.signal.Name, mapping.message.Name)
+		}
+
+		transmittedData[mapping.message][path] = mapping.fromPhysical(value)
+	}
+
+	messages := make([]*dbc.Message, 0, len(frames))
+
+	for message := range frames {
+		messages = append(messages, message)
+	}
+
+	sort.Slice(messages, func(i, j int) bool { return messages[i].ID < messages[j].ID })
+
+	writtenData := make(map[string]interface{})
+
+	for _, message := range messages {
+		if err = adapter.bus.writeFrame(
+			canFrame{id: message.ID, extended: message.Extended, data: frames[message]}); err != nil {
+			break
+		}
+
+		adapter.frames[message.ID] = frames[message]
+
+		for path, value := range transmittedData[message] {
+			writtenData[path] = value
+		}
+	}
+
+	// Frames are transmitted in ID order and values of written frames are set even if next frame write fails
+	if setErr := adapter.baseAdapter.SetData(writtenData); err == nil {
+		err = aoserrors.Wrap(setErr)
+	}
+
+	return err
+}
+
+// GetSubscribeChannel returns channel on which data changes will be sent.
+func (adapter *CANAdapter) GetSubscribeChannel() (channel <-chan map[string]interface{}) {
+	return adapter.baseAdapter.SubscribeChannel
+}
+
+// Subscribe subscribes for data changes.
+func (adapter *CANAdapter) Subscribe(pathList []string) (err error) {
+	return aoserrors.Wrap(adapter.baseAdapter.Subscribe(pathList))
+}
+
+// Unsubscribe unsubscribes from data changes.
+func (adapter *CANAdapter) Unsubscribe(pathList []string) (err error) {
+	return aoserrors.Wrap(adapter.baseAdapter.Unsubscribe(pathList))
+}
+
+// UnsubscribeAll unsubscribes from all data changes.
+func (adapter *CANAdapter) UnsubscribeAll() (err error) {
+	return aoserrors.Wrap(adapter.baseAdapter.UnsubscribeAll())
+}
+
+/*******************************************************************************
+ * Private
+ ******************************************************************************/
+
+// newAdapter creates adapter instance which uses bus opened by openBus.
+func newAdapter(
+	configJSON json.RawMessage, openBus func(interfaceName string) (bus canBus, err error),
+) (adapter dataprovider.DataAdapter, err error) {
+	log.Info("Create CAN adapter")
+
+	var cfg adapterConfig
+
+	if err = json.Unmarshal(configJSON, &cfg); err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	if cfg.Interface == "" || cfg.DBCFile == "" {
+		return nil, aoserrors.New("interface and DBC file should be set")
+	}
+
+	database, err := dbc.ParseFile(cfg.DBCFile)
+	if err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	localAdapter := &CANAdapter{
+		signals:  make(map[string]*signalMapping),
+		messages: make(map[uint32][]*signalMapping),
+		frames:   make(map[uint32][]byte),
+	}
+
+	if localAdapter.baseAdapter, err = dataprovider.NewBaseAdapter(); err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	localAdapter.baseAdapter.Name = "CANAdapter"
+
+	for _, signalCfg := range cfg.Signals {
+		if err = localAdapter.addSignal(database, signalCfg); err != nil {
+			return nil, err
+		}
+	}
+
+	if localAdapter.bus, err = openBus(cfg.Interface); err != nil {
+		return nil, err
+	}
+
+	localAdapter.wg.Add(1)
+
+	go localAdapter.processFrames()
+
+	return localAdapter, nil
+}
+
+func (adapter *CANAdapter) addSignal(database *dbc.Database, signalCfg signalConfig) (err error) {
+	if signalCfg.Path == "" {
+		return aoserrors.Errorf("path of signal %s is not set", signalCfg.Signal)
+	}
+
+	if _, ok := adapter.signals[signalCfg.Path]; ok {
+		return aoserrors.Errorf("path %s is mapped twice", signalCfg.Path)
+	}
+
+	message, err := database.GetMessage(signalCfg.Message)
+	if err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	signal, ok := message.Signals[signalCfg.Signal]
+	if !ok {
+		return aoserrors.Errorf("signal %s not found in message %s", signalCfg.Signal, signalCfg.Message)
+	}
+
+	mapping := &signalMapping{
+		path: signalCfg.Path, message: message, signal: signal, scale: 1, offset: signalCfg.Offset,
+		dataType: signalCfg.DataType,
+	}
+
+	if signalCfg.Scale != nil {
+		if *signalCfg.Scale == 0 {
+			return aoserrors.Errorf("scale of path %s should not be zero", signalCfg.Path)
+		}
+
+		mapping.scale = *signalCfg.Scale
+	}
+
+	if mapping.dataType == "" {
+		mapping.dataType = defaultDataType
+	}
+
+	baseData := &dataprovider.BaseData{
+		Public: signalCfg.Public, ReadOnly: !signalCfg.Writable, DataType: mapping.dataType, Unit: signal.Unit,
+		Type: dataprovider.NodeTypeSensor,
+	}
+
+	if signalCfg.Unit != nil {
+		baseData.Unit = *signalCfg.Unit
+	}
+
+	if signalCfg.Writable {
+		baseData.Type = dataprovider.NodeTypeActuator
+	}
+
+	if signal.Min < signal.Max && mapping.dataType != "boolean" {
+		baseData.Min = math.Min(mapping.toVIS(signal.Min), mapping.toVIS(signal.Max))
+		baseData.Max = math.Max(mapping.toVIS(signal.Min), mapping.toVIS(signal.Max))
+	}
+
+	adapter.signals[mapping.path] = mapping
+	adapter.messages[message.ID] = append(adapter.messages[message.ID], mapping)
+	adapter.baseAdapter.Data[mapping.path] = baseData
+
+	return nil
+}
+
+func (adapter *CANAdapter) processFrames() {
+	defer adapter.wg.Done()
+
+	for {
+		frame, err := adapter.bus.readFrame()
+		if err != nil {
+			if !errors.Is(err, errBusClosed) {
+				log.Errorf("Can't read CAN frame: %s", err)
+			}
+
+			return
+		}
+
+		if err = adapter.handleFrame(frame); err != nil {
+			log.WithField("id", frame.id).Errorf("Can't handle CAN frame: %s", err)
+		}
+	}
+}
+
+func (adapter *CANAdapter) handleFrame(frame canFrame) (err error) {
+	mappings := adapter.messages[frame.id]
+	if len(mappings) == 0 || mappings[0].message.Extended != frame.extended {
+		return nil
+	}
+
+	values, err := mappings[0].message.Decode(frame.data)
+	if err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	adapter.Lock()
+	adapter.frames[frame.id] = frame.data
+	adapter.Unlock()
+
+	data := make(map[string]interface{})
+
+	for _, mapping := range mappings {
+		if value, ok := values[mapping.signal.Name]; ok {
+			data[mapping.path] = mapping.fromPhysical(value)
+		}
+	}
+
+	if len(data) == 0 {
+		return nil
+	}
+
+	return aoserrors.Wrap(adapter.baseAdapter.UpdateData(data))
+}
+
+func (mapping *signalMapping) toVIS(value float64) (result float64) {
+	return value*mapping.scale + mapping.offset
+}
+
+// fromPhysical converts DBC physical value to VIS value of configured datatype.
+func (mapping *signalMapping) fromPhysical(value float64) (result interface{}) {
+	visValue := mapping.toVIS(value)
+
+	switch mapping.dataType {
+	case "boolean":
+		return visValue != 0
+
+	case "float", "double":
+		return visValue
+
+	default:
+		return int64(math.Round(visValue))
+	}
+}
+
+// encode puts VIS value to frame data.
+func (mapping *signalMapping) encode(frame []byte, value interface{}) (err error) {
+	var visValue float64
+
+	if boolValue, ok := value.(bool); ok {
+		if boolValue {
+			visValue = 1
+		}
+	} else if visValue, ok = dataprovider.GetNumericValue(value); !ok {
+		return aoserrors.Errorf("invalid value for path %s: %v is not numeric", mapping.path, value)
+	}
+
+	return aoserrors.Wrap(mapping.signal.Encode(frame, (visValue-mapping.offset)/mapping.scale))
+}
@@ -0,0 +1,316 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2021 Renesas Electronics Corporation.
+// Copyright (C) 2021 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package canadapter_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/aosedge/aos_vis/dataprovider"
+	"github.com/aosedge/aos_vis/plugins/canadapter"
+)
+
+/*******************************************************************************
+ * Consts
+ ******************************************************************************/
+
+const testInterface = "vcan0"
+
+const testDBC = `BO_ 256 Engine: 8 ECU
+ SG_ EngineSpeed : 0|16@1+ (0.25,0) [0|16383.75] "rpm" BCM
+ SG_ CoolantTemp : 16|8@1- (1,0) [-128|127] "degC" BCM
+
+BO_ 512 Body: 1 BCM
+ SG_ TrunkLocked : 0|1@1+ (1,0) [0|1] "" ECU
+`
+
+/*******************************************************************************
+ * Vars
+ ******************************************************************************/
+
+var dbcFile string
+
+/*******************************************************************************
+ * Init
+ ******************************************************************************/
+
+func init() {
+	log.SetFormatter(&log.TextFormatter{
+		DisableTimestamp: false,
+		TimestampFormat:  "2006-01-02 15:04:05.000",
+		FullTimestamp:    true,
+	})
+	log.SetLevel(log.DebugLevel)
+	log.SetOutput(os.Stdout)
+}
+
+/*******************************************************************************
+ * Main
+ ******************************************************************************/
+
+func TestMain(m *testing.M) {
+	tmpDir, err := os.MkdirTemp("", "canadapter_")
+	if err != nil {
+		log.Fatalf("Can't create tmp dir: %s", err)
+	}
+
+	dbcFile = filepath.Join(tmpDir, "test.dbc")
+
+	if err = os.WriteFile(dbcFile, []byte(testDBC), 0o600); err != nil {
+		log.Fatalf("Can't write DBC file: %s", err)
+	}
+
+	ret := m.Run()
+
+	os.RemoveAll(tmpDir)
+
+	os.Exit(ret)
+}
+
+/*******************************************************************************
+ * Tests
+ ******************************************************************************/
+
+func TestConfigErrors(t *testing.T) {
+	testItems := []string{
+		`{"DBCFile": "` + dbcFile + `"}`,
+		`{"Interface": "` + testInterface + `", "DBCFile": "/not/existing.dbc"}`,
+		`{"Interface": "` + testInterface + `", "DBCFile": "` + dbcFile + `", "Signals": [
+			{"Path": "Signal.Vehicle.Speed", "Message": "Brakes", "Signal": "Speed"}
+		]}`,
+		`{"Interface": "` + testInterface + `", "DBCFile": "` + dbcFile + `", "Signals": [
+			{"Path": "Signal.Vehicle.Speed", "Message": "Engine", "Signal": "Speed"}
+		]}`,
+		`{"Interface": "` + testInterface + `", "DBCFile": "` + dbcFile + `", "Signals": [
+			{"Path": "Signal.Engine.Speed", "Message": "Engine", "Signal": "EngineSpeed", "Scale": 0}
+		]}`,
+		`{"Interface": "not_existing_can", "DBCFile": "` + dbcFile + `", "Signals": [
+			{"Path": "Signal.Engine.Speed", "Message": "Engine", "Signal": "EngineSpeed"}
+		]}`,
+	}
+
+	for _, item := range testItems {
+		if adapter, err := canadapter.New(json.RawMessage(item)); err == nil {
+			adapter.Close()
+			t.Errorf("Error expected for config: %s", item)
+		}
+	}
+}
+
+func TestFakeBus(t *testing.T) {
+	adapter, bus, err := canadapter.NewWithFakeBus(json.RawMessage(`{"Interface": "fake0", "DBCFile": "` + dbcFile +
+		`", "Signals": [
+		{"Path": "Signal.Engine.Speed", "Message": "Engine", "Signal": "EngineSpeed", "DataType": "uint16"},
+		{"Path": "Signal.Engine.Temperature", "Message": "Engine", "Signal": "CoolantTemp", "Scale": 1.8, "Offset": 32,
+		 "Writable": true},
+		{"Path": "Signal.Body.Trunk.IsLocked", "Message": "Body", "Signal": "TrunkLocked", "DataType": "boolean",
+		 "Writable": true}
+	]}`))
+	if err != nil {
+		t.Fatalf("Can't create adapter: %s", err)
+	}
+	defer adapter.Close()
+
+	metadata, err := adapter.GetMetadata([]string{"Signal.Engine.Speed", "Signal.Engine.Temperature"})
+	if err != nil {
+		t.Fatalf("Can't get metadata: %s", err)
+	}
+
+	if metadata["Signal.Engine.Speed"].Writable || !metadata["Signal.Engine.Temperature"].Writable {
+		t.Errorf("Wrong metadata: %v, %v", metadata["Signal.Engine.Speed"], metadata["Signal.Engine.Temperature"])
+	}
+
+	if err = adapter.Subscribe([]string{"Signal.Engine.Speed", "Signal.Engine.Temperature"}); err != nil {
+		t.Fatalf("Can't subscribe: %s", err)
+	}
+
+	// Frame with the same ID but different format is ignored
+	bus.Receive(256, true, []byte{0xff, 0xff, 0xff, 0, 0, 0, 0, 0})
+
+	// EngineSpeed raw 4000 * 0.25 = 1000 rpm, CoolantTemp raw -10 * 1.8 + 32 = 14 degF
+	bus.Receive(256, false, []byte{0xa0, 0x0f, 0xf6, 0, 0, 0, 0, 0})
+
+	select {
+	case changes := <-adapter.GetSubscribeChannel():
+		data, _ := dataprovider.UnpackChanges(changes)
+
+		if data["Signal.Engine.Speed"] != int64(1000) || data["Signal.Engine.Temperature"] != 14.0 {
+			t.Errorf("Wrong received data: %v", data)
+		}
+
+	case <-time.After(time.Second):
+		t.Fatal("Wait data timeout")
+	}
+
+	// Not changed signals of frame are encoded with received values
+	for _, item := range []struct {
+		data  map[string]interface{}
+		id    uint32
+		frame []byte
+	}{
+		{map[string]interface{}{"Signal.Engine.Temperature": 212.0}, 256, []byte{0xa0, 0x0f, 0x64, 0, 0, 0, 0, 0}},
+		{map[string]interface{}{"Signal.Body.Trunk.IsLocked": true}, 512, []byte{0x01}},
+	} {
+		if err = adapter.SetData(item.data); err != nil {
+			t.Fatalf("Can't set data: %s", err)
+		}
+
+		id, frame, ok := bus.Written(time.Second)
+		if !ok {
+			t.Fatal("Wait frame timeout")
+		}
+
+		if id != item.id || !bytes.Equal(frame, item.frame) {
+			t.Errorf("Wrong written frame: %d %x", id, frame)
+		}
+	}
+
+	// Stored value is quantised to DBC resolution as transmitted: raw 37 * 1.8 + 32 = 98.6 degF
+	if err = adapter.SetData(map[string]interface{}{"Signal.Engine.Temperature": 99.0}); err != nil {
+		t.Fatalf("Can't set data: %s", err)
+	}
+
+	if _, frame, ok := bus.Written(time.Second); !ok || !bytes.Equal(frame, []byte{0xa0, 0x0f, 0x25, 0, 0, 0, 0, 0}) {
+		t.Errorf("Wrong written frame: %x", frame)
+	}
+
+	data, err := adapter.GetData([]string{"Signal.Engine.Temperature", "Signal.Body.Trunk.IsLocked"})
+	if err != nil {
+		t.Fatalf("Can't get data: %s", err)
+	}
+
+	if temperature, ok := data["Signal.Engine.Temperature"].(float64); !ok || math.Abs(temperature-98.6) > 1e-9 ||
+		data["Signal.Body.Trunk.IsLocked"] != true {
+		t.Errorf("Wrong stored data: %v", data)
+	}
+
+	// Invalid values are not transmitted
+	for _, data := range []map[string]interface{}{
+		{"Signal.Engine.Speed": 1000},
+		{"Signal.Engine.Temperature": "hot"},
+		{"Signal.Engine.Flux": 1},
+	} {
+		if err = adapter.SetData(data); err == nil {
+			t.Errorf("Error expected for data: %v", data)
+		}
+	}
+
+	if _, _, ok := bus.Written(100 * time.Millisecond); ok {
+		t.Error("Unexpected frame is written")
+	}
+
+	// Values of frames written before failed one are set
+	bus.FailWrite(512)
+
+	if err = adapter.SetData(map[string]interface{}{
+		"Signal.Engine.Temperature": 32.0, "Signal.Body.Trunk.IsLocked": false,
+	}); err == nil {
+		t.Error("Error expected for failed frame write")
+	}
+
+	if id, _, ok := bus.Written(time.Second); !ok || id != 256 {
+		t.Errorf("Wrong written frame: %d", id)
+	}
+
+	if data, err = adapter.GetData([]string{"Signal.Engine.Temperature", "Signal.Body.Trunk.IsLocked"}); err != nil {
+		t.Fatalf("Can't get data: %s", err)
+	}
+
+	if data["Signal.Engine.Temperature"] != 32.0 || data["Signal.Body.Trunk.IsLocked"] != true {
+		t.Errorf("Wrong stored data: %v", data)
+	}
+}
+
+func TestVirtualCAN(t *testing.T) {
+	if _, err := net.InterfaceByName(testInterface); err != nil {
+		t.Skipf("Virtual CAN interface %s is not available", testInterface)
+	}
+
+	configJSON := json.RawMessage(`{"Interface": "` + testInterface + `", "DBCFile": "` + dbcFile + `", "Signals": [
+		{"Path": "Signal.Engine.Speed", "Message": "Engine", "Signal": "EngineSpeed", "DataType": "uint16"},
+		{"Path": "Signal.Engine.Temperature", "Message": "Engine", "Signal": "CoolantTemp", "Scale": 1.8, "Offset": 32,
+		 "Unit": "degF", "Writable": true},
+		{"Path": "Signal.Body.Trunk.IsLocked", "Message": "Body", "Signal": "TrunkLocked", "DataType": "boolean",
+		 "Writable": true}
+	]}`)
+
+	// Frames sent by one adapter are received by another one on the same interface
+	sender, err := canadapter.New(configJSON)
+	if err != nil {
+		t.Fatalf("Can't create adapter: %s", err)
+	}
+	defer sender.Close()
+
+	receiver, err := canadapter.New(configJSON)
+	if err != nil {
+		t.Fatalf("Can't create adapter: %s", err)
+	}
+	defer receiver.Close()
+
+	metadata, err := receiver.GetMetadata([]string{"Signal.Engine.Speed", "Signal.Engine.Temperature"})
+	if err != nil {
+		t.Fatalf("Can't get metadata: %s", err)
+	}
+
+	if metadata["Signal.Engine.Speed"].Unit != "rpm" || metadata["Signal.Engine.Speed"].Writable ||
+		metadata["Signal.Engine.Temperature"].Unit != "degF" || !metadata["Signal.Engine.Temperature"].Writable {
+		t.Errorf("Wrong metadata: %v, %v", metadata["Signal.Engine.Speed"], metadata["Signal.Engine.Temperature"])
+	}
+
+	if err = sender.SetData(map[string]interface{}{"Signal.Engine.Speed": 1000}); err == nil {
+		t.Error("Error expected for read only signal")
+	}
+
+	if err = receiver.Subscribe([]string{"Signal.Engine.Temperature", "Signal.Body.Trunk.IsLocked"}); err != nil {
+		t.Fatalf("Can't subscribe: %s", err)
+	}
+
+	if err = sender.SetData(map[string]interface{}{
+		"Signal.Engine.Temperature": 212.0, "Signal.Body.Trunk.IsLocked": true,
+	}); err != nil {
+		t.Fatalf("Can't set data: %s", err)
+	}
+
+	received := make(map[string]interface{})
+
+	for len(received) < 2 {
+		select {
+		case changes := <-receiver.GetSubscribeChannel():
+			data, _ := dataprovider.UnpackChanges(changes)
+
+			for path, value := range data {
+				received[path] = value
+			}
+
+		case <-time.After(time.Second):
+			t.Fatalf("Wait data timeout, received: %v", received)
+		}
+	}
+
+	if received["Signal.Engine.Temperature"] != 212.0 || received["Signal.Body.Trunk.IsLocked"] != true {
+		t.Errorf("Wrong received data: %v", received)
+	}
+}
@@ -0,0 +1,383 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2021 Renesas Electronics Corporation.
+// Copyright (C) 2021 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package dbc parses CAN database (DBC) files and encodes/decodes CAN frame signals.
+package dbc
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+	"math"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/aosedge/aos_common/aoserrors"
+)
+
+/*******************************************************************************
+ * Consts
+ ******************************************************************************/
+
+// MaxFrameLength max data length of classic CAN frame.
+const MaxFrameLength = 8
+
+const (
+	extendedIDFlag = 0x80000000
+	extendedIDMask = 0x1FFFFFFF
+	frameBits      = MaxFrameLength * 8
+)
+
+/*******************************************************************************
+ * Types
+ ******************************************************************************/
+
+// Database CAN database.
+type Database struct {
+	Messages map[uint32]*Message
+}
+
+// Message CAN message description.
+type Message struct {
+	ID       uint32
+	Extended bool
+	Name     string
+	Length   int
+	Signals  map[string]*Signal
+}
+
+// Signal CAN signal description.
+type Signal struct {
+	Name         string
+	StartBit     int
+	Length       int
+	LittleEndian bool
+	Signed       bool
+	Factor       float64
+	Offset       float64
+	Min          float64
+	Max          float64
+	Unit         string
+	// Multiplexor is true for multiplexor switch signal
+	Multiplexor bool
+	// MuxValue is value of multiplexor switch for multiplexed signal, nil if signal is not multiplexed
+	MuxValue *uint64
+}
+
+/*******************************************************************************
+ * Vars
+ ******************************************************************************/
+
+//nolint:gochecknoglobals // constant regexp
+var (
+	messageRegexp = regexp.MustCompile(`^BO_\s+(\d+)\s+(\w+)\s*:\s*(\d+)\s+\w+`)
+	signalRegexp  = regexp.MustCompile(`^SG_\s+(\w+)\s*(M|m\d+)?\s*:\s*(\d+)\|(\d+)@([01])([+-])\s*` +
+		`\(\s*([^,\s]+)\s*,\s*([^)\s]+)\s*\)\s*\[\s*([^|\s]+)\s*\|\s*([^\]\s]+)\s*\]\s*"([^"]*)"`)
+)
+
+/*******************************************************************************
+ * Public
+ ******************************************************************************/
+
+// ParseFile parses DBC file.
+func ParseFile(fileName string) (database *Database, err error) {
+	file, err := os.Open(fileName)
+	if err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+	defer file.Close()
+
+	return Parse(file)
+}
+
+// Parse parses DBC content. Only message and signal definitions are used, other sections are ignored.
+func Parse(reader io.Reader) (database *Database, err error) {
+	database = &Database{Messages: make(map[uint32]*Message)}
+
+	var (
+		message    *Message
+		lineNumber int
+	)
+
+	scanner := bufio.NewScanner(reader)
+
+	for scanner.Scan() {
+		lineNumber++
+
+		line := strings.TrimSpace(scanner.Text())
+
+		switch {
+		case strings.HasPrefix(line, "BO_ "):
+			if message, err = parseMessage(line); err != nil {
+				return nil, aoserrors.Errorf("line %d: %v", lineNumber, err)
+			}
+
+			database.Messages[message.ID] = message
+
+		case strings.HasPrefix(line, "SG_ "):
+			if message == nil {
+				return nil, aoserrors.Errorf("line %d: signal without message", lineNumber)
+			}
+
+			signal, err := parseSignal(line, message)
+			if err != nil {
+				return nil, aoserrors.Errorf("line %d: %v", lineNumber, err)
+			}
+
+			message.Signals[signal.Name] = signal
+
+		case line == "":
+			message = nil
+		}
+	}
+
+	if err = scanner.Err(); err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	return database, nil
+}
+
+// GetMessage returns message by name.
+func (database *Database) GetMessage(name string) (message *Message, err error) {
+	for _, message := range database.Messages {
+		if message.Name == name {
+			return message, nil
+		}
+	}
+
+	return nil, aoserrors.Errorf("message %s not found", name)
+}
+
+// Decode returns physical values of signals contained in frame data. Multiplexed signals are decoded only if
+// multiplexor matches.
+func (message *Message) Decode(data []byte) (values map[string]float64, err error) {
+	if len(data) < message.Length {
+		return nil, aoserrors.Errorf("wrong %s frame length: %d", message.Name, len(data))
+	}
+
+	var muxValue *uint64
+
+	for _, signal := range message.Signals {
+		if signal.Multiplexor {
+			rawValue := signal.getRaw(data)
+			muxValue = &rawValue
+		}
+	}
+
+	values = make(map[string]float64)
+
+	for name, signal := range message.Signals {
+		if signal.MuxValue != nil && (muxValue == nil || *muxValue != *signal.MuxValue) {
+			continue
+		}
+
+		values[name] = signal.Decode(data)
+	}
+
+	return values, nil
+}
+
+// Decode returns physical value of signal.
+func (signal *Signal) Decode(data []byte) (value float64) {
+	rawValue := signal.getRaw(data)
+
+	if signal.Signed {
+		return float64(signExtend(rawValue, signal.Length))*signal.Factor + signal.Offset
+	}
+
+	return float64(rawValue)*signal.Factor + signal.Offset
+}
+
+// Encode puts physical value of signal into frame data.
+func (signal *Signal) Encode(data []byte, value float64) (err error) {
+	if signal.Min < signal.Max && (value < signal.Min || value > signal.Max) {
+		return aoserrors.Errorf("value %v of signal %s is out of range [%v|%v]", value, signal.Name,
+			signal.Min, signal.Max)
+	}
+
+	if signal.Factor == 0 {
+		return aoserrors.Errorf("signal %s has zero factor", signal.Name)
+	}
+
+	rawFloat := math.Round((value - signal.Offset) / signal.Factor)
+
+	var rawValue uint64
+
+	if signal.Signed {
+		limit := math.Exp2(float64(signal.Length - 1))
+
+		if rawFloat < -limit || rawFloat >= limit {
+			return aoserrors.Errorf("value %v of signal %s doesn't fit %d bits", value, signal.Name, signal.Length)
+		}
+
+		rawValue = uint64(int64(rawFloat)) & mask(signal.Length)
+	} else {
+		if rawFloat < 0 || rawFloat >= math.Exp2(float64(signal.Length)) {
+			return aoserrors.Errorf("value %v of signal %s doesn't fit %d bits", value, signal.Name, signal.Length)
+		}
+
+		rawValue = uint64(rawFloat)
+	}
+
+	signal.setRaw(data, rawValue)
+
+	return nil
+}
+
+/*******************************************************************************
+ * Private
+ ******************************************************************************/
+
+func parseMessage(line string) (message *Message, err error) {
+	fields := messageRegexp.FindStringSubmatch(line)
+	if fields == nil {
+		return nil, aoserrors.New("invalid message definition")
+	}
+
+	id, err := strconv.ParseUint(fields[1], 10, 32)
+	if err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	length, err := strconv.Atoi(fields[3])
+	if err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	if length > MaxFrameLength {
+		return nil, aoserrors.Errorf("message %s length %d is not supported", fields[2], length)
+	}
+
+	message = &Message{
+		ID: uint32(id) & extendedIDMask, Extended: id&extendedIDFlag != 0, Name: fields[2], Length: length,
+		Signals: make(map[string]*Signal),
+	}
+
+	return message, nil
+}
+
+func parseSignal(line string, message *Message) (signal *Signal, err error) {
+	fields := signalRegexp.FindStringSubmatch(line)
+	if fields == nil {
+		return nil, aoserrors.New("invalid signal definition")
+	}
+
+	signal = &Signal{
+		Name: fields[1], LittleEndian: fields[5] == "1", Signed: fields[6] == "-", Unit: fields[11],
+	}
+
+	switch {
+	case fields[2] == "M":
+		signal.Multiplexor = true
+
+	case fields[2] != "":
+		muxValue, err := strconv.ParseUint(fields[2][1:], 10, 64)
+		if err != nil {
+			return nil, aoserrors.Wrap(err)
+		}
+
+		signal.MuxValue = &muxValue
+	}
+
+	if signal.StartBit, err = strconv.Atoi(fields[3]); err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	if signal.Length, err = strconv.Atoi(fields[4]); err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	for i, value := range []*float64{&signal.Factor, &signal.Offset, &signal.Min, &signal.Max} {
+		if *value, err = strconv.ParseFloat(fields[7+i], 64); err != nil {
+			return nil, aoserrors.Wrap(err)
+		}
+	}
+
+	if signal.Length <= 0 || signal.Length > frameBits {
+		return nil, aoserrors.Errorf("signal %s has invalid length %d", signal.Name, signal.Length)
+	}
+
+	if first, last := signal.bitRange(); first < 0 || last > message.Length*8 {
+		return nil, aoserrors.Errorf("signal %s doesn't fit message %s", signal.Name, message.Name)
+	}
+
+	return signal, nil
+}
+
+// bitRange returns signal position in frame: little endian bits are numbered from LSB of first byte, big endian bits
+// from MSB of first byte.
+func (signal *Signal) bitRange() (first, last int) {
+	if signal.LittleEndian {
+		return signal.StartBit, signal.StartBit + signal.Length
+	}
+
+	// Big endian start bit is MSB of signal in sawtooth numbering
+	first = signal.StartBit/8*8 + 7 - signal.StartBit%8
+
+	return first, first + signal.Length
+}
+
+func (signal *Signal) getRaw(data []byte) (rawValue uint64) {
+	first, _ := signal.bitRange()
+	frame := make([]byte, MaxFrameLength)
+
+	copy(frame, data)
+
+	if signal.LittleEndian {
+		return binary.LittleEndian.Uint64(frame) >> first & mask(signal.Length)
+	}
+
+	return binary.BigEndian.Uint64(frame) >> (frameBits - first - signal.Length) & mask(signal.Length)
+}
+
+func (signal *Signal) setRaw(data []byte, rawValue uint64) {
+	first, _ := signal.bitRange()
+	frame := make([]byte, MaxFrameLength)
+
+	copy(frame, data)
+
+	if signal.LittleEndian {
+		shift := first
+		value := binary.LittleEndian.Uint64(frame)&^(mask(signal.Length)<<shift) | rawValue<<shift
+
+		binary.LittleEndian.PutUint64(frame, value)
+	} else {
+		shift := frameBits - first - signal.Length
+		value := binary.BigEndian.Uint64(frame)&^(mask(signal.Length)<<shift) | rawValue<<shift
+
+		binary.BigEndian.PutUint64(frame, value)
+	}
+
+	copy(data, frame)
+}
+
+func mask(length int) (result uint64) {
+	if length >= frameBits {
+		return math.MaxUint64
+	}
+
+	return 1<<length - 1
+}
+
+func signExtend(value uint64, length int) (result int64) {
+	shift := frameBits - length
+
+	return int64(value<<shift) >> shift //nolint:gosec // intended conversion
+}
@@ -0,0 +1,159 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2021 Renesas Electronics Corporation.
+// Copyright (C) 2021 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbc_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/aosedge/aos_vis/plugins/canadapter/dbc"
+)
+
+/*******************************************************************************
+ * Consts
+ ******************************************************************************/
+
+const testDBC = `VERSION ""
+
+NS_ :
+	CM_
+
+BU_: ECU BCM
+
+BO_ 256 Engine: 8 ECU
+ SG_ EngineSpeed : 0|16@1+ (0.25,0) [0|16383.75] "rpm" BCM
+ SG_ CoolantTemp : 16|8@1- (1,0) [-128|127] "degC" BCM
+ SG_ Gear : 31|4@0+ (1,0) [0|15] "" BCM
+ SG_ Torque : 39|12@0+ (0.5,-100) [-100|1947.5] "Nm" BCM
+
+BO_ 2566844926 Doors: 2 BCM
+ SG_ Mux M : 0|2@1+ (1,0) [0|3] "" ECU
+ SG_ DriverDoor m0 : 8|1@1+ (1,0) [0|1] "" ECU
+ SG_ PassengerDoor m1 : 8|1@1+ (1,0) [0|1] "" ECU
+
+CM_ SG_ 256 EngineSpeed "Engine speed";
+`
+
+/*******************************************************************************
+ * Tests
+ ******************************************************************************/
+
+func TestParse(t *testing.T) {
+	database, err := dbc.Parse(strings.NewReader(testDBC))
+	if err != nil {
+		t.Fatalf("Can't parse DBC: %s", err)
+	}
+
+	if len(database.Messages) != 2 {
+		t.Fatalf("Wrong messages count: %d", len(database.Messages))
+	}
+
+	doors, err := database.GetMessage("Doors")
+	if err != nil {
+		t.Fatalf("Can't get message: %s", err)
+	}
+
+	if doors.ID != 0x18FEF1FE || !doors.Extended || doors.Length != 2 || len(doors.Signals) != 3 {
+		t.Errorf("Wrong message: %+v", doors)
+	}
+
+	engineSpeed := database.Messages[256].Signals["EngineSpeed"]
+
+	if engineSpeed == nil || engineSpeed.Factor != 0.25 || engineSpeed.Max != 16383.75 || engineSpeed.Unit != "rpm" {
+		t.Errorf("Wrong signal: %+v", engineSpeed)
+	}
+
+	if _, err = database.GetMessage("Brakes"); err == nil {
+		t.Error("Error expected for unknown message")
+	}
+
+	invalidItems := []string{
+		"BO_ 1 Long: 9 ECU",
+		"BO_ 1 Short: 1 ECU\n SG_ Speed : 4|8@1+ (1,0) [0|255] \"\" ECU",
+		" SG_ Speed : 0|8@1+ (1,0) [0|255] \"\" ECU",
+		"BO_ 1 Short: 1 ECU\n SG_ Speed : 0|8@1+ (a,0) [0|255] \"\" ECU",
+	}
+
+	for _, item := range invalidItems {
+		if _, err = dbc.Parse(strings.NewReader(item)); err == nil {
+			t.Errorf("Error expected for: %s", item)
+		}
+	}
+}
+
+func TestDecodeEncode(t *testing.T) {
+	database, err := dbc.Parse(strings.NewReader(testDBC))
+	if err != nil {
+		t.Fatalf("Can't parse DBC: %s", err)
+	}
+
+	engine := database.Messages[256]
+	data := []byte{0xE0, 0x2E, 0xFB, 0x50, 0xAB, 0xC0, 0x00, 0x00}
+
+	values, err := engine.Decode(data)
+	if err != nil {
+		t.Fatalf("Can't decode frame: %s", err)
+	}
+
+	expectedValues := map[string]float64{"EngineSpeed": 3000, "CoolantTemp": -5, "Gear": 5, "Torque": 1274}
+
+	for name, value := range expectedValues {
+		if values[name] != value {
+			t.Errorf("Wrong %s value: %v", name, values[name])
+		}
+	}
+
+	encoded := make([]byte, engine.Length)
+
+	for name, value := range expectedValues {
+		if err = engine.Signals[name].Encode(encoded, value); err != nil {
+			t.Fatalf("Can't encode %s: %s", name, err)
+		}
+	}
+
+	if !bytes.Equal(encoded, data) {
+		t.Errorf("Wrong encoded frame: % X", encoded)
+	}
+
+	if err = engine.Signals["EngineSpeed"].Encode(encoded, 20000); err == nil {
+		t.Error("Error expected for out of range value")
+	}
+
+	if _, err = engine.Decode(data[:4]); err == nil {
+		t.Error("Error expected for short frame")
+	}
+}
+
+func TestMultiplexedSignals(t *testing.T) {
+	database, err := dbc.Parse(strings.NewReader(testDBC))
+	if err != nil {
+		t.Fatalf("Can't parse DBC: %s", err)
+	}
+
+	doors := database.Messages[0x18FEF1FE]
+
+	values, err := doors.Decode([]byte{0x01, 0x01})
+	if err != nil {
+		t.Fatalf("Can't decode frame: %s", err)
+	}
+
+	if _, ok := values["DriverDoor"]; ok || values["PassengerDoor"] != 1 || values["Mux"] != 1 {
+		t.Errorf("Wrong multiplexed values: %v", values)
+	}
+}
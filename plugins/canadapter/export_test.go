@@ -0,0 +1,116 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2021 Renesas Electronics Corporation.
+// Copyright (C) 2021 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package canadapter
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/aosedge/aos_common/aoserrors"
+
+	"github.com/aosedge/aos_vis/dataprovider"
+)
+
+/*******************************************************************************
+ * Types
+ ******************************************************************************/
+
+// FakeBus in-memory CAN bus: received frames are injected by Receive, transmitted frames are returned by Written.
+type FakeBus struct {
+	sync.Mutex
+	received  chan canFrame
+	written   chan canFrame
+	closed    chan struct{}
+	closeOnce sync.Once
+	failID    *uint32
+}
+
+/*******************************************************************************
+ * Public
+ ******************************************************************************/
+
+// NewWithFakeBus creates adapter instance which uses fake CAN bus.
+func NewWithFakeBus(configJSON json.RawMessage) (adapter dataprovider.DataAdapter, bus *FakeBus, err error) {
+	bus = &FakeBus{
+		received: make(chan canFrame, 10), written: make(chan canFrame, 10), closed: make(chan struct{}),
+	}
+
+	if adapter, err = newAdapter(configJSON, func(string) (canBus, error) { return bus, nil }); err != nil {
+		return nil, nil, err
+	}
+
+	return adapter, bus, nil
+}
+
+// Receive injects frame received by adapter.
+func (bus *FakeBus) Receive(id uint32, extended bool, data []byte) {
+	bus.received <- canFrame{id: id, extended: extended, data: data}
+}
+
+// FailWrite makes transmission of frames with the ID fail.
+func (bus *FakeBus) FailWrite(id uint32) {
+	bus.Lock()
+	defer bus.Unlock()
+
+	bus.failID = &id
+}
+
+// Written returns frame transmitted by adapter.
+func (bus *FakeBus) Written(timeout time.Duration) (id uint32, data []byte, ok bool) {
+	select {
+	case frame := <-bus.written:
+		return frame.id, frame.data, true
+
+	case <-time.After(timeout):
+		return 0, nil, false
+	}
+}
+
+/*******************************************************************************
+ * Private
+ ******************************************************************************/
+
+func (bus *FakeBus) readFrame() (frame canFrame, err error) {
+	select {
+	case frame = <-bus.received:
+		return frame, nil
+
+	case <-bus.closed:
+		return canFrame{}, errBusClosed
+	}
+}
+
+func (bus *FakeBus) writeFrame(frame canFrame) (err error) {
+	bus.Lock()
+	defer bus.Unlock()
+
+	if bus.failID != nil && *bus.failID == frame.id {
+		return aoserrors.Errorf("write frame %d failed", frame.id)
+	}
+
+	bus.written <- canFrame{id: frame.id, extended: frame.extended, data: append([]byte(nil), frame.data...)}
+
+	return nil
+}
+
+func (bus *FakeBus) close() (err error) {
+	bus.closeOnce.Do(func() { close(bus.closed) })
+
+	return nil
+}
@@ -0,0 +1,147 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2021 Renesas Electronics Corporation.
+// Copyright (C) 2021 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package canadapter
+
+import (
+	"encoding/binary"
+	"errors"
+	"net"
+	"os"
+
+	"github.com/aosedge/aos_common/aoserrors"
+	"golang.org/x/sys/unix"
+
+	"github.com/aosedge/aos_vis/plugins/canadapter/dbc"
+)
+
+/*******************************************************************************
+ * Consts
+ ******************************************************************************/
+
+// Linux can_frame structure layout.
+const (
+	canFrameSize  = 16
+	canDLCOffset  = 4
+	canDataOffset = 8
+	canEFFFlag    = 0x80000000
+	canRTRFlag    = 0x40000000
+	canErrFlag    = 0x20000000
+	canEFFMask    = 0x1FFFFFFF
+	canSFFMask    = 0x000007FF
+)
+
+/*******************************************************************************
+ * Types
+ ******************************************************************************/
+
+type socketCAN struct {
+	file *os.File
+}
+
+/*******************************************************************************
+ * Private
+ ******************************************************************************/
+
+// openSocketCAN opens raw CAN socket bound to network interface.
+func openSocketCAN(interfaceName string) (bus canBus, err error) {
+	netInterface, err := net.InterfaceByName(interfaceName)
+	if err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	fd, err := unix.Socket(unix.AF_CAN, unix.SOCK_RAW, unix.CAN_RAW)
+	if err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	if err = unix.Bind(fd, &unix.SockaddrCAN{Ifindex: netInterface.Index}); err != nil {
+		unix.Close(fd)
+
+		return nil, aoserrors.Wrap(err)
+	}
+
+	// Non blocking socket is handled by runtime poller, so close interrupts pending read
+	if err = unix.SetNonblock(fd, true); err != nil {
+		unix.Close(fd)
+
+		return nil, aoserrors.Wrap(err)
+	}
+
+	return &socketCAN{file: os.NewFile(uintptr(fd), interfaceName)}, nil
+}
+
+func (bus *socketCAN) readFrame() (frame canFrame, err error) {
+	buffer := make([]byte, canFrameSize)
+
+	for {
+		if _, err = bus.file.Read(buffer); err != nil {
+			if errors.Is(err, os.ErrClosed) {
+				return frame, errBusClosed
+			}
+
+			return frame, aoserrors.Wrap(err)
+		}
+
+		id := binary.NativeEndian.Uint32(buffer)
+
+		// Skip remote and error frames
+		if id&(canRTRFlag|canErrFlag) != 0 {
+			continue
+		}
+
+		frame = canFrame{id: id & canSFFMask}
+
+		if id&canEFFFlag != 0 {
+			frame.id, frame.extended = id&canEFFMask, true
+		}
+
+		length := min(int(buffer[canDLCOffset]), dbc.MaxFrameLength)
+
+		frame.data = make([]byte, length)
+		copy(frame.data, buffer[canDataOffset:canDataOffset+length])
+
+		return frame, nil
+	}
+}
+
+func (bus *socketCAN) writeFrame(frame canFrame) (err error) {
+	if len(frame.data) > dbc.MaxFrameLength {
+		return aoserrors.Errorf("wrong frame length: %d", len(frame.data))
+	}
+
+	buffer := make([]byte, canFrameSize)
+
+	id := frame.id
+	if frame.extended {
+		id |= canEFFFlag
+	}
+
+	binary.NativeEndian.PutUint32(buffer, id)
+	buffer[canDLCOffset] = byte(len(frame.data))
+	copy(buffer[canDataOffset:], frame.data)
+
+	if _, err = bus.file.Write(buffer); err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	return nil
+}
+
+func (bus *socketCAN) close() (err error) {
+	return aoserrors.Wrap(bus.file.Close())
+}
@@ -0,0 +1,33 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2021 Renesas Electronics Corporation.
+// Copyright (C) 2021 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !linux
+
+package canadapter
+
+import (
+	"github.com/aosedge/aos_common/aoserrors"
+)
+
+/*******************************************************************************
+ * Private
+ ******************************************************************************/
+
+// openSocketCAN returns error as SocketCAN is available on Linux only.
+func openSocketCAN(interfaceName string) (bus canBus, err error) {
+	return nil, aoserrors.Errorf("can't open %s: SocketCAN is not supported on this platform", interfaceName)
+}
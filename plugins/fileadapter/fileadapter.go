@@ -0,0 +1,496 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2021 Renesas Electronics Corporation.
+// Copyright (C) 2021 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileadapter
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/aosedge/aos_common/aoserrors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/aosedge/aos_vis/dataprovider"
+)
+
+/*******************************************************************************
+ * Consts
+ ******************************************************************************/
+
+const defaultPollInterval = 1000 // ms
+
+const (
+	watchPoll    = "poll"
+	watchInotify = "inotify"
+	watchNone    = "none"
+)
+
+/*******************************************************************************
+ * Types
+ ******************************************************************************/
+
+// FileAdapter file adapter.
+type FileAdapter struct {
+	baseAdapter *dataprovider.BaseAdapter
+	signals     map[string]*signalMapping
+	watcher     *fileWatcher
+	stopChannel chan struct{}
+	wg          sync.WaitGroup
+}
+
+type adapterConfig struct {
+	// PollInterval default poll interval in milliseconds
+	PollInterval int            `json:"pollInterval"`
+	Signals      []signalConfig `json:"signals"`
+}
+
+type signalConfig struct {
+	Path         string   `json:"path"`
+	File         string   `json:"file"`
+	Parser       string   `json:"parser"`
+	Scale        *float64 `json:"scale"`
+	Pointer      string   `json:"pointer"`
+	Watch        string   `json:"watch"`
+	PollInterval int      `json:"pollInterval"`
+	Type         string   `json:"type"`
+	DataType     string   `json:"dataType"`
+	Unit         string   `json:"unit"`
+	Writable     bool     `json:"writable"`
+	Public       bool     `json:"public"`
+}
+
+// signalMapping maps file content to VIS path.
+type signalMapping struct {
+	path         string
+	file         string
+	parser       string
+	scale        float64
+	pointer      []string
+	watch        string
+	pollInterval time.Duration
+	// failed is set after read error to not report it on each poll
+	failed bool
+}
+
+/*******************************************************************************
+ * Vars
+ ******************************************************************************/
+
+var errWatcherClosed = errors.New("watcher closed")
+
+/*******************************************************************************
+ * Public
+ ******************************************************************************/
+
+// New creates adapter instance.
+func New(configJSON json.RawMessage) (adapter dataprovider.DataAdapter, err error) {
+	log.Info("Create file adapter")
+
+	if configJSON == nil {
+		return nil, aoserrors.New("config should be set")
+	}
+
+	cfg := adapterConfig{PollInterval: defaultPollInterval}
+
+	if err = json.Unmarshal(configJSON, &cfg); err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	if cfg.PollInterval <= 0 {
+		return nil, aoserrors.Errorf("invalid poll interval %d", cfg.PollInterval)
+	}
+
+	localAdapter := &FileAdapter{signals: make(map[string]*signalMapping), stopChannel: make(chan struct{})}
+
+	if localAdapter.baseAdapter, err = dataprovider.NewBaseAdapter(); err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	localAdapter.baseAdapter.Name = "FileAdapter"
+
+	for _, signalCfg := range cfg.Signals {
+		if err = localAdapter.addSignal(signalCfg, cfg.PollInterval); err != nil {
+			return nil, err
+		}
+	}
+
+	mappings := make([]*signalMapping, 0, len(localAdapter.signals))
+
+	for _, mapping := range localAdapter.signals {
+		mappings = append(mappings, mapping)
+	}
+
+	localAdapter.readSignals(mappings)
+
+	if err = localAdapter.startWatching(); err != nil {
+		localAdapter.Close()
+
+		return nil, err
+	}
+
+	return localAdapter, nil
+}
+
+// Close closes adapter.
+func (adapter *FileAdapter) Close() {
+	log.Info("Close file adapter")
+
+	close(adapter.stopChannel)
+
+	if adapter.watcher != nil {
+		if err := adapter.watcher.close(); err != nil {
+			log.Errorf("Can't close file watcher: %s", err)
+		}
+	}
+
+	adapter.wg.Wait()
+
+	adapter.baseAdapter.Close()
+}
+
+// GetName returns adapter name.
+func (adapter *FileAdapter) GetName() (name string) {
+	return adapter.baseAdapter.GetName()
+}
+
+// GetPathList returns list of all pathes for this adapter.
+func (adapter *FileAdapter) GetPathList() (pathList []string, err error) {
+	pathList, err = adapter.baseAdapter.GetPathList()
+	if err != nil {
+		return pathList, aoserrors.Wrap(err)
+	}
+
+	return pathList, nil
+}
+
+// IsPathPublic returns true if requested data accessible without authorization.
+func (adapter *FileAdapter) IsPathPublic(path string) (result bool, err error) {
+	result, err = adapter.baseAdapter.IsPathPublic(path)
+	if err != nil {
+		return result, aoserrors.Wrap(err)
+	}
+
+	return result, nil
+}
+
+// GetMetadata returns metadata by path.
+func (adapter *FileAdapter) GetMetadata(pathList []string) (
+	metadata map[string]*dataprovider.SignalMetadata, err error,
+) {
+	metadata, err = adapter.baseAdapter.GetMetadata(pathList)
+	if err != nil {
+		return metadata, aoserrors.Wrap(err)
+	}
+
+	return metadata, nil
+}
+
+// GetData returns data by path.
+func (adapter *FileAdapter) GetData(pathList []string) (data map[string]interface{}, err error) {
+	data, err = adapter.baseAdapter.GetData(pathList)
+	if err != nil {
+		return data, aoserrors.Wrap(err)
+	}
+
+	return data, nil
+}
+
+// GetTimestamps returns time when data was sampled.
+func (adapter *FileAdapter) GetTimestamps(pathList []string) (timestamps map[string]time.Time, err error) {
+	timestamps, err = adapter.baseAdapter.GetTimestamps(pathList)
+	if err != nil {
+		return timestamps, aoserrors.Wrap(err)
+	}
+
+	return timestamps, nil
+}
+
+// SetData writes values of writable signals to their files.
+func (adapter *FileAdapter) SetData(data map[string]interface{}) (err error) {
+	if err = adapter.baseAdapter.CheckSetData(data); err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	writtenData := make(map[string]interface{})
+
+	for path, value := range data {
+		mapping := adapter.signals[path]
+
+		content, err := mapping.format(value)
+		if err != nil {
+			return err
+		}
+
+		if err = os.WriteFile(mapping.file, content, 0o600); err != nil {
+			return aoserrors.Wrap(err)
+		}
+
+		// Store value as it will be read back to not report change on next read
+		if writtenData[path], err = mapping.parse(content); err != nil {
+			return err
+		}
+	}
+
+	return aoserrors.Wrap(adapter.baseAdapter.SetData(writtenData))
+}
+
+// GetSubscribeChannel returns channel on which data changes will be sent.
+func (adapter *FileAdapter) GetSubscribeChannel() (channel <-chan map[string]interface{}) {
+	return adapter.baseAdapter.SubscribeChannel
+}
+
+// Subscribe subscribes for data changes.
+func (adapter *FileAdapter) Subscribe(pathList []string) (err error) {
+	return aoserrors.Wrap(adapter.baseAdapter.Subscribe(pathList))
+}
+
+// Unsubscribe unsubscribes from data changes.
+func (adapter *FileAdapter) Unsubscribe(pathList []string) (err error) {
+	return aoserrors.Wrap(adapter.baseAdapter.Unsubscribe(pathList))
+}
+
+// UnsubscribeAll unsubscribes from all data changes.
+func (adapter *FileAdapter) UnsubscribeAll() (err error) {
+	return aoserrors.Wrap(adapter.baseAdapter.UnsubscribeAll())
+}
+
+/*******************************************************************************
+ * Private
+ ******************************************************************************/
+
+func (adapter *FileAdapter) addSignal(signalCfg signalConfig, defaultInterval int) (err error) {
+	if signalCfg.Path == "" || signalCfg.File == "" {
+		return aoserrors.Errorf("path and file of signal should be set: %s", signalCfg.Path)
+	}
+
+	if _, ok := adapter.signals[signalCfg.Path]; ok {
+		return aoserrors.Errorf("path %s is mapped twice", signalCfg.Path)
+	}
+
+	mapping := &signalMapping{
+		path: signalCfg.Path, file: filepath.Clean(signalCfg.File), parser: signalCfg.Parser, scale: 1,
+		watch: signalCfg.Watch,
+	}
+
+	if mapping.parser == "" {
+		mapping.parser = parserString
+	}
+
+	if mapping.watch == "" {
+		mapping.watch = watchPoll
+	}
+
+	if err = mapping.setOptions(signalCfg, defaultInterval); err != nil {
+		return err
+	}
+
+	baseData := &dataprovider.BaseData{
+		Public: signalCfg.Public, ReadOnly: !signalCfg.Writable, Type: signalCfg.Type, DataType: signalCfg.DataType,
+		Unit: signalCfg.Unit,
+	}
+
+	if baseData.DataType == "" {
+		baseData.DataType = defaultDataTypes[mapping.parser]
+	}
+
+	if baseData.Type == "" {
+		baseData.Type = dataprovider.NodeTypeSensor
+
+		if signalCfg.Writable {
+			baseData.Type = dataprovider.NodeTypeActuator
+		}
+	}
+
+	adapter.signals[mapping.path] = mapping
+	adapter.baseAdapter.Data[mapping.path] = baseData
+
+	return nil
+}
+
+func (mapping *signalMapping) setOptions(signalCfg signalConfig, defaultInterval int) (err error) {
+	switch mapping.parser {
+	case parserString, parserInt, parserLines:
+
+	case parserFloat:
+		if signalCfg.Scale != nil {
+			if *signalCfg.Scale == 0 {
+				return aoserrors.Errorf("scale of path %s should not be zero", mapping.path)
+			}
+
+			mapping.scale = *signalCfg.Scale
+		}
+
+	case parserJSON:
+		if signalCfg.Writable {
+			return aoserrors.Errorf("signal %s with json parser cannot be writable", mapping.path)
+		}
+
+		if mapping.pointer, err = parseJSONPointer(signalCfg.Pointer); err != nil {
+			return err
+		}
+
+	default:
+		return aoserrors.Errorf("unsupported parser %s of path %s", mapping.parser, mapping.path)
+	}
+
+	switch mapping.watch {
+	case watchPoll:
+		if signalCfg.PollInterval < 0 {
+			return aoserrors.Errorf("invalid poll interval of path %s", mapping.path)
+		}
+
+		if signalCfg.PollInterval == 0 {
+			signalCfg.PollInterval = defaultInterval
+		}
+
+		mapping.pollInterval = time.Duration(signalCfg.PollInterval) * time.Millisecond
+
+	case watchInotify, watchNone:
+
+	default:
+		return aoserrors.Errorf("unsupported watch mode %s of path %s", mapping.watch, mapping.path)
+	}
+
+	return nil
+}
+
+// startWatching starts poll routine per poll interval and inotify routine if any signal uses it.
+func (adapter *FileAdapter) startWatching() (err error) {
+	pollGroups := make(map[time.Duration][]*signalMapping)
+	watchFiles := make(map[string][]*signalMapping)
+
+	for _, mapping := range adapter.signals {
+		switch mapping.watch {
+		case watchPoll:
+			pollGroups[mapping.pollInterval] = append(pollGroups[mapping.pollInterval], mapping)
+
+		case watchInotify:
+			watchFiles[mapping.file] = append(watchFiles[mapping.file], mapping)
+		}
+	}
+
+	if len(watchFiles) > 0 {
+		fileNames := make([]string, 0, len(watchFiles))
+
+		for fileName := range watchFiles {
+			fileNames = append(fileNames, fileName)
+		}
+
+		if adapter.watcher, err = newFileWatcher(fileNames); err != nil {
+			return err
+		}
+
+		adapter.wg.Add(1)
+
+		go adapter.processWatchEvents(watchFiles)
+	}
+
+	for interval, mappings := range pollGroups {
+		adapter.wg.Add(1)
+
+		go adapter.poll(interval, mappings)
+	}
+
+	return nil
+}
+
+func (adapter *FileAdapter) poll(interval time.Duration, mappings []*signalMapping) {
+	defer adapter.wg.Done()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			adapter.readSignals(mappings)
+
+		case <-adapter.stopChannel:
+			return
+		}
+	}
+}
+
+func (adapter *FileAdapter) processWatchEvents(watchFiles map[string][]*signalMapping) {
+	defer adapter.wg.Done()
+
+	for {
+		fileNames, err := adapter.watcher.readEvents()
+		if err != nil {
+			if !errors.Is(err, errWatcherClosed) {
+				log.Errorf("Can't read file watcher events: %s", err)
+			}
+
+			return
+		}
+
+		var mappings []*signalMapping
+
+		for _, fileName := range fileNames {
+			mappings = append(mappings, watchFiles[fileName]...)
+		}
+
+		adapter.readSignals(mappings)
+	}
+}
+
+// readSignals reads files of signals and updates changed values. Each file is read once.
+func (adapter *FileAdapter) readSignals(mappings []*signalMapping) {
+	contents := make(map[string][]byte)
+	data := make(map[string]interface{})
+
+	for _, mapping := range mappings {
+		value, err := mapping.read(contents)
+		if err != nil {
+			if !mapping.failed {
+				log.WithField("path", mapping.path).Warnf("Can't read signal: %s", err)
+			}
+
+			mapping.failed = true
+
+			continue
+		}
+
+		mapping.failed = false
+		data[mapping.path] = value
+	}
+
+	if len(data) == 0 {
+		return
+	}
+
+	if err := adapter.baseAdapter.UpdateData(data); err != nil {
+		log.Errorf("Can't update data: %s", err)
+	}
+}
+
+func (mapping *signalMapping) read(contents map[string][]byte) (value interface{}, err error) {
+	content, ok := contents[mapping.file]
+	if !ok {
+		if content, err = os.ReadFile(mapping.file); err != nil {
+			return nil, aoserrors.Wrap(err)
+		}
+
+		contents[mapping.file] = content
+	}
+
+	return mapping.parse(content)
+}
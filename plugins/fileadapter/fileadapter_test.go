@@ -0,0 +1,359 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2021 Renesas Electronics Corporation.
+// Copyright (C) 2021 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileadapter_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/aosedge/aos_common/aoserrors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/aosedge/aos_vis/dataprovider"
+	"github.com/aosedge/aos_vis/plugins/fileadapter"
+)
+
+/*******************************************************************************
+ * Consts
+ ******************************************************************************/
+
+const waitTimeout = 5 * time.Second
+
+/*******************************************************************************
+ * Vars
+ ******************************************************************************/
+
+var tmpDir string
+
+/*******************************************************************************
+ * Init
+ ******************************************************************************/
+
+func init() {
+	log.SetFormatter(&log.TextFormatter{
+		DisableTimestamp: false,
+		TimestampFormat:  "2006-01-02 15:04:05.000",
+		FullTimestamp:    true,
+	})
+	log.SetLevel(log.DebugLevel)
+	log.SetOutput(os.Stdout)
+}
+
+/*******************************************************************************
+ * Main
+ ******************************************************************************/
+
+func TestMain(m *testing.M) {
+	var err error
+
+	tmpDir, err = os.MkdirTemp("", "fileadapter_")
+	if err != nil {
+		log.Fatalf("Error creating tmp dir: %s", err)
+	}
+
+	ret := m.Run()
+
+	if err := os.RemoveAll(tmpDir); err != nil {
+		log.Fatalf("Error removing tmp dir: %s", err)
+	}
+
+	os.Exit(ret)
+}
+
+/*******************************************************************************
+ * Tests
+ ******************************************************************************/
+
+func TestConfigErrors(t *testing.T) {
+	testItems := []string{
+		`{"PollInterval": -1}`,
+		`{"Signals": [{"Path": "Attribute.Vehicle.VehicleIdentification.VIN"}]}`,
+		`{"Signals": [{"File": "/etc/vin"}]}`,
+		`{"Signals": [
+			{"Path": "Attribute.Vehicle.VehicleIdentification.VIN", "File": "/etc/vin"},
+			{"Path": "Attribute.Vehicle.VehicleIdentification.VIN", "File": "/etc/vin"}
+		]}`,
+		`{"Signals": [{"Path": "Signal.Vehicle.Speed", "File": "/tmp/speed", "Parser": "double"}]}`,
+		`{"Signals": [{"Path": "Signal.Vehicle.Speed", "File": "/tmp/speed", "Parser": "float", "Scale": 0}]}`,
+		`{"Signals": [{"Path": "Signal.Vehicle.Speed", "File": "/tmp/speed", "Parser": "json", "Pointer": "speed"}]}`,
+		`{"Signals": [{"Path": "Signal.Vehicle.Speed", "File": "/tmp/speed", "Parser": "json", "Writable": true}]}`,
+		`{"Signals": [{"Path": "Signal.Vehicle.Speed", "File": "/tmp/speed", "Watch": "fanotify"}]}`,
+		`{"Signals": [{"Path": "Signal.Vehicle.Speed", "File": "/tmp/speed", "PollInterval": -1}]}`,
+	}
+
+	for _, item := range testItems {
+		if adapter, err := fileadapter.New(json.RawMessage(item)); err == nil {
+			adapter.Close()
+			t.Errorf("Error expected for config: %s", item)
+		}
+	}
+}
+
+func TestParsers(t *testing.T) {
+	files := map[string]string{
+		"vin":    "TEST_VIN\n",
+		"mode":   "0x1f\n",
+		"temp":   "45500\n",
+		"status": `{"battery": {"cells": [3.7, 3.8]}, "a/b": {"c~d": "value"}}`,
+		"users":  "user1\nuser2\n",
+	}
+
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(tmpDir, name), []byte(content), 0o600); err != nil {
+			t.Fatalf("Can't write file: %s", err)
+		}
+	}
+
+	adapter, err := fileadapter.New(generateConfig(`[
+		{"Path": "Attribute.Vehicle.VehicleIdentification.VIN", "File": "vin", "Watch": "none"},
+		{"Path": "Attribute.Aos.UnitMode", "File": "mode", "Parser": "int", "Watch": "none"},
+		{"Path": "Signal.Cabin.Temperature", "File": "temp", "Parser": "float", "Scale": 0.001, "Watch": "none"},
+		{"Path": "Signal.Battery.Cell2.Voltage", "File": "status", "Parser": "json", "Pointer": "/battery/cells/1",
+		 "Watch": "none"},
+		{"Path": "Attribute.Status.Escaped", "File": "status", "Parser": "json", "Pointer": "/a~1b/c~0d",
+		 "Watch": "none"},
+		{"Path": "Attribute.Aos.Subjects", "File": "users", "Parser": "lines", "Watch": "none"},
+		{"Path": "Attribute.Aos.Missing", "File": "missing", "Watch": "none"}
+	]`))
+	if err != nil {
+		t.Fatalf("Can't create adapter: %s", err)
+	}
+	defer adapter.Close()
+
+	expectedData := map[string]interface{}{
+		"Attribute.Vehicle.VehicleIdentification.VIN": "TEST_VIN",
+		"Attribute.Aos.UnitMode":                      int64(31),
+		"Signal.Cabin.Temperature":                    45.5,
+		"Signal.Battery.Cell2.Voltage":                3.8,
+		"Attribute.Status.Escaped":                    "value",
+		"Attribute.Aos.Subjects":                      []string{"user1", "user2"},
+		"Attribute.Aos.Missing":                       nil,
+	}
+
+	pathList := make([]string, 0, len(expectedData))
+
+	for path := range expectedData {
+		pathList = append(pathList, path)
+	}
+
+	data, err := adapter.GetData(pathList)
+	if err != nil {
+		t.Fatalf("Can't get data: %s", err)
+	}
+
+	if !reflect.DeepEqual(data, expectedData) {
+		t.Errorf("Wrong data: %v", data)
+	}
+
+	metadata, err := adapter.GetMetadata([]string{"Attribute.Aos.UnitMode", "Attribute.Aos.Subjects"})
+	if err != nil {
+		t.Fatalf("Can't get metadata: %s", err)
+	}
+
+	if metadata["Attribute.Aos.UnitMode"].DataType != "int64" ||
+		metadata["Attribute.Aos.Subjects"].DataType != "string[]" {
+		t.Error("Wrong default datatype")
+	}
+}
+
+func TestPolling(t *testing.T) {
+	file := filepath.Join(tmpDir, "polling")
+
+	if err := os.WriteFile(file, []byte("10"), 0o600); err != nil {
+		t.Fatalf("Can't write file: %s", err)
+	}
+
+	adapter, err := fileadapter.New(generateConfig(`[
+		{"Path": "Signal.Vehicle.Speed", "File": "polling", "Parser": "int", "PollInterval": 20}
+	]`))
+	if err != nil {
+		t.Fatalf("Can't create adapter: %s", err)
+	}
+	defer adapter.Close()
+
+	if err = adapter.Subscribe([]string{"Signal.Vehicle.Speed"}); err != nil {
+		t.Fatalf("Can't subscribe: %s", err)
+	}
+
+	if err := os.WriteFile(file, []byte("20"), 0o600); err != nil {
+		t.Fatalf("Can't write file: %s", err)
+	}
+
+	if err = waitChange(adapter, map[string]interface{}{"Signal.Vehicle.Speed": int64(20)}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestInotify(t *testing.T) {
+	file := filepath.Join(tmpDir, "inotify")
+
+	if err := os.WriteFile(file, []byte("initial"), 0o600); err != nil {
+		t.Fatalf("Can't write file: %s", err)
+	}
+
+	adapter, err := fileadapter.New(generateConfig(`[
+		{"Path": "Attribute.Vehicle.VehicleIdentification.VIN", "File": "inotify", "Watch": "inotify"}
+	]`))
+	if err != nil {
+		t.Fatalf("Can't create adapter: %s", err)
+	}
+	defer adapter.Close()
+
+	if err = adapter.Subscribe([]string{"Attribute.Vehicle.VehicleIdentification.VIN"}); err != nil {
+		t.Fatalf("Can't subscribe: %s", err)
+	}
+
+	// In place write
+	if err := os.WriteFile(file, []byte("changed"), 0o600); err != nil {
+		t.Fatalf("Can't write file: %s", err)
+	}
+
+	if err = waitChange(
+		adapter, map[string]interface{}{"Attribute.Vehicle.VehicleIdentification.VIN": "changed"}); err != nil {
+		t.Error(err)
+	}
+
+	// Atomic replacement
+	if err := os.WriteFile(file+".tmp", []byte("replaced"), 0o600); err != nil {
+		t.Fatalf("Can't write file: %s", err)
+	}
+
+	if err := os.Rename(file+".tmp", file); err != nil {
+		t.Fatalf("Can't rename file: %s", err)
+	}
+
+	if err = waitChange(
+		adapter, map[string]interface{}{"Attribute.Vehicle.VehicleIdentification.VIN": "replaced"}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSetData(t *testing.T) {
+	adapter, err := fileadapter.New(generateConfig(`[
+		{"Path": "Attribute.Aos.UnitMode", "File": "setmode", "Parser": "int", "Writable": true, "Watch": "none"},
+		{"Path": "Attribute.Aos.Subjects", "File": "setusers", "Parser": "lines", "Writable": true, "Watch": "none"},
+		{"Path": "Signal.Cabin.Temperature", "File": "settemp", "Parser": "float", "Scale": 0.001, "Writable": true,
+		 "Watch": "none"},
+		{"Path": "Attribute.Vehicle.VehicleIdentification.VIN", "File": "vin", "Watch": "none"}
+	]`))
+	if err != nil {
+		t.Fatalf("Can't create adapter: %s", err)
+	}
+	defer adapter.Close()
+
+	metadata, err := adapter.GetMetadata([]string{
+		"Attribute.Aos.UnitMode", "Attribute.Vehicle.VehicleIdentification.VIN",
+	})
+	if err != nil {
+		t.Fatalf("Can't get metadata: %s", err)
+	}
+
+	if !metadata["Attribute.Aos.UnitMode"].Writable || metadata["Attribute.Vehicle.VehicleIdentification.VIN"].Writable {
+		t.Error("Wrong writable metadata")
+	}
+
+	if err = adapter.SetData(map[string]interface{}{
+		"Attribute.Vehicle.VehicleIdentification.VIN": "NEW_VIN",
+	}); err == nil {
+		t.Error("Error expected for read only signal")
+	}
+
+	if err = adapter.SetData(map[string]interface{}{"Attribute.Aos.UnitMode": 1.5}); err == nil {
+		t.Error("Error expected for non integer value")
+	}
+
+	if err = adapter.SetData(map[string]interface{}{
+		"Attribute.Aos.UnitMode":   3.0,
+		"Attribute.Aos.Subjects":   []interface{}{"user1", "user2"},
+		"Signal.Cabin.Temperature": 21.5,
+	}); err != nil {
+		t.Fatalf("Can't set data: %s", err)
+	}
+
+	expectedContents := map[string]string{
+		"setmode":  "3\n",
+		"setusers": "user1\nuser2\n",
+		"settemp":  "21500\n",
+	}
+
+	for name, expectedContent := range expectedContents {
+		content, err := os.ReadFile(filepath.Join(tmpDir, name))
+		if err != nil {
+			t.Fatalf("Can't read file: %s", err)
+		}
+
+		if string(content) != expectedContent {
+			t.Errorf("Wrong %s content: %s", name, string(content))
+		}
+	}
+
+	data, err := adapter.GetData([]string{"Attribute.Aos.UnitMode", "Attribute.Aos.Subjects"})
+	if err != nil {
+		t.Fatalf("Can't get data: %s", err)
+	}
+
+	if !reflect.DeepEqual(data, map[string]interface{}{
+		"Attribute.Aos.UnitMode": int64(3), "Attribute.Aos.Subjects": []string{"user1", "user2"},
+	}) {
+		t.Errorf("Wrong data: %v", data)
+	}
+}
+
+/*******************************************************************************
+ * Private
+ ******************************************************************************/
+
+// generateConfig creates adapter config, relative file names are resolved against tmp dir.
+func generateConfig(signalsJSON string) (configJSON json.RawMessage) {
+	var signals []map[string]interface{}
+
+	if err := json.Unmarshal([]byte(signalsJSON), &signals); err != nil {
+		log.Fatalf("Can't parse signals: %s", err)
+	}
+
+	for _, signal := range signals {
+		signal["File"] = filepath.Join(tmpDir, signal["File"].(string))
+	}
+
+	configJSON, err := json.Marshal(map[string]interface{}{"Signals": signals})
+	if err != nil {
+		log.Fatalf("Can't create config: %s", err)
+	}
+
+	return configJSON
+}
+
+func waitChange(adapter dataprovider.DataAdapter, expectedData map[string]interface{}) (err error) {
+	select {
+	case changes := <-adapter.GetSubscribeChannel():
+		data, _ := dataprovider.UnpackChanges(changes)
+
+		if !reflect.DeepEqual(data, expectedData) {
+			return aoserrors.Errorf("wrong changed data: %v", data)
+		}
+
+		return nil
+
+	case <-time.After(waitTimeout):
+		return aoserrors.New("wait data change timeout")
+	}
+}
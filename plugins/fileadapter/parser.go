@@ -0,0 +1,211 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2021 Renesas Electronics Corporation.
+// Copyright (C) 2021 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileadapter
+
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/aosedge/aos_common/aoserrors"
+
+	"github.com/aosedge/aos_vis/dataprovider"
+)
+
+/*******************************************************************************
+ * Consts
+ ******************************************************************************/
+
+const (
+	parserString = "string"
+	parserInt    = "int"
+	parserFloat  = "float"
+	parserJSON   = "json"
+	parserLines  = "lines"
+)
+
+/*******************************************************************************
+ * Vars
+ ******************************************************************************/
+
+//nolint:gochecknoglobals // constant map
+var defaultDataTypes = map[string]string{
+	parserString: "string",
+	parserInt:    "int64",
+	parserFloat:  "double",
+	parserLines:  "string[]",
+}
+
+/*******************************************************************************
+ * Private
+ ******************************************************************************/
+
+// parse converts file content to VIS value.
+func (mapping *signalMapping) parse(content []byte) (value interface{}, err error) {
+	text := strings.TrimSpace(string(content))
+
+	switch mapping.parser {
+	case parserString:
+		return text, nil
+
+	case parserInt:
+		// Base prefix is accepted as sysfs entries may contain hex values
+		intValue, err := strconv.ParseInt(text, 0, 64)
+		if err != nil {
+			return nil, aoserrors.Wrap(err)
+		}
+
+		return intValue, nil
+
+	case parserFloat:
+		floatValue, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			return nil, aoserrors.Wrap(err)
+		}
+
+		return floatValue * mapping.scale, nil
+
+	case parserJSON:
+		var document interface{}
+
+		if err = json.Unmarshal(content, &document); err != nil {
+			return nil, aoserrors.Wrap(err)
+		}
+
+		return resolveJSONPointer(document, mapping.pointer)
+
+	case parserLines:
+		lines := make([]string, 0)
+
+		if text != "" {
+			lines = strings.Split(text, "\n")
+		}
+
+		return lines, nil
+
+	default:
+		return nil, aoserrors.Errorf("unsupported parser %s", mapping.parser)
+	}
+}
+
+// format converts VIS value to file content.
+func (mapping *signalMapping) format(value interface{}) (content []byte, err error) {
+	switch mapping.parser {
+	case parserString:
+		stringValue, ok := value.(string)
+		if !ok {
+			return nil, aoserrors.Errorf("invalid value for path %s: %v is not string", mapping.path, value)
+		}
+
+		return []byte(stringValue), nil
+
+	case parserInt:
+		floatValue, ok := dataprovider.GetNumericValue(value)
+		if !ok || floatValue != math.Trunc(floatValue) {
+			return nil, aoserrors.Errorf("invalid value for path %s: %v is not integer", mapping.path, value)
+		}
+
+		return []byte(strconv.FormatInt(int64(floatValue), 10) + "\n"), nil
+
+	case parserFloat:
+		floatValue, ok := dataprovider.GetNumericValue(value)
+		if !ok {
+			return nil, aoserrors.Errorf("invalid value for path %s: %v is not numeric", mapping.path, value)
+		}
+
+		return []byte(strconv.FormatFloat(floatValue/mapping.scale, 'g', -1, 64) + "\n"), nil
+
+	case parserLines:
+		return formatLines(mapping.path, value)
+
+	default:
+		return nil, aoserrors.Errorf("signal %s with parser %s cannot be written", mapping.path, mapping.parser)
+	}
+}
+
+func formatLines(path string, value interface{}) (content []byte, err error) {
+	var builder strings.Builder
+
+	switch lines := value.(type) {
+	case []string:
+		for _, line := range lines {
+			builder.WriteString(line + "\n")
+		}
+
+	case []interface{}:
+		for _, item := range lines {
+			line, ok := item.(string)
+			if !ok {
+				return nil, aoserrors.Errorf("wrong element type for path %s", path)
+			}
+
+			builder.WriteString(line + "\n")
+		}
+
+	default:
+		return nil, aoserrors.Errorf("wrong value type for path %s", path)
+	}
+
+	return []byte(builder.String()), nil
+}
+
+// parseJSONPointer splits RFC 6901 JSON pointer to unescaped reference tokens.
+func parseJSONPointer(pointer string) (tokens []string, err error) {
+	if pointer == "" {
+		return nil, nil
+	}
+
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, aoserrors.Errorf("JSON pointer %s should start with /", pointer)
+	}
+
+	for _, token := range strings.Split(pointer[1:], "/") {
+		tokens = append(tokens, strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~"))
+	}
+
+	return tokens, nil
+}
+
+func resolveJSONPointer(document interface{}, tokens []string) (value interface{}, err error) {
+	value = document
+
+	for _, token := range tokens {
+		switch element := value.(type) {
+		case map[string]interface{}:
+			var ok bool
+
+			if value, ok = element[token]; !ok {
+				return nil, aoserrors.Errorf("field %s not found", token)
+			}
+
+		case []interface{}:
+			index, err := strconv.Atoi(token)
+			if err != nil || index < 0 || index >= len(element) {
+				return nil, aoserrors.Errorf("invalid array index %s", token)
+			}
+
+			value = element[index]
+
+		default:
+			return nil, aoserrors.Errorf("element %s not found", token)
+		}
+	}
+
+	return value, nil
+}
@@ -0,0 +1,114 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2021 Renesas Electronics Corporation.
+// Copyright (C) 2021 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileadapter
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"os"
+	"path/filepath"
+
+	"github.com/aosedge/aos_common/aoserrors"
+	"golang.org/x/sys/unix"
+)
+
+/*******************************************************************************
+ * Consts
+ ******************************************************************************/
+
+// Directory is watched to detect both in place writes and atomic replacements of files.
+const (
+	watchMask       = unix.IN_CLOSE_WRITE | unix.IN_MOVED_TO
+	eventBufferSize = 4096
+	eventMaskOffset = 4
+	eventLenOffset  = 12
+)
+
+/*******************************************************************************
+ * Types
+ ******************************************************************************/
+
+type fileWatcher struct {
+	file        *os.File
+	directories map[int32]string
+}
+
+/*******************************************************************************
+ * Private
+ ******************************************************************************/
+
+// newFileWatcher creates inotify watcher for directories of specified files.
+func newFileWatcher(fileNames []string) (watcher *fileWatcher, err error) {
+	fd, err := unix.InotifyInit1(unix.IN_CLOEXEC | unix.IN_NONBLOCK)
+	if err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	// Non blocking descriptor is handled by runtime poller, so close interrupts pending read
+	watcher = &fileWatcher{file: os.NewFile(uintptr(fd), "inotify"), directories: make(map[int32]string)}
+
+	for _, fileName := range fileNames {
+		directory := filepath.Dir(fileName)
+
+		wd, err := unix.InotifyAddWatch(fd, directory, watchMask)
+		if err != nil {
+			watcher.file.Close()
+
+			return nil, aoserrors.Errorf("can't watch %s: %v", directory, err)
+		}
+
+		watcher.directories[int32(wd)] = directory //nolint:gosec // watch descriptor is int32 in inotify event
+	}
+
+	return watcher, nil
+}
+
+// readEvents waits for changes and returns changed files.
+func (watcher *fileWatcher) readEvents() (fileNames []string, err error) {
+	buffer := make([]byte, eventBufferSize)
+
+	length, err := watcher.file.Read(buffer)
+	if err != nil {
+		if errors.Is(err, os.ErrClosed) {
+			return nil, errWatcherClosed
+		}
+
+		return nil, aoserrors.Wrap(err)
+	}
+
+	for offset := 0; offset+unix.SizeofInotifyEvent <= length; {
+		event := buffer[offset:]
+		wd := int32(binary.NativeEndian.Uint32(event)) //nolint:gosec // inotify wd is int32
+		mask := binary.NativeEndian.Uint32(event[eventMaskOffset:])
+		nameLength := int(binary.NativeEndian.Uint32(event[eventLenOffset:]))
+		name := string(bytes.TrimRight(event[unix.SizeofInotifyEvent:unix.SizeofInotifyEvent+nameLength], "\x00"))
+
+		offset += unix.SizeofInotifyEvent + nameLength
+
+		if directory, ok := watcher.directories[wd]; ok && mask&watchMask != 0 && name != "" {
+			fileNames = append(fileNames, filepath.Join(directory, name))
+		}
+	}
+
+	return fileNames, nil
+}
+
+func (watcher *fileWatcher) close() (err error) {
+	return aoserrors.Wrap(watcher.file.Close())
+}
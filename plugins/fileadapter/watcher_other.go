@@ -0,0 +1,47 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2021 Renesas Electronics Corporation.
+// Copyright (C) 2021 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !linux
+
+package fileadapter
+
+import (
+	"github.com/aosedge/aos_common/aoserrors"
+)
+
+/*******************************************************************************
+ * Types
+ ******************************************************************************/
+
+type fileWatcher struct{}
+
+/*******************************************************************************
+ * Private
+ ******************************************************************************/
+
+// newFileWatcher returns error as inotify is available on Linux only.
+func newFileWatcher(fileNames []string) (watcher *fileWatcher, err error) {
+	return nil, aoserrors.New("inotify is not supported on this platform, use polling")
+}
+
+func (watcher *fileWatcher) readEvents() (fileNames []string, err error) {
+	return nil, errWatcherClosed
+}
+
+func (watcher *fileWatcher) close() (err error) {
+	return nil
+}
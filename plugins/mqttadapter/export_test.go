@@ -0,0 +1,86 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2021 Renesas Electronics Corporation.
+// Copyright (C) 2021 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mqttadapter
+
+import (
+	"time"
+
+	"github.com/aosedge/aos_common/aoserrors"
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+
+	"github.com/aosedge/aos_vis/dataprovider"
+)
+
+/*******************************************************************************
+ * Types
+ ******************************************************************************/
+
+// failingClient MQTT client which fails publishes to the topic.
+type failingClient struct {
+	mqtt.Client
+	topic string
+}
+
+// failedToken completed token with error.
+type failedToken struct {
+	err  error
+	done chan struct{}
+}
+
+/*******************************************************************************
+ * Public
+ ******************************************************************************/
+
+// FailPublish makes adapter publishes to the topic fail.
+func FailPublish(adapter dataprovider.DataAdapter, topic string) {
+	mqttAdapter, _ := adapter.(*MQTTAdapter)
+
+	mqttAdapter.client = &failingClient{Client: mqttAdapter.client, topic: topic}
+}
+
+/*******************************************************************************
+ * Private
+ ******************************************************************************/
+
+func (client *failingClient) Publish(topic string, qos byte, retained bool, payload interface{}) mqtt.Token {
+	if topic == client.topic {
+		token := &failedToken{err: aoserrors.Errorf("publish to topic %s failed", topic), done: make(chan struct{})}
+
+		close(token.done)
+
+		return token
+	}
+
+	return client.Client.Publish(topic, qos, retained, payload)
+}
+
+func (token *failedToken) Wait() bool {
+	return true
+}
+
+func (token *failedToken) WaitTimeout(time.Duration) bool {
+	return true
+}
+
+func (token *failedToken) Done() <-chan struct{} {
+	return token.done
+}
+
+func (token *failedToken) Error() error {
+	return token.err
+}
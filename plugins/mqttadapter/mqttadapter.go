@@ -0,0 +1,399 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2021 Renesas Electronics Corporation.
+// Copyright (C) 2021 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mqttadapter
+
+import (
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/aosedge/aos_common/aoserrors"
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/aosedge/aos_vis/dataprovider"
+)
+
+/*******************************************************************************
+ * Consts
+ ******************************************************************************/
+
+const (
+	defaultClientID             = "aos_vis"
+	defaultMaxReconnectInterval = 30 * time.Second
+	connectRetryInterval        = 1 * time.Second
+	publishTimeout              = 5 * time.Second
+	disconnectQuiesce           = 250 // ms
+	maxQoS                      = 2
+)
+
+/*******************************************************************************
+ * Types
+ ******************************************************************************/
+
+// MQTTAdapter MQTT bridge adapter. Values are published on VIS set requests of adapter writable paths only,
+// changes of paths provided by other adapters are not published.
+type MQTTAdapter struct {
+	baseAdapter *dataprovider.BaseAdapter
+	client      mqtt.Client
+	qos         byte
+	signals     map[string]*signalMapping
+	topics      map[string][]*signalMapping
+}
+
+type adapterConfig struct {
+	Broker   string `json:"broker"`
+	ClientID string `json:"clientId"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+	QoS      byte   `json:"qos"`
+	// MaxReconnectInterval max interval between reconnect attempts in seconds
+	MaxReconnectInterval int            `json:"maxReconnectInterval"`
+	Signals              []signalConfig `json:"signals"`
+}
+
+type signalConfig struct {
+	Path         string      `json:"path"`
+	Topic        string      `json:"topic"`
+	Selector     string      `json:"selector"`
+	PublishTopic string      `json:"publishTopic"`
+	Value        interface{} `json:"value"`
+	DataType     string      `json:"dataType"`
+	Unit         string      `json:"unit"`
+	Writable     bool        `json:"writable"`
+	Public       bool        `json:"public"`
+}
+
+// signalMapping maps payload element of topic message to VIS path.
+type signalMapping struct {
+	path         string
+	selector     selector
+	publishTopic string
+}
+
+/*******************************************************************************
+ * Public
+ ******************************************************************************/
+
+// New creates adapter instance.
+func New(configJSON json.RawMessage) (adapter dataprovider.DataAdapter, err error) {
+	log.Info("Create MQTT adapter")
+
+	var cfg adapterConfig
+
+	if err = json.Unmarshal(configJSON, &cfg); err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	if cfg.Broker == "" {
+		return nil, aoserrors.New("broker should be set")
+	}
+
+	if cfg.QoS > maxQoS {
+		return nil, aoserrors.Errorf("invalid QoS %d", cfg.QoS)
+	}
+
+	localAdapter := &MQTTAdapter{
+		qos:     cfg.QoS,
+		signals: make(map[string]*signalMapping),
+		topics:  make(map[string][]*signalMapping),
+	}
+
+	if localAdapter.baseAdapter, err = dataprovider.NewBaseAdapter(); err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	localAdapter.baseAdapter.Name = "MQTTAdapter"
+
+	for _, signalCfg := range cfg.Signals {
+		if err = localAdapter.addSignal(signalCfg); err != nil {
+			return nil, err
+		}
+	}
+
+	localAdapter.client = mqtt.NewClient(localAdapter.createClientOptions(cfg))
+
+	// Connection is retried in background, so broker may be unavailable on start
+	localAdapter.client.Connect()
+
+	return localAdapter, nil
+}
+
+// Close closes adapter.
+func (adapter *MQTTAdapter) Close() {
+	log.Info("Close MQTT adapter")
+
+	adapter.client.Disconnect(disconnectQuiesce)
+
+	adapter.baseAdapter.Close()
+}
+
+// GetName returns adapter name.
+func (adapter *MQTTAdapter) GetName() (name string) {
+	return adapter.baseAdapter.GetName()
+}
+
+// GetPathList returns list of all pathes for this adapter.
+func (adapter *MQTTAdapter) GetPathList() (pathList []string, err error) {
+	pathList, err = adapter.baseAdapter.GetPathList()
+	if err != nil {
+		return pathList, aoserrors.Wrap(err)
+	}
+
+	return pathList, nil
+}
+
+// IsPathPublic returns true if requested data accessible without authorization.
+func (adapter *MQTTAdapter) IsPathPublic(path string) (result bool, err error) {
+	result, err = adapter.baseAdapter.IsPathPublic(path)
+	if err != nil {
+		return result, aoserrors.Wrap(err)
+	}
+
+	return result, nil
+}
+
+// GetMetadata returns metadata by path.
+func (adapter *MQTTAdapter) GetMetadata(pathList []string) (
+	metadata map[string]*dataprovider.SignalMetadata, err error,
+) {
+	metadata, err = adapter.baseAdapter.GetMetadata(pathList)
+	if err != nil {
+		return metadata, aoserrors.Wrap(err)
+	}
+
+	return metadata, nil
+}
+
+// GetData returns data by path.
+func (adapter *MQTTAdapter) GetData(pathList []string) (data map[string]interface{}, err error) {
+	data, err = adapter.baseAdapter.GetData(pathList)
+	if err != nil {
+		return data, aoserrors.Wrap(err)
+	}
+
+	return data, nil
+}
+
+// GetTimestamps returns time when data was sampled.
+func (adapter *MQTTAdapter) GetTimestamps(pathList []string) (timestamps map[string]time.Time, err error) {
+	timestamps, err = adapter.baseAdapter.GetTimestamps(pathList)
+	if err != nil {
+		return timestamps, aoserrors.Wrap(err)
+	}
+
+	return timestamps, nil
+}
+
+// SetData sets values of writable signals and publishes them to configured topics. Values received from MQTT are not
+// published back. Set is not atomic: paths are published in sorted order and if publish fails, values published
+// before the failure are set and the error is returned.
+func (adapter *MQTTAdapter) SetData(data map[string]interface{}) (err error) {
+	if err = adapter.baseAdapter.CheckSetData(data); err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	payloads := make(map[string][]byte)
+
+	for path, value := range data {
+		if payloads[path], err = json.Marshal(value); err != nil {
+			return aoserrors.Wrap(err)
+		}
+	}
+
+	pathList := make([]string, 0, len(data))
+
+	for path := range data {
+		pathList = append(pathList, path)
+	}
+
+	sort.Strings(pathList)
+
+	publishedData := make(map[string]interface{})
+
+	for _, path := range pathList {
+		if err = adapter.publish(adapter.signals[path], payloads[path]); err != nil {
+			break
+		}
+
+		publishedData[path] = data[path]
+	}
+
+	// Published values are already sent to broker, so they are set even if next publish fails
+	if setErr := adapter.baseAdapter.SetData(publishedData); err == nil {
+		err = aoserrors.Wrap(setErr)
+	}
+
+	return err
+}
+
+// GetSubscribeChannel returns channel on which data changes will be sent.
+func (adapter *MQTTAdapter) GetSubscribeChannel() (channel <-chan map[string]interface{}) {
+	return adapter.baseAdapter.SubscribeChannel
+}
+
+// Subscribe subscribes for data changes.
+func (adapter *MQTTAdapter) Subscribe(pathList []string) (err error) {
+	return aoserrors.Wrap(adapter.baseAdapter.Subscribe(pathList))
+}
+
+// Unsubscribe unsubscribes from data changes.
+func (adapter *MQTTAdapter) Unsubscribe(pathList []string) (err error) {
+	return aoserrors.Wrap(adapter.baseAdapter.Unsubscribe(pathList))
+}
+
+// UnsubscribeAll unsubscribes from all data changes.
+func (adapter *MQTTAdapter) UnsubscribeAll() (err error) {
+	return aoserrors.Wrap(adapter.baseAdapter.UnsubscribeAll())
+}
+
+/*******************************************************************************
+ * Private
+ ******************************************************************************/
+
+func (adapter *MQTTAdapter) addSignal(signalCfg signalConfig) (err error) {
+	if signalCfg.Path == "" {
+		return aoserrors.Errorf("path of signal with topic %s is not set", signalCfg.Topic)
+	}
+
+	if _, ok := adapter.signals[signalCfg.Path]; ok {
+		return aoserrors.Errorf("path %s is mapped twice", signalCfg.Path)
+	}
+
+	if signalCfg.Selector != "" && signalCfg.Topic == "" {
+		return aoserrors.Errorf("selector of path %s is set without topic", signalCfg.Path)
+	}
+
+	// Values are published on VIS set requests only, so publish topic of read only signal is never used
+	if signalCfg.PublishTopic != "" && !signalCfg.Writable {
+		return aoserrors.Errorf("publish topic of read only path %s is set", signalCfg.Path)
+	}
+
+	mapping := &signalMapping{
+		path: signalCfg.Path, publishTopic: signalCfg.PublishTopic,
+	}
+
+	if mapping.selector, err = parseSelector(signalCfg.Selector); err != nil {
+		return err
+	}
+
+	baseData := &dataprovider.BaseData{
+		Public: signalCfg.Public, ReadOnly: !signalCfg.Writable, Value: signalCfg.Value, DataType: signalCfg.DataType,
+		Unit: signalCfg.Unit, Type: dataprovider.NodeTypeSensor,
+	}
+
+	if signalCfg.Writable {
+		baseData.Type = dataprovider.NodeTypeActuator
+	}
+
+	adapter.signals[mapping.path] = mapping
+	adapter.baseAdapter.Data[mapping.path] = baseData
+
+	if signalCfg.Topic != "" {
+		adapter.topics[signalCfg.Topic] = append(adapter.topics[signalCfg.Topic], mapping)
+	}
+
+	return nil
+}
+
+func (adapter *MQTTAdapter) createClientOptions(cfg adapterConfig) (options *mqtt.ClientOptions) {
+	options = mqtt.NewClientOptions().AddBroker(cfg.Broker).SetClientID(cfg.ClientID).
+		SetUsername(cfg.Username).SetPassword(cfg.Password).
+		SetAutoReconnect(true).SetConnectRetry(true).SetConnectRetryInterval(connectRetryInterval).
+		SetMaxReconnectInterval(defaultMaxReconnectInterval).
+		SetOnConnectHandler(adapter.handleConnect).
+		SetConnectionLostHandler(func(client mqtt.Client, err error) {
+			log.Warnf("MQTT connection lost: %s", err)
+		})
+
+	if cfg.ClientID == "" {
+		options.SetClientID(defaultClientID)
+	}
+
+	// Reconnect interval is doubled after each failed attempt up to this value
+	if cfg.MaxReconnectInterval > 0 {
+		options.SetMaxReconnectInterval(time.Duration(cfg.MaxReconnectInterval) * time.Second)
+	}
+
+	return options
+}
+
+// handleConnect subscribes to configured topics on each connect as clean session doesn't keep subscriptions.
+func (adapter *MQTTAdapter) handleConnect(client mqtt.Client) {
+	log.Info("MQTT connected")
+
+	for topic, mappings := range adapter.topics {
+		token := client.Subscribe(topic, adapter.qos, adapter.createMessageHandler(mappings))
+
+		go func(topic string) {
+			if token.Wait(); token.Error() != nil {
+				log.WithField("topic", topic).Errorf("Can't subscribe to MQTT topic: %s", token.Error())
+			}
+		}(topic)
+	}
+}
+
+func (adapter *MQTTAdapter) createMessageHandler(mappings []*signalMapping) (handler mqtt.MessageHandler) {
+	return func(client mqtt.Client, message mqtt.Message) {
+		if err := adapter.handleMessage(mappings, message.Payload()); err != nil {
+			log.WithField("topic", message.Topic()).Errorf("Can't handle MQTT message: %s", err)
+		}
+	}
+}
+
+func (adapter *MQTTAdapter) handleMessage(mappings []*signalMapping, payload []byte) (err error) {
+	var content interface{}
+
+	if err = json.Unmarshal(payload, &content); err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	data := make(map[string]interface{})
+
+	for _, mapping := range mappings {
+		value, err := mapping.selector.apply(content)
+		if err != nil {
+			// Topic may carry different payloads, so missing element is not an error
+			log.WithField("path", mapping.path).Debugf("Payload element not selected: %s", err)
+
+			continue
+		}
+
+		data[mapping.path] = value
+	}
+
+	if len(data) == 0 {
+		return nil
+	}
+
+	return aoserrors.Wrap(adapter.baseAdapter.UpdateData(data))
+}
+
+func (adapter *MQTTAdapter) publish(mapping *signalMapping, payload []byte) (err error) {
+	if mapping.publishTopic == "" {
+		return nil
+	}
+
+	token := adapter.client.Publish(mapping.publishTopic, adapter.qos, false, payload)
+
+	if !token.WaitTimeout(publishTimeout) {
+		return aoserrors.Errorf("publish to topic %s timeout", mapping.publishTopic)
+	}
+
+	return aoserrors.Wrap(token.Error())
+}
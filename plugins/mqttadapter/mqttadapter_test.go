@@ -0,0 +1,616 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2021 Renesas Electronics Corporation.
+// Copyright (C) 2021 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mqttadapter_test
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"net"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aosedge/aos_common/aoserrors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/aosedge/aos_vis/dataprovider"
+	"github.com/aosedge/aos_vis/plugins/mqttadapter"
+)
+
+/*******************************************************************************
+ * Consts
+ ******************************************************************************/
+
+const waitTimeout = 10 * time.Second
+
+const (
+	packetConnect     = 1
+	packetPublish     = 3
+	packetSubscribe   = 8
+	packetUnsubscribe = 10
+	packetPingReq     = 12
+	packetDisconnect  = 14
+)
+
+/*******************************************************************************
+ * Types
+ ******************************************************************************/
+
+type publishMessage struct {
+	topic   string
+	payload []byte
+}
+
+// testBroker minimal MQTT 3.1.1 broker stand-in which forwards QoS 0 messages.
+type testBroker struct {
+	sync.Mutex
+	address   string
+	listener  net.Listener
+	clients   map[*brokerClient]struct{}
+	published chan publishMessage
+	wg        sync.WaitGroup
+}
+
+type brokerClient struct {
+	sync.Mutex
+	conn    net.Conn
+	filters []string
+}
+
+/*******************************************************************************
+ * Init
+ ******************************************************************************/
+
+func init() {
+	log.SetFormatter(&log.TextFormatter{
+		DisableTimestamp: false,
+		TimestampFormat:  "2006-01-02 15:04:05.000",
+		FullTimestamp:    true,
+	})
+	log.SetLevel(log.DebugLevel)
+	log.SetOutput(os.Stdout)
+}
+
+/*******************************************************************************
+ * Tests
+ ******************************************************************************/
+
+func TestConfigErrors(t *testing.T) {
+	testItems := []string{
+		`{"Signals": [{"Path": "Signal.Vehicle.Speed", "Topic": "vehicle"}]}`,
+		`{"Broker": "tcp://localhost:1883", "QoS": 3}`,
+		`{"Broker": "tcp://localhost:1883", "Signals": [{"Topic": "vehicle"}]}`,
+		`{"Broker": "tcp://localhost:1883", "Signals": [
+			{"Path": "Signal.Vehicle.Speed", "Topic": "vehicle"},
+			{"Path": "Signal.Vehicle.Speed", "Topic": "vehicle"}
+		]}`,
+		`{"Broker": "tcp://localhost:1883", "Signals": [{"Path": "Signal.Vehicle.Speed", "Selector": "$.speed"}]}`,
+		`{"Broker": "tcp://localhost:1883", "Signals": [
+			{"Path": "Signal.Vehicle.Speed", "Topic": "vehicle", "Selector": "speed"}
+		]}`,
+		`{"Broker": "tcp://localhost:1883", "Signals": [
+			{"Path": "Signal.Vehicle.Speed", "Topic": "vehicle", "Selector": "$.speed[1"}
+		]}`,
+		`{"Broker": "tcp://localhost:1883", "Signals": [
+			{"Path": "Signal.Vehicle.Speed", "Topic": "vehicle", "Selector": "$..speed"}
+		]}`,
+		`{"Broker": "tcp://localhost:1883", "Signals": [
+			{"Path": "Signal.Vehicle.Speed", "Topic": "vehicle", "Selector": "$.speed[-1]"}
+		]}`,
+		`{"Broker": "tcp://localhost:1883", "Signals": [
+			{"Path": "Signal.Vehicle.Speed", "Topic": "vehicle", "PublishTopic": "vehicle/speed"}
+		]}`,
+	}
+
+	for _, item := range testItems {
+		if adapter, err := mqttadapter.New(json.RawMessage(item)); err == nil {
+			adapter.Close()
+			t.Errorf("Error expected for config: %s", item)
+		}
+	}
+}
+
+func TestMessages(t *testing.T) {
+	broker, err := newTestBroker("localhost:0")
+	if err != nil {
+		t.Fatalf("Can't create test broker: %s", err)
+	}
+	defer broker.close()
+
+	adapter, err := mqttadapter.New(json.RawMessage(`{"Broker": "tcp://` + broker.address + `", "Signals": [
+		{"Path": "Signal.Vehicle.Speed", "Topic": "vehicle/telemetry", "Selector": "$.speed", "Value": 0},
+		{"Path": "Signal.Drivetrain.Transmission.Gear", "Topic": "vehicle/telemetry",
+		 "Selector": "$.drivetrain['current gear']"},
+		{"Path": "Signal.Chassis.Axle.Row1.Wheel.Right.Tire.Pressure", "Topic": "vehicle/tires",
+		 "Selector": "$.tires[1].pressure"},
+		{"Path": "Signal.Cabin.Door.Row1.Left.Window.Position", "Topic": "vehicle/window"},
+		{"Path": "Signal.Body.Trunk.IsLocked", "PublishTopic": "vehicle/trunk/set", "Writable": true,
+		 "Value": false, "DataType": "boolean"}
+	]}`))
+	if err != nil {
+		t.Fatalf("Can't create adapter: %s", err)
+	}
+	defer adapter.Close()
+
+	if err = broker.waitSubscriptions("vehicle/telemetry", "vehicle/tires", "vehicle/window"); err != nil {
+		t.Fatalf("Subscriptions error: %s", err)
+	}
+
+	if err = adapter.Subscribe([]string{"Signal.Vehicle.Speed"}); err != nil {
+		t.Fatalf("Subscribe error: %s", err)
+	}
+
+	broker.publish("vehicle/telemetry", []byte(`{"speed": 42.5, "drivetrain": {"current gear": 3}}`))
+	broker.publish("vehicle/tires", []byte(`{"tires": [{"pressure": 230}, {"pressure": 235}]}`))
+	broker.publish("vehicle/window", []byte(`{"position": 10}`))
+
+	select {
+	case changes := <-adapter.GetSubscribeChannel():
+		data, _ := dataprovider.UnpackChanges(changes)
+
+		if !reflect.DeepEqual(data, map[string]interface{}{"Signal.Vehicle.Speed": 42.5}) {
+			t.Errorf("Wrong subscription data: %v", data)
+		}
+
+	case <-time.After(waitTimeout):
+		t.Fatal("Wait subscription data timeout")
+	}
+
+	expectedData := map[string]interface{}{
+		"Signal.Vehicle.Speed":                               42.5,
+		"Signal.Drivetrain.Transmission.Gear":                3.0,
+		"Signal.Chassis.Axle.Row1.Wheel.Right.Tire.Pressure": 235.0,
+		"Signal.Cabin.Door.Row1.Left.Window.Position":        map[string]interface{}{"position": 10.0},
+	}
+
+	if err = waitData(adapter, expectedData); err != nil {
+		t.Errorf("Wrong data: %s", err)
+	}
+
+	// Message without selected elements and invalid message should not change values
+	broker.publish("vehicle/telemetry", []byte(`{"odometer": 1000}`))
+	broker.publish("vehicle/telemetry", []byte(`not json`))
+	broker.publish("vehicle/telemetry", []byte(`{"speed": 50}`))
+
+	expectedData["Signal.Vehicle.Speed"] = 50.0
+
+	if err = waitData(adapter, expectedData); err != nil {
+		t.Errorf("Wrong data: %s", err)
+	}
+
+	metadata, err := adapter.GetMetadata([]string{"Signal.Vehicle.Speed", "Signal.Body.Trunk.IsLocked"})
+	if err != nil {
+		t.Fatalf("Get metadata error: %s", err)
+	}
+
+	if metadata["Signal.Vehicle.Speed"].Writable || !metadata["Signal.Body.Trunk.IsLocked"].Writable {
+		t.Error("Wrong writable metadata")
+	}
+
+	if err = adapter.SetData(map[string]interface{}{"Signal.Vehicle.Speed": 10.0}); err == nil {
+		t.Error("Error expected for read only signal")
+	}
+
+	if err = adapter.SetData(map[string]interface{}{"Signal.Body.Trunk.IsLocked": true}); err != nil {
+		t.Fatalf("Set data error: %s", err)
+	}
+
+	select {
+	case message := <-broker.published:
+		if message.topic != "vehicle/trunk/set" || string(message.payload) != "true" {
+			t.Errorf("Wrong published message: %s %s", message.topic, message.payload)
+		}
+
+	case <-time.After(waitTimeout):
+		t.Error("Wait published message timeout")
+	}
+
+	expectedData["Signal.Body.Trunk.IsLocked"] = true
+
+	if err = waitData(adapter, expectedData); err != nil {
+		t.Errorf("Wrong data: %s", err)
+	}
+}
+
+func TestPublishFailure(t *testing.T) {
+	broker, err := newTestBroker("localhost:0")
+	if err != nil {
+		t.Fatalf("Can't create test broker: %s", err)
+	}
+	defer broker.close()
+
+	adapter, err := mqttadapter.New(json.RawMessage(`{"Broker": "tcp://` + broker.address + `", "Signals": [
+		{"Path": "Signal.Body.Hood.IsOpen", "PublishTopic": "vehicle/hood/set", "Writable": true, "Value": false},
+		{"Path": "Signal.Body.Trunk.IsLocked", "PublishTopic": "vehicle/trunk/set", "Writable": true, "Value": false}
+	]}`))
+	if err != nil {
+		t.Fatalf("Can't create adapter: %s", err)
+	}
+	defer adapter.Close()
+
+	if err = broker.waitConnected(); err != nil {
+		t.Fatalf("Connect error: %s", err)
+	}
+
+	mqttadapter.FailPublish(adapter, "vehicle/trunk/set")
+
+	// Paths are published in sorted order, so hood value is published before trunk publish fails
+	if err = adapter.SetData(map[string]interface{}{
+		"Signal.Body.Hood.IsOpen": true, "Signal.Body.Trunk.IsLocked": true,
+	}); err == nil {
+		t.Error("Error expected for failed publish")
+	}
+
+	select {
+	case message := <-broker.published:
+		if message.topic != "vehicle/hood/set" || string(message.payload) != "true" {
+			t.Errorf("Wrong published message: %s %s", message.topic, message.payload)
+		}
+
+	case <-time.After(waitTimeout):
+		t.Error("Wait published message timeout")
+	}
+
+	if err = waitData(adapter, map[string]interface{}{
+		"Signal.Body.Hood.IsOpen": true, "Signal.Body.Trunk.IsLocked": false,
+	}); err != nil {
+		t.Errorf("Wrong data: %s", err)
+	}
+}
+
+func TestReconnect(t *testing.T) {
+	broker, err := newTestBroker("localhost:0")
+	if err != nil {
+		t.Fatalf("Can't create test broker: %s", err)
+	}
+
+	adapter, err := mqttadapter.New(json.RawMessage(`{"Broker": "tcp://` + broker.address + `",
+		"MaxReconnectInterval": 2, "Signals": [
+		{"Path": "Signal.Vehicle.Speed", "Topic": "vehicle/telemetry", "Selector": "$.speed"}
+	]}`))
+	if err != nil {
+		broker.close()
+		t.Fatalf("Can't create adapter: %s", err)
+	}
+	defer adapter.Close()
+
+	if err = broker.waitSubscriptions("vehicle/telemetry"); err != nil {
+		broker.close()
+		t.Fatalf("Subscriptions error: %s", err)
+	}
+
+	broker.close()
+
+	if broker, err = newTestBroker(broker.address); err != nil {
+		t.Fatalf("Can't restart test broker: %s", err)
+	}
+	defer broker.close()
+
+	// Adapter should reconnect and subscribe again
+	if err = broker.waitSubscriptions("vehicle/telemetry"); err != nil {
+		t.Fatalf("Subscriptions error: %s", err)
+	}
+
+	broker.publish("vehicle/telemetry", []byte(`{"speed": 80}`))
+
+	if err = waitData(adapter, map[string]interface{}{"Signal.Vehicle.Speed": 80.0}); err != nil {
+		t.Errorf("Wrong data: %s", err)
+	}
+}
+
+/*******************************************************************************
+ * Private
+ ******************************************************************************/
+
+func waitData(adapter dataprovider.DataAdapter, expectedData map[string]interface{}) (err error) {
+	pathList := make([]string, 0, len(expectedData))
+
+	for path := range expectedData {
+		pathList = append(pathList, path)
+	}
+
+	var data map[string]interface{}
+
+	for start := time.Now(); time.Since(start) < waitTimeout; time.Sleep(10 * time.Millisecond) {
+		if data, err = adapter.GetData(pathList); err != nil {
+			return aoserrors.Wrap(err)
+		}
+
+		if reflect.DeepEqual(data, expectedData) {
+			return nil
+		}
+	}
+
+	return aoserrors.Errorf("expected %v, got %v", expectedData, data)
+}
+
+func newTestBroker(address string) (broker *testBroker, err error) {
+	broker = &testBroker{
+		clients:   make(map[*brokerClient]struct{}),
+		published: make(chan publishMessage, 10),
+	}
+
+	if broker.listener, err = net.Listen("tcp", address); err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	broker.address = broker.listener.Addr().String()
+
+	broker.wg.Add(1)
+
+	go broker.accept()
+
+	return broker, nil
+}
+
+func (broker *testBroker) close() {
+	broker.listener.Close()
+
+	broker.Lock()
+
+	for client := range broker.clients {
+		client.conn.Close()
+	}
+
+	broker.Unlock()
+
+	broker.wg.Wait()
+}
+
+func (broker *testBroker) accept() {
+	defer broker.wg.Done()
+
+	for {
+		conn, err := broker.listener.Accept()
+		if err != nil {
+			return
+		}
+
+		client := &brokerClient{conn: conn}
+
+		broker.Lock()
+		broker.clients[client] = struct{}{}
+		broker.Unlock()
+
+		broker.wg.Add(1)
+
+		go broker.serve(client)
+	}
+}
+
+func (broker *testBroker) serve(client *brokerClient) {
+	defer broker.wg.Done()
+
+	defer func() {
+		client.conn.Close()
+
+		broker.Lock()
+		delete(broker.clients, client)
+		broker.Unlock()
+	}()
+
+	reader := bufio.NewReader(client.conn)
+
+	for {
+		header, body, err := readPacket(reader)
+		if err != nil {
+			return
+		}
+
+		if err = broker.handlePacket(client, header, body); err != nil {
+			return
+		}
+	}
+}
+
+func (broker *testBroker) handlePacket(client *brokerClient, header byte, body []byte) (err error) {
+	switch header >> 4 {
+	case packetConnect:
+		return client.write(0x20, []byte{0, 0})
+
+	case packetSubscribe:
+		granted := []byte{body[0], body[1]}
+
+		for rest := body[2:]; len(rest) > 2; {
+			length := int(binary.BigEndian.Uint16(rest))
+
+			broker.Lock()
+			client.filters = append(client.filters, string(rest[2:2+length]))
+			broker.Unlock()
+
+			granted = append(granted, 0)
+			rest = rest[3+length:]
+		}
+
+		return client.write(0x90, granted)
+
+	case packetUnsubscribe:
+		return client.write(0xb0, body[:2])
+
+	case packetPublish:
+		length := int(binary.BigEndian.Uint16(body))
+		topic := string(body[2 : 2+length])
+		payload := body[2+length:]
+
+		if qos := header >> 1 & 0x03; qos > 0 {
+			payload = payload[2:]
+
+			if err = client.write(0x40, body[2+length:4+length]); err != nil {
+				return err
+			}
+		}
+
+		broker.published <- publishMessage{topic: topic, payload: payload}
+
+		broker.publish(topic, payload)
+
+		return nil
+
+	case packetPingReq:
+		return client.write(0xd0, nil)
+
+	case packetDisconnect:
+		return io.EOF
+
+	default:
+		return aoserrors.Errorf("unsupported packet type %d", header>>4)
+	}
+}
+
+// publish sends QoS 0 message to clients subscribed to the topic.
+func (broker *testBroker) publish(topic string, payload []byte) {
+	body := binary.BigEndian.AppendUint16(nil, uint16(len(topic)))
+	body = append(append(body, topic...), payload...)
+
+	broker.Lock()
+	defer broker.Unlock()
+
+	for client := range broker.clients {
+		for _, filter := range client.filters {
+			if matchTopic(filter, topic) {
+				if err := client.write(0x30, body); err != nil {
+					log.Errorf("Can't publish message: %s", err)
+				}
+
+				break
+			}
+		}
+	}
+}
+
+func (broker *testBroker) waitSubscriptions(topics ...string) (err error) {
+	for start := time.Now(); time.Since(start) < waitTimeout; time.Sleep(10 * time.Millisecond) {
+		if broker.isSubscribed(topics) {
+			return nil
+		}
+	}
+
+	return aoserrors.Errorf("topics %v are not subscribed", topics)
+}
+
+func (broker *testBroker) waitConnected() (err error) {
+	for start := time.Now(); time.Since(start) < waitTimeout; time.Sleep(10 * time.Millisecond) {
+		broker.Lock()
+		connected := len(broker.clients) > 0
+		broker.Unlock()
+
+		if connected {
+			return nil
+		}
+	}
+
+	return aoserrors.New("client is not connected")
+}
+
+func (broker *testBroker) isSubscribed(topics []string) (result bool) {
+	broker.Lock()
+	defer broker.Unlock()
+
+	subscribed := make(map[string]bool)
+
+	for client := range broker.clients {
+		for _, filter := range client.filters {
+			subscribed[filter] = true
+		}
+	}
+
+	for _, topic := range topics {
+		if !subscribed[topic] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (client *brokerClient) write(header byte, body []byte) (err error) {
+	client.Lock()
+	defer client.Unlock()
+
+	packet := []byte{header}
+
+	// Remaining length is encoded by 7 bits per byte
+	for length := len(body); ; {
+		encoded := byte(length & 0x7f)
+
+		if length >>= 7; length > 0 {
+			encoded |= 0x80
+		}
+
+		packet = append(packet, encoded)
+
+		if length == 0 {
+			break
+		}
+	}
+
+	_, err = client.conn.Write(append(packet, body...))
+
+	return aoserrors.Wrap(err)
+}
+
+func readPacket(reader *bufio.Reader) (header byte, body []byte, err error) {
+	if header, err = reader.ReadByte(); err != nil {
+		return 0, nil, aoserrors.Wrap(err)
+	}
+
+	length := 0
+
+	for shift := 0; ; shift += 7 {
+		encoded, err := reader.ReadByte()
+		if err != nil {
+			return 0, nil, aoserrors.Wrap(err)
+		}
+
+		length |= int(encoded&0x7f) << shift
+
+		if encoded&0x80 == 0 {
+			break
+		}
+	}
+
+	body = make([]byte, length)
+
+	if _, err = io.ReadFull(reader, body); err != nil {
+		return 0, nil, aoserrors.Wrap(err)
+	}
+
+	return header, body, nil
+}
+
+func matchTopic(filter, topic string) (result bool) {
+	filterLevels := strings.Split(filter, "/")
+	topicLevels := strings.Split(topic, "/")
+
+	for i, level := range filterLevels {
+		if level == "#" {
+			return true
+		}
+
+		if i >= len(topicLevels) || (level != "+" && level != topicLevels[i]) {
+			return false
+		}
+	}
+
+	return len(filterLevels) == len(topicLevels)
+}
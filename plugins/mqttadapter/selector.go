@@ -0,0 +1,143 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2021 Renesas Electronics Corporation.
+// Copyright (C) 2021 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mqttadapter
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/aosedge/aos_common/aoserrors"
+)
+
+/*******************************************************************************
+ * Consts
+ ******************************************************************************/
+
+const selectorRoot = "$"
+
+/*******************************************************************************
+ * Types
+ ******************************************************************************/
+
+// selector JSONPath-like selector of payload element: $.object.field, $.array[1], $['key with spaces'].
+type selector []selectorToken
+
+// selectorToken contains object key or array index if key is not set.
+type selectorToken struct {
+	key   string
+	index int
+	isKey bool
+}
+
+/*******************************************************************************
+ * Private
+ ******************************************************************************/
+
+func parseSelector(source string) (result selector, err error) {
+	if source == "" {
+		return nil, nil
+	}
+
+	if !strings.HasPrefix(source, selectorRoot) {
+		return nil, aoserrors.Errorf("selector %s should start with %s", source, selectorRoot)
+	}
+
+	for rest := source[len(selectorRoot):]; rest != ""; {
+		var token selectorToken
+
+		switch rest[0] {
+		case '.':
+			end := strings.IndexAny(rest[1:], ".[") + 1
+			if end == 0 {
+				end = len(rest)
+			}
+
+			token = selectorToken{key: rest[1:end], isKey: true}
+			rest = rest[end:]
+
+		case '[':
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return nil, aoserrors.Errorf("selector %s has unclosed bracket", source)
+			}
+
+			if token, err = parseBracketToken(rest[1:end]); err != nil {
+				return nil, aoserrors.Errorf("selector %s: %v", source, err)
+			}
+
+			rest = rest[end+1:]
+
+		default:
+			return nil, aoserrors.Errorf("selector %s has unexpected character %q", source, rest[0])
+		}
+
+		if token.isKey && token.key == "" {
+			return nil, aoserrors.Errorf("selector %s has empty key", source)
+		}
+
+		result = append(result, token)
+	}
+
+	return result, nil
+}
+
+func parseBracketToken(content string) (token selectorToken, err error) {
+	if len(content) >= 2 && (content[0] == '\'' || content[0] == '"') && content[len(content)-1] == content[0] {
+		return selectorToken{key: content[1 : len(content)-1], isKey: true}, nil
+	}
+
+	index, err := strconv.Atoi(content)
+	if err != nil || index < 0 {
+		return token, aoserrors.Errorf("invalid index %s", content)
+	}
+
+	return selectorToken{index: index}, nil
+}
+
+// apply returns payload element pointed by selector.
+func (selector selector) apply(payload interface{}) (value interface{}, err error) {
+	value = payload
+
+	for _, token := range selector {
+		if token.isKey {
+			object, ok := value.(map[string]interface{})
+			if !ok {
+				return nil, aoserrors.Errorf("element %s is not an object field", token.key)
+			}
+
+			if value, ok = object[token.key]; !ok {
+				return nil, aoserrors.Errorf("field %s not found", token.key)
+			}
+
+			continue
+		}
+
+		array, ok := value.([]interface{})
+		if !ok {
+			return nil, aoserrors.Errorf("element %d is not an array item", token.index)
+		}
+
+		if token.index >= len(array) {
+			return nil, aoserrors.Errorf("index %d is out of range", token.index)
+		}
+
+		value = array[token.index]
+	}
+
+	return value, nil
+}
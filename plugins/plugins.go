@@ -19,7 +19,11 @@ package plugins
 
 import (
 	// include all supported plugins.
+	_ "github.com/aosedge/aos_vis/plugins/canadapter"
+	_ "github.com/aosedge/aos_vis/plugins/fileadapter"
+	_ "github.com/aosedge/aos_vis/plugins/mqttadapter"
 	_ "github.com/aosedge/aos_vis/plugins/renesassimulatoradapter"
+	_ "github.com/aosedge/aos_vis/plugins/replayadapter"
 	_ "github.com/aosedge/aos_vis/plugins/storageadapter"
 	_ "github.com/aosedge/aos_vis/plugins/subjectsadapter"
 	_ "github.com/aosedge/aos_vis/plugins/telemetryemulatoradapter"
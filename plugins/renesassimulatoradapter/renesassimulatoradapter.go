@@ -152,6 +152,24 @@ func (adapter *RenesasSimulatorAdapter) IsPathPublic(path string) (result bool,
 	return true, nil
 }
 
+// GetMetadata returns metadata by path.
+func (adapter *RenesasSimulatorAdapter) GetMetadata(pathList []string) (
+	metadata map[string]*dataprovider.SignalMetadata, err error,
+) {
+	metadata, err = adapter.baseAdapter.GetMetadata(pathList)
+	if err != nil {
+		return metadata, aoserrors.Wrap(err)
+	}
+
+	// Simulator signals are public and can't be set
+	for _, signalMetadata := range metadata {
+		signalMetadata.Writable = false
+		signalMetadata.Public = true
+	}
+
+	return metadata, nil
+}
+
 // GetData returns data by path.
 func (adapter *RenesasSimulatorAdapter) GetData(pathList []string) (data map[string]interface{}, err error) {
 	data, err = adapter.baseAdapter.GetData(pathList)
@@ -162,6 +180,16 @@ func (adapter *RenesasSimulatorAdapter) GetData(pathList []string) (data map[str
 	return data, nil
 }
 
+// GetTimestamps returns time when data was sampled.
+func (adapter *RenesasSimulatorAdapter) GetTimestamps(pathList []string) (timestamps map[string]time.Time, err error) {
+	timestamps, err = adapter.baseAdapter.GetTimestamps(pathList)
+	if err != nil {
+		return timestamps, aoserrors.Wrap(err)
+	}
+
+	return timestamps, nil
+}
+
 // SetData sets data by pathes.
 func (adapter *RenesasSimulatorAdapter) SetData(data map[string]interface{}) (err error) {
 	return aoserrors.New("operation is not supported")
@@ -27,6 +27,7 @@ import (
 	log "github.com/sirupsen/logrus"
 
 	"github.com/aosedge/aos_vis/dataadaptertest"
+	"github.com/aosedge/aos_vis/dataprovider"
 	"github.com/aosedge/aos_vis/plugins/renesassimulatoradapter"
 )
 
@@ -168,6 +169,12 @@ func TestPublicPath(t *testing.T) {
 	}
 }
 
+func TestGetMetadata(t *testing.T) {
+	if err := dataadaptertest.GetMetadata(&adapterInfo); err != nil {
+		t.Errorf("Test get metadata error: %s", err)
+	}
+}
+
 func TestGetData(t *testing.T) {
 	message := messageType{Cmd: "data"}
 	message.Arg.Geometry.Coordinates.Latitude = 75.34455
@@ -266,7 +273,9 @@ func TestSubscribeUnsubscribe(t *testing.T) {
 	}
 
 	select {
-	case data := <-adapterInfo.Adapter.GetSubscribeChannel():
+	case changes := <-adapterInfo.Adapter.GetSubscribeChannel():
+		data, _ := dataprovider.UnpackChanges(changes)
+
 		for path, value := range data {
 			// Workaround for inverse longitude of Renesas simulator
 			if path == "Signal.Cabin.Infotainment.Navigation.CurrentLocation.Longitude" {
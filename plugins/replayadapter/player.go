@@ -0,0 +1,212 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2021 Renesas Electronics Corporation.
+// Copyright (C) 2021 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package replayadapter
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+/*******************************************************************************
+ * Types
+ ******************************************************************************/
+
+// player plays records according to log clock: log offset = base offset + (now - base time) * speed.
+type player struct {
+	sync.Mutex
+	records       []record
+	index         int
+	speed         float64
+	loop          bool
+	paused        bool
+	baseOffset    time.Duration
+	baseTime      time.Time
+	updateChannel chan struct{}
+	stopChannel   chan struct{}
+	wg            sync.WaitGroup
+	play          func(data map[string]interface{}, offset time.Duration)
+}
+
+/*******************************************************************************
+ * Private
+ ******************************************************************************/
+
+func newPlayer(
+	records []record, speed float64, loop, paused bool, play func(data map[string]interface{}, offset time.Duration),
+) (replayPlayer *player) {
+	replayPlayer = &player{
+		records: records, speed: speed, loop: loop, paused: paused, baseTime: time.Now(),
+		updateChannel: make(chan struct{}, 1), stopChannel: make(chan struct{}), play: play,
+	}
+
+	replayPlayer.wg.Add(1)
+
+	go replayPlayer.run()
+
+	return replayPlayer
+}
+
+func (replayPlayer *player) close() {
+	close(replayPlayer.stopChannel)
+
+	replayPlayer.wg.Wait()
+}
+
+func (replayPlayer *player) duration() (duration time.Duration) {
+	return replayPlayer.records[len(replayPlayer.records)-1].offset
+}
+
+func (replayPlayer *player) setSpeed(speed float64) {
+	replayPlayer.update(func() {
+		replayPlayer.rebase()
+		replayPlayer.speed = speed
+	})
+}
+
+func (replayPlayer *player) setLoop(loop bool) {
+	replayPlayer.update(func() {
+		replayPlayer.loop = loop
+	})
+}
+
+func (replayPlayer *player) setPaused(paused bool) {
+	replayPlayer.update(func() {
+		replayPlayer.rebase()
+		replayPlayer.paused = paused
+	})
+}
+
+// seek moves playback to log offset, records before it are skipped.
+func (replayPlayer *player) seek(offset time.Duration) {
+	replayPlayer.update(func() {
+		replayPlayer.baseOffset = offset
+		replayPlayer.baseTime = time.Now()
+		replayPlayer.index = sort.Search(len(replayPlayer.records), func(i int) bool {
+			return replayPlayer.records[i].offset >= offset
+		})
+	})
+}
+
+func (replayPlayer *player) update(change func()) {
+	replayPlayer.Lock()
+	change()
+	replayPlayer.Unlock()
+
+	select {
+	case replayPlayer.updateChannel <- struct{}{}:
+
+	default:
+	}
+}
+
+// currentOffset returns log offset of playback clock.
+func (replayPlayer *player) currentOffset() (offset time.Duration) {
+	if replayPlayer.paused {
+		return replayPlayer.baseOffset
+	}
+
+	return replayPlayer.baseOffset + time.Duration(float64(time.Since(replayPlayer.baseTime))*replayPlayer.speed)
+}
+
+// rebase fixes current log offset as base one before clock parameters change.
+func (replayPlayer *player) rebase() {
+	replayPlayer.baseOffset = replayPlayer.currentOffset()
+	replayPlayer.baseTime = time.Now()
+}
+
+func (replayPlayer *player) run() {
+	defer replayPlayer.wg.Done()
+
+	for {
+		wait, pending := replayPlayer.next()
+
+		if len(pending) != 0 {
+			for _, rec := range pending {
+				replayPlayer.play(rec.data, rec.offset)
+			}
+
+			continue
+		}
+
+		if !replayPlayer.wait(wait) {
+			return
+		}
+	}
+}
+
+// wait waits for timeout or update and returns false if player is closed. Negative timeout is infinite.
+func (replayPlayer *player) wait(timeout time.Duration) (result bool) {
+	var timerChannel <-chan time.Time
+
+	if timeout >= 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+
+		timerChannel = timer.C
+	}
+
+	select {
+	case <-timerChannel:
+		return true
+
+	case <-replayPlayer.updateChannel:
+		return true
+
+	case <-replayPlayer.stopChannel:
+		return false
+	}
+}
+
+// next returns records due to be played or time to wait for the next one. Negative wait means waiting for update.
+func (replayPlayer *player) next() (wait time.Duration, pending []record) {
+	replayPlayer.Lock()
+	defer replayPlayer.Unlock()
+
+	if replayPlayer.index >= len(replayPlayer.records) {
+		// Log without duration is not looped to not replay it continuously
+		if !replayPlayer.loop || replayPlayer.paused || replayPlayer.duration() == 0 {
+			return -1, nil
+		}
+
+		replayPlayer.index = 0
+		replayPlayer.baseOffset = 0
+		replayPlayer.baseTime = time.Now()
+	}
+
+	if replayPlayer.paused {
+		return -1, nil
+	}
+
+	current := replayPlayer.currentOffset()
+
+	for ; replayPlayer.index < len(replayPlayer.records); replayPlayer.index++ {
+		rec := replayPlayer.records[replayPlayer.index]
+		if rec.offset > current {
+			break
+		}
+
+		pending = append(pending, rec)
+	}
+
+	if len(pending) != 0 {
+		return 0, pending
+	}
+
+	return time.Duration(float64(replayPlayer.records[replayPlayer.index].offset-current) / replayPlayer.speed), nil
+}
@@ -0,0 +1,354 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2021 Renesas Electronics Corporation.
+// Copyright (C) 2021 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package replayadapter
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aosedge/aos_common/aoserrors"
+
+	"github.com/aosedge/aos_vis/plugins/canadapter/dbc"
+)
+
+/*******************************************************************************
+ * Consts
+ ******************************************************************************/
+
+const (
+	formatCSV     = "csv"
+	formatJSONL   = "jsonl"
+	formatCANDump = "candump"
+)
+
+const (
+	csvFieldCount = 3
+	// candump prints standard IDs with 3 hex digits and extended IDs with 8 hex digits
+	standardIDLength = 3
+	nanosecondDigits = 9
+)
+
+/*******************************************************************************
+ * Types
+ ******************************************************************************/
+
+// record sample of recorded log.
+type record struct {
+	offset time.Duration
+	data   map[string]interface{}
+}
+
+type jsonRecord struct {
+	Timestamp json.RawMessage `json:"timestamp"`
+	Path      string          `json:"path"`
+	Value     interface{}     `json:"value"`
+}
+
+// canMapping maps DBC signal to VIS path: VIS value = DBC physical value * scale + offset.
+type canMapping struct {
+	path   string
+	signal string
+	scale  float64
+	offset float64
+}
+
+/*******************************************************************************
+ * Vars
+ ******************************************************************************/
+
+//nolint:gochecknoglobals // constant regexp
+var canDumpRegexp = regexp.MustCompile(`^\(\s*(\d+(?:\.\d+)?)\)\s+\S+\s+([0-9A-Fa-f]+)#([0-9A-Fa-f]*)$`)
+
+/*******************************************************************************
+ * Private
+ ******************************************************************************/
+
+// detectFormat returns log format by file extension.
+func detectFormat(fileName string) (format string) {
+	switch {
+	case strings.HasSuffix(fileName, ".csv"):
+		return formatCSV
+
+	case strings.HasSuffix(fileName, ".jsonl"), strings.HasSuffix(fileName, ".json"):
+		return formatJSONL
+
+	case strings.HasSuffix(fileName, ".log"):
+		return formatCANDump
+
+	default:
+		return ""
+	}
+}
+
+// readRecords reads log file and returns records ordered by time with offsets relative to the first one.
+func readRecords(
+	fileName, format string, database *dbc.Database, mappings map[string][]*canMapping,
+) (records []record, err error) {
+	file, err := os.Open(fileName)
+	if err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+	defer file.Close()
+
+	var timestamps []time.Time
+
+	switch format {
+	case formatCSV:
+		timestamps, records, err = readCSV(file)
+
+	case formatJSONL:
+		timestamps, records, err = readJSONL(file)
+
+	case formatCANDump:
+		timestamps, records, err = readCANDump(file, database, mappings)
+
+	default:
+		return nil, aoserrors.Errorf("unsupported log format %s", format)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(records) == 0 {
+		return nil, aoserrors.Errorf("log %s has no records", fileName)
+	}
+
+	start := timestamps[0]
+
+	for _, timestamp := range timestamps {
+		if timestamp.Before(start) {
+			start = timestamp
+		}
+	}
+
+	for i := range records {
+		records[i].offset = timestamps[i].Sub(start)
+	}
+
+	sort.SliceStable(records, func(i, j int) bool { return records[i].offset < records[j].offset })
+
+	return records, nil
+}
+
+func readCSV(reader io.Reader) (timestamps []time.Time, records []record, err error) {
+	csvReader := csv.NewReader(reader)
+
+	csvReader.FieldsPerRecord = csvFieldCount
+	csvReader.TrimLeadingSpace = true
+	csvReader.Comment = '#'
+
+	for lineNumber := 1; ; lineNumber++ {
+		fields, err := csvReader.Read()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return timestamps, records, nil
+			}
+
+			return nil, nil, aoserrors.Wrap(err)
+		}
+
+		// Optional header
+		if lineNumber == 1 && fields[0] == "timestamp" {
+			continue
+		}
+
+		timestamp, err := parseTimestamp(fields[0])
+		if err != nil {
+			return nil, nil, aoserrors.Errorf("line %d: %v", lineNumber, err)
+		}
+
+		timestamps = append(timestamps, timestamp)
+		records = append(records, record{data: map[string]interface{}{fields[1]: parseCSVValue(fields[2])}})
+	}
+}
+
+// parseCSVValue returns JSON value if field contains it, otherwise field is used as string.
+func parseCSVValue(field string) (value interface{}) {
+	if err := json.Unmarshal([]byte(field), &value); err != nil {
+		return field
+	}
+
+	return value
+}
+
+func readJSONL(reader io.Reader) (timestamps []time.Time, records []record, err error) {
+	scanner := bufio.NewScanner(reader)
+
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		var jsonRec jsonRecord
+
+		if err = json.Unmarshal([]byte(line), &jsonRec); err != nil {
+			return nil, nil, aoserrors.Errorf("line %d: %v", lineNumber, err)
+		}
+
+		if jsonRec.Path == "" {
+			return nil, nil, aoserrors.Errorf("line %d: path is not set", lineNumber)
+		}
+
+		// Timestamp is either number of seconds or RFC 3339 string
+		var timestampStr string
+
+		if err = json.Unmarshal(jsonRec.Timestamp, &timestampStr); err != nil {
+			timestampStr = string(jsonRec.Timestamp)
+		}
+
+		timestamp, err := parseTimestamp(timestampStr)
+		if err != nil {
+			return nil, nil, aoserrors.Errorf("line %d: %v", lineNumber, err)
+		}
+
+		timestamps = append(timestamps, timestamp)
+		records = append(records, record{data: map[string]interface{}{jsonRec.Path: jsonRec.Value}})
+	}
+
+	if err = scanner.Err(); err != nil {
+		return nil, nil, aoserrors.Wrap(err)
+	}
+
+	return timestamps, records, nil
+}
+
+// readCANDump reads candump -L log, frames without mapped signals are skipped.
+func readCANDump(
+	reader io.Reader, database *dbc.Database, mappings map[string][]*canMapping,
+) (timestamps []time.Time, records []record, err error) {
+	scanner := bufio.NewScanner(reader)
+
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		fields := canDumpRegexp.FindStringSubmatch(line)
+		if fields == nil {
+			return nil, nil, aoserrors.Errorf("line %d: invalid candump record", lineNumber)
+		}
+
+		data, err := decodeCANFrame(fields[2], fields[3], database, mappings)
+		if err != nil {
+			return nil, nil, aoserrors.Errorf("line %d: %v", lineNumber, err)
+		}
+
+		if len(data) == 0 {
+			continue
+		}
+
+		timestamp, err := parseTimestamp(fields[1])
+		if err != nil {
+			return nil, nil, aoserrors.Errorf("line %d: %v", lineNumber, err)
+		}
+
+		timestamps = append(timestamps, timestamp)
+		records = append(records, record{data: data})
+	}
+
+	if err = scanner.Err(); err != nil {
+		return nil, nil, aoserrors.Wrap(err)
+	}
+
+	return timestamps, records, nil
+}
+
+func decodeCANFrame(
+	idField, dataField string, database *dbc.Database, mappings map[string][]*canMapping,
+) (data map[string]interface{}, err error) {
+	id, err := strconv.ParseUint(idField, 16, 32)
+	if err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	message, ok := database.Messages[uint32(id)]
+	if !ok || message.Extended != (len(idField) > standardIDLength) || len(mappings[message.Name]) == 0 {
+		return nil, nil
+	}
+
+	frame, err := hex.DecodeString(dataField)
+	if err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	values, err := message.Decode(frame)
+	if err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	data = make(map[string]interface{})
+
+	for _, mapping := range mappings[message.Name] {
+		if value, ok := values[mapping.signal]; ok {
+			data[mapping.path] = value*mapping.scale + mapping.offset
+		}
+	}
+
+	return data, nil
+}
+
+// parseTimestamp parses timestamp in seconds since epoch or in RFC 3339 format.
+func parseTimestamp(value string) (timestamp time.Time, err error) {
+	if timestamp, ok := parseSeconds(value); ok {
+		return timestamp, nil
+	}
+
+	if timestamp, err = time.Parse(time.RFC3339Nano, value); err != nil {
+		return timestamp, aoserrors.Errorf("invalid timestamp %s", value)
+	}
+
+	return timestamp, nil
+}
+
+// parseSeconds parses decimal seconds exactly as float conversion loses precision of epoch time fraction.
+func parseSeconds(value string) (timestamp time.Time, ok bool) {
+	integerPart, fractionPart, _ := strings.Cut(value, ".")
+
+	seconds, err := strconv.ParseInt(integerPart, 10, 64)
+	if err != nil {
+		return timestamp, false
+	}
+
+	if len(fractionPart) > nanosecondDigits {
+		fractionPart = fractionPart[:nanosecondDigits]
+	}
+
+	var nanoseconds int64
+
+	if fractionPart != "" {
+		if nanoseconds, err = strconv.ParseInt(
+			fractionPart+strings.Repeat("0", nanosecondDigits-len(fractionPart)), 10, 64); err != nil {
+			return timestamp, false
+		}
+	}
+
+	return time.Unix(seconds, nanoseconds), true
+}
@@ -0,0 +1,395 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2021 Renesas Electronics Corporation.
+// Copyright (C) 2021 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package replayadapter
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/aosedge/aos_common/aoserrors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/aosedge/aos_vis/dataprovider"
+	"github.com/aosedge/aos_vis/plugins/canadapter/dbc"
+)
+
+/*******************************************************************************
+ * Consts
+ ******************************************************************************/
+
+// Playback control paths.
+const (
+	controlSpeed    = "Attribute.Replay.Speed"
+	controlLoop     = "Attribute.Replay.Loop"
+	controlPaused   = "Attribute.Replay.Paused"
+	controlPosition = "Attribute.Replay.Position"
+	controlDuration = "Attribute.Replay.Duration"
+)
+
+const defaultCANDataType = "double"
+
+/*******************************************************************************
+ * Types
+ ******************************************************************************/
+
+// ReplayAdapter recorded log replay adapter.
+type ReplayAdapter struct {
+	baseAdapter *dataprovider.BaseAdapter
+	player      *player
+}
+
+type adapterConfig struct {
+	File    string            `json:"file"`
+	Format  string            `json:"format"`
+	DBCFile string            `json:"dbcFile"`
+	Signals []canSignalConfig `json:"signals"`
+	Speed   float64           `json:"speed"`
+	Loop    bool              `json:"loop"`
+	Paused  bool              `json:"paused"`
+	Public  bool              `json:"public"`
+}
+
+type canSignalConfig struct {
+	Path    string   `json:"path"`
+	Message string   `json:"message"`
+	Signal  string   `json:"signal"`
+	Scale   *float64 `json:"scale"`
+	Offset  float64  `json:"offset"`
+}
+
+/*******************************************************************************
+ * Public
+ ******************************************************************************/
+
+// New creates adapter instance.
+func New(configJSON json.RawMessage) (adapter dataprovider.DataAdapter, err error) {
+	log.Info("Create replay adapter")
+
+	if configJSON == nil {
+		return nil, aoserrors.New("config should be set")
+	}
+
+	cfg := adapterConfig{Speed: 1}
+
+	if err = json.Unmarshal(configJSON, &cfg); err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	if cfg.File == "" {
+		return nil, aoserrors.New("log file should be set")
+	}
+
+	if cfg.Speed <= 0 {
+		return nil, aoserrors.Errorf("invalid speed %v", cfg.Speed)
+	}
+
+	if cfg.Format == "" {
+		cfg.Format = detectFormat(cfg.File)
+	}
+
+	localAdapter := &ReplayAdapter{}
+
+	if localAdapter.baseAdapter, err = dataprovider.NewBaseAdapter(); err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	localAdapter.baseAdapter.Name = "ReplayAdapter"
+
+	records, err := localAdapter.loadRecords(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	localAdapter.addControls(cfg, records[len(records)-1].offset)
+
+	log.WithFields(log.Fields{"file": cfg.File, "records": len(records)}).Debug("Replay log loaded")
+
+	localAdapter.player = newPlayer(records, cfg.Speed, cfg.Loop, cfg.Paused, localAdapter.play)
+
+	return localAdapter, nil
+}
+
+// Close closes adapter.
+func (adapter *ReplayAdapter) Close() {
+	log.Info("Close replay adapter")
+
+	adapter.player.close()
+
+	adapter.baseAdapter.Close()
+}
+
+// GetName returns adapter name.
+func (adapter *ReplayAdapter) GetName() (name string) {
+	return adapter.baseAdapter.GetName()
+}
+
+// GetPathList returns list of all pathes for this adapter.
+func (adapter *ReplayAdapter) GetPathList() (pathList []string, err error) {
+	pathList, err = adapter.baseAdapter.GetPathList()
+	if err != nil {
+		return pathList, aoserrors.Wrap(err)
+	}
+
+	return pathList, nil
+}
+
+// IsPathPublic returns true if requested data accessible without authorization.
+func (adapter *ReplayAdapter) IsPathPublic(path string) (result bool, err error) {
+	result, err = adapter.baseAdapter.IsPathPublic(path)
+	if err != nil {
+		return result, aoserrors.Wrap(err)
+	}
+
+	return result, nil
+}
+
+// GetMetadata returns metadata by path.
+func (adapter *ReplayAdapter) GetMetadata(pathList []string) (
+	metadata map[string]*dataprovider.SignalMetadata, err error,
+) {
+	metadata, err = adapter.baseAdapter.GetMetadata(pathList)
+	if err != nil {
+		return metadata, aoserrors.Wrap(err)
+	}
+
+	return metadata, nil
+}
+
+// GetData returns data by path.
+func (adapter *ReplayAdapter) GetData(pathList []string) (data map[string]interface{}, err error) {
+	data, err = adapter.baseAdapter.GetData(pathList)
+	if err != nil {
+		return data, aoserrors.Wrap(err)
+	}
+
+	return data, nil
+}
+
+// GetTimestamps returns time when data was sampled.
+func (adapter *ReplayAdapter) GetTimestamps(pathList []string) (timestamps map[string]time.Time, err error) {
+	timestamps, err = adapter.baseAdapter.GetTimestamps(pathList)
+	if err != nil {
+		return timestamps, aoserrors.Wrap(err)
+	}
+
+	return timestamps, nil
+}
+
+// SetData sets playback controls.
+func (adapter *ReplayAdapter) SetData(data map[string]interface{}) (err error) {
+	if err = adapter.baseAdapter.CheckSetData(data); err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	controls := make(map[string]interface{})
+
+	for path, value := range data {
+		if controls[path], err = adapter.validateControl(path, value); err != nil {
+			return err
+		}
+	}
+
+	for path, value := range controls {
+		switch path {
+		case controlSpeed:
+			adapter.player.setSpeed(value.(float64)) //nolint:forcetypeassert // validated
+
+		case controlLoop:
+			adapter.player.setLoop(value.(bool)) //nolint:forcetypeassert // validated
+
+		case controlPaused:
+			adapter.player.setPaused(value.(bool)) //nolint:forcetypeassert // validated
+
+		case controlPosition:
+			adapter.player.seek(secondsToDuration(value.(float64))) //nolint:forcetypeassert // validated
+		}
+	}
+
+	return aoserrors.Wrap(adapter.baseAdapter.SetData(controls))
+}
+
+// GetSubscribeChannel returns channel on which data changes will be sent.
+func (adapter *ReplayAdapter) GetSubscribeChannel() (channel <-chan map[string]interface{}) {
+	return adapter.baseAdapter.SubscribeChannel
+}
+
+// Subscribe subscribes for data changes.
+func (adapter *ReplayAdapter) Subscribe(pathList []string) (err error) {
+	return aoserrors.Wrap(adapter.baseAdapter.Subscribe(pathList))
+}
+
+// Unsubscribe unsubscribes from data changes.
+func (adapter *ReplayAdapter) Unsubscribe(pathList []string) (err error) {
+	return aoserrors.Wrap(adapter.baseAdapter.Unsubscribe(pathList))
+}
+
+// UnsubscribeAll unsubscribes from all data changes.
+func (adapter *ReplayAdapter) UnsubscribeAll() (err error) {
+	return aoserrors.Wrap(adapter.baseAdapter.UnsubscribeAll())
+}
+
+/*******************************************************************************
+ * Private
+ ******************************************************************************/
+
+func (adapter *ReplayAdapter) loadRecords(cfg adapterConfig) (records []record, err error) {
+	var (
+		database *dbc.Database
+		mappings map[string][]*canMapping
+	)
+
+	if cfg.Format == formatCANDump {
+		if database, mappings, err = adapter.createCANMappings(cfg); err != nil {
+			return nil, err
+		}
+	}
+
+	if records, err = readRecords(cfg.File, cfg.Format, database, mappings); err != nil {
+		return nil, err
+	}
+
+	for _, rec := range records {
+		for path := range rec.data {
+			if _, ok := adapter.baseAdapter.Data[path]; ok {
+				continue
+			}
+
+			if isControl(path) {
+				return nil, aoserrors.Errorf("log path %s conflicts with replay controls", path)
+			}
+
+			adapter.baseAdapter.Data[path] = &dataprovider.BaseData{
+				Public: cfg.Public, ReadOnly: true, Type: dataprovider.NodeTypeSensor,
+			}
+		}
+	}
+
+	return records, nil
+}
+
+func (adapter *ReplayAdapter) createCANMappings(
+	cfg adapterConfig,
+) (database *dbc.Database, mappings map[string][]*canMapping, err error) {
+	if cfg.DBCFile == "" {
+		return nil, nil, aoserrors.New("DBC file should be set for candump log")
+	}
+
+	if database, err = dbc.ParseFile(cfg.DBCFile); err != nil {
+		return nil, nil, aoserrors.Wrap(err)
+	}
+
+	mappings = make(map[string][]*canMapping)
+
+	for _, signalCfg := range cfg.Signals {
+		if signalCfg.Path == "" {
+			return nil, nil, aoserrors.Errorf("path of signal %s is not set", signalCfg.Signal)
+		}
+
+		message, err := database.GetMessage(signalCfg.Message)
+		if err != nil {
+			return nil, nil, aoserrors.Wrap(err)
+		}
+
+		signal, ok := message.Signals[signalCfg.Signal]
+		if !ok {
+			return nil, nil, aoserrors.Errorf("signal %s not found in message %s", signalCfg.Signal, signalCfg.Message)
+		}
+
+		mapping := &canMapping{path: signalCfg.Path, signal: signal.Name, scale: 1, offset: signalCfg.Offset}
+
+		if signalCfg.Scale != nil {
+			mapping.scale = *signalCfg.Scale
+		}
+
+		mappings[message.Name] = append(mappings[message.Name], mapping)
+
+		adapter.baseAdapter.Data[mapping.path] = &dataprovider.BaseData{
+			Public: cfg.Public, ReadOnly: true, Type: dataprovider.NodeTypeSensor, DataType: defaultCANDataType,
+			Unit: signal.Unit,
+		}
+	}
+
+	return database, mappings, nil
+}
+
+func (adapter *ReplayAdapter) addControls(cfg adapterConfig, duration time.Duration) {
+	controls := map[string]*dataprovider.BaseData{
+		controlSpeed:    {Value: cfg.Speed, DataType: "double"},
+		controlLoop:     {Value: cfg.Loop, DataType: "boolean"},
+		controlPaused:   {Value: cfg.Paused, DataType: "boolean"},
+		controlPosition: {Value: 0.0, DataType: "double", Unit: "s"},
+		controlDuration: {Value: duration.Seconds(), ReadOnly: true, DataType: "double", Unit: "s"},
+	}
+
+	for path, data := range controls {
+		data.Public = cfg.Public
+		data.Type = dataprovider.NodeTypeAttribute
+
+		adapter.baseAdapter.Data[path] = data
+	}
+}
+
+// play updates replayed values and playback position.
+func (adapter *ReplayAdapter) play(data map[string]interface{}, offset time.Duration) {
+	update := map[string]interface{}{controlPosition: offset.Seconds()}
+
+	for path, value := range data {
+		update[path] = value
+	}
+
+	if err := adapter.baseAdapter.UpdateData(update); err != nil {
+		log.Errorf("Can't update replayed data: %s", err)
+	}
+}
+
+func (adapter *ReplayAdapter) validateControl(path string, value interface{}) (result interface{}, err error) {
+	switch path {
+	case controlLoop, controlPaused:
+		boolValue, ok := value.(bool)
+		if !ok {
+			return nil, aoserrors.Errorf("invalid value for path %s: %v is not boolean", path, value)
+		}
+
+		return boolValue, nil
+
+	default:
+		floatValue, ok := dataprovider.GetNumericValue(value)
+		if !ok {
+			return nil, aoserrors.Errorf("invalid value for path %s: %v is not numeric", path, value)
+		}
+
+		if path == controlSpeed && floatValue <= 0 {
+			return nil, aoserrors.Errorf("invalid value for path %s: speed should be positive", path)
+		}
+
+		if path == controlPosition && (floatValue < 0 || floatValue > adapter.player.duration().Seconds()) {
+			return nil, aoserrors.Errorf("invalid value for path %s: position is out of log", path)
+		}
+
+		return floatValue, nil
+	}
+}
+
+func isControl(path string) (result bool) {
+	return path == controlSpeed || path == controlLoop || path == controlPaused || path == controlPosition ||
+		path == controlDuration
+}
+
+func secondsToDuration(seconds float64) (duration time.Duration) {
+	return time.Duration(seconds * float64(time.Second))
+}
@@ -0,0 +1,315 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2021 Renesas Electronics Corporation.
+// Copyright (C) 2021 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package replayadapter_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/aosedge/aos_common/aoserrors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/aosedge/aos_vis/dataprovider"
+	"github.com/aosedge/aos_vis/plugins/replayadapter"
+)
+
+/*******************************************************************************
+ * Consts
+ ******************************************************************************/
+
+const waitTimeout = 5 * time.Second
+
+const testCSV = `timestamp,path,value
+1700000000.0,Signal.Vehicle.Speed,10
+1700000000.1,Signal.Vehicle.Speed,20
+1700000000.1,Signal.Cabin.Door.Row1.Left.IsOpen,true
+1700000000.2,Signal.Vehicle.Speed,30.5
+`
+
+const testJSONL = `{"timestamp": "2024-01-01T10:00:00Z", "path": "Signal.Vehicle.Gear", "value": "P"}
+{"timestamp": "2024-01-01T10:00:00.05Z", "path": "Signal.Vehicle.Gear", "value": "D"}
+
+{"timestamp": "2024-01-01T10:00:00.1Z", "path": "Signal.Vehicle.Gear", "value": "N"}
+`
+
+const testDBC = `BO_ 256 Engine: 2 ECU
+ SG_ EngineSpeed : 0|16@1+ (0.25,0) [0|16383.75] "rpm" BCM
+
+BO_ 2147484160 Body: 1 BCM
+ SG_ TrunkLocked : 0|1@1+ (1,0) [0|1] "" ECU
+`
+
+const testCANDump = `(1700000000.000000) vcan0 100#A00F
+(1700000000.050000) vcan0 123#FF
+(1700000000.100000) vcan0 00000200#01
+(1700000000.150000) vcan0 100#4006
+`
+
+/*******************************************************************************
+ * Vars
+ ******************************************************************************/
+
+var tmpDir string
+
+/*******************************************************************************
+ * Init
+ ******************************************************************************/
+
+func init() {
+	log.SetFormatter(&log.TextFormatter{
+		DisableTimestamp: false,
+		TimestampFormat:  "2006-01-02 15:04:05.000",
+		FullTimestamp:    true,
+	})
+	log.SetLevel(log.DebugLevel)
+	log.SetOutput(os.Stdout)
+}
+
+/*******************************************************************************
+ * Main
+ ******************************************************************************/
+
+func TestMain(m *testing.M) {
+	var err error
+
+	tmpDir, err = os.MkdirTemp("", "replayadapter_")
+	if err != nil {
+		log.Fatalf("Error creating tmp dir: %s", err)
+	}
+
+	files := map[string]string{
+		"drive.csv": testCSV, "drive.jsonl": testJSONL, "vehicle.dbc": testDBC, "drive.log": testCANDump,
+		"invalid.csv": "1700000000.0,Signal.Vehicle.Speed,10\nyesterday,Signal.Vehicle.Speed,20\n",
+	}
+
+	for name, content := range files {
+		if err = os.WriteFile(filepath.Join(tmpDir, name), []byte(content), 0o600); err != nil {
+			log.Fatalf("Error writing test file: %s", err)
+		}
+	}
+
+	ret := m.Run()
+
+	if err := os.RemoveAll(tmpDir); err != nil {
+		log.Fatalf("Error removing tmp dir: %s", err)
+	}
+
+	os.Exit(ret)
+}
+
+/*******************************************************************************
+ * Tests
+ ******************************************************************************/
+
+func TestConfigErrors(t *testing.T) {
+	testItems := []string{
+		`{}`,
+		`{"File": "` + testFile("drive.csv") + `", "Speed": -1}`,
+		`{"File": "` + testFile("drive.txt") + `"}`,
+		`{"File": "` + testFile("missing.csv") + `"}`,
+		`{"File": "` + testFile("invalid.csv") + `"}`,
+		`{"File": "` + testFile("drive.log") + `"}`,
+		`{"File": "` + testFile("drive.log") + `", "DBCFile": "` + testFile("vehicle.dbc") + `", "Signals": [
+			{"Path": "Signal.Vehicle.Speed", "Message": "Engine", "Signal": "Speed"}
+		]}`,
+	}
+
+	for _, item := range testItems {
+		if adapter, err := replayadapter.New(json.RawMessage(item)); err == nil {
+			adapter.Close()
+			t.Errorf("Error expected for config: %s", item)
+		}
+	}
+}
+
+func TestCSV(t *testing.T) {
+	adapter, err := replayadapter.New(json.RawMessage(`{"File": "` + testFile("drive.csv") + `", "Paused": true}`))
+	if err != nil {
+		t.Fatalf("Can't create adapter: %s", err)
+	}
+	defer adapter.Close()
+
+	if err = adapter.Subscribe([]string{"Signal.Vehicle.Speed", "Signal.Cabin.Door.Row1.Left.IsOpen"}); err != nil {
+		t.Fatalf("Can't subscribe: %s", err)
+	}
+
+	if err = adapter.SetData(map[string]interface{}{"Attribute.Replay.Paused": false}); err != nil {
+		t.Fatalf("Can't set data: %s", err)
+	}
+
+	if err = waitChanges(adapter, []map[string]interface{}{
+		{"Signal.Vehicle.Speed": 10.0},
+		{"Signal.Vehicle.Speed": 20.0},
+		{"Signal.Cabin.Door.Row1.Left.IsOpen": true},
+		{"Signal.Vehicle.Speed": 30.5},
+	}); err != nil {
+		t.Error(err)
+	}
+
+	data, err := adapter.GetData([]string{"Attribute.Replay.Position", "Attribute.Replay.Duration"})
+	if err != nil {
+		t.Fatalf("Can't get data: %s", err)
+	}
+
+	if data["Attribute.Replay.Position"] != 0.2 || data["Attribute.Replay.Duration"] != 0.2 {
+		t.Errorf("Wrong position data: %v", data)
+	}
+}
+
+func TestJSONLLoop(t *testing.T) {
+	adapter, err := replayadapter.New(json.RawMessage(
+		`{"File": "` + testFile("drive.jsonl") + `", "Loop": true, "Speed": 2, "Paused": true}`))
+	if err != nil {
+		t.Fatalf("Can't create adapter: %s", err)
+	}
+	defer adapter.Close()
+
+	if err = adapter.Subscribe([]string{"Signal.Vehicle.Gear"}); err != nil {
+		t.Fatalf("Can't subscribe: %s", err)
+	}
+
+	if err = adapter.SetData(map[string]interface{}{"Attribute.Replay.Paused": false}); err != nil {
+		t.Fatalf("Can't set data: %s", err)
+	}
+
+	if err = waitChanges(adapter, []map[string]interface{}{
+		{"Signal.Vehicle.Gear": "P"}, {"Signal.Vehicle.Gear": "D"}, {"Signal.Vehicle.Gear": "N"},
+		{"Signal.Vehicle.Gear": "P"}, {"Signal.Vehicle.Gear": "D"}, {"Signal.Vehicle.Gear": "N"},
+	}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCANDump(t *testing.T) {
+	adapter, err := replayadapter.New(json.RawMessage(`{"File": "` + testFile("drive.log") + `",
+		"DBCFile": "` + testFile("vehicle.dbc") + `", "Paused": true, "Speed": 10, "Signals": [
+		{"Path": "Signal.Engine.Speed", "Message": "Engine", "Signal": "EngineSpeed"},
+		{"Path": "Signal.Body.Trunk.IsLocked", "Message": "Body", "Signal": "TrunkLocked"}
+	]}`))
+	if err != nil {
+		t.Fatalf("Can't create adapter: %s", err)
+	}
+	defer adapter.Close()
+
+	if err = adapter.Subscribe([]string{"Signal.Engine.Speed", "Signal.Body.Trunk.IsLocked"}); err != nil {
+		t.Fatalf("Can't subscribe: %s", err)
+	}
+
+	if err = adapter.SetData(map[string]interface{}{"Attribute.Replay.Paused": false}); err != nil {
+		t.Fatalf("Can't set data: %s", err)
+	}
+
+	// Frame 0x123 is not in DBC and skipped
+	if err = waitChanges(adapter, []map[string]interface{}{
+		{"Signal.Engine.Speed": 1000.0},
+		{"Signal.Body.Trunk.IsLocked": 1.0},
+		{"Signal.Engine.Speed": 400.0},
+	}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestControls(t *testing.T) {
+	adapter, err := replayadapter.New(json.RawMessage(`{"File": "` + testFile("drive.csv") + `", "Paused": true}`))
+	if err != nil {
+		t.Fatalf("Can't create adapter: %s", err)
+	}
+	defer adapter.Close()
+
+	metadata, err := adapter.GetMetadata([]string{"Attribute.Replay.Speed", "Attribute.Replay.Duration",
+		"Signal.Vehicle.Speed"})
+	if err != nil {
+		t.Fatalf("Can't get metadata: %s", err)
+	}
+
+	if !metadata["Attribute.Replay.Speed"].Writable || metadata["Attribute.Replay.Duration"].Writable ||
+		metadata["Signal.Vehicle.Speed"].Writable {
+		t.Error("Wrong writable metadata")
+	}
+
+	errorItems := []map[string]interface{}{
+		{"Signal.Vehicle.Speed": 50},
+		{"Attribute.Replay.Duration": 1.0},
+		{"Attribute.Replay.Speed": 0},
+		{"Attribute.Replay.Loop": "yes"},
+		{"Attribute.Replay.Position": 1.0},
+	}
+
+	for _, item := range errorItems {
+		if err = adapter.SetData(item); err == nil {
+			t.Errorf("Error expected for data: %v", item)
+		}
+	}
+
+	// Nothing is played while paused
+	time.Sleep(300 * time.Millisecond)
+
+	data, err := adapter.GetData([]string{"Signal.Vehicle.Speed"})
+	if err != nil {
+		t.Fatalf("Can't get data: %s", err)
+	}
+
+	if data["Signal.Vehicle.Speed"] != nil {
+		t.Errorf("Unexpected played value: %v", data["Signal.Vehicle.Speed"])
+	}
+
+	if err = adapter.Subscribe([]string{"Signal.Vehicle.Speed"}); err != nil {
+		t.Fatalf("Can't subscribe: %s", err)
+	}
+
+	// Records before position are skipped
+	if err = adapter.SetData(map[string]interface{}{
+		"Attribute.Replay.Position": 0.15, "Attribute.Replay.Paused": false,
+	}); err != nil {
+		t.Fatalf("Can't set data: %s", err)
+	}
+
+	if err = waitChanges(adapter, []map[string]interface{}{{"Signal.Vehicle.Speed": 30.5}}); err != nil {
+		t.Error(err)
+	}
+}
+
+/*******************************************************************************
+ * Private
+ ******************************************************************************/
+
+func testFile(name string) (fileName string) {
+	return filepath.Join(tmpDir, name)
+}
+
+func waitChanges(adapter dataprovider.DataAdapter, expectedChanges []map[string]interface{}) (err error) {
+	for _, expectedData := range expectedChanges {
+		select {
+		case changes := <-adapter.GetSubscribeChannel():
+			data, _ := dataprovider.UnpackChanges(changes)
+
+			if !reflect.DeepEqual(data, expectedData) {
+				return aoserrors.Errorf("wrong changed data: %v, expected: %v", data, expectedData)
+			}
+
+		case <-time.After(waitTimeout):
+			return aoserrors.Errorf("wait data change timeout: %v", expectedData)
+		}
+	}
+
+	return nil
+}
@@ -20,6 +20,11 @@ package storageadapter
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 
@@ -33,7 +38,10 @@ import (
 
 // StorageAdapter storage adapter.
 type StorageAdapter struct {
-	baseAdapter *dataprovider.BaseAdapter
+	sync.Mutex
+	baseAdapter   *dataprovider.BaseAdapter
+	storageFile   string
+	writablePaths []string
 }
 
 /*******************************************************************************
@@ -54,7 +62,8 @@ func New(configJSON json.RawMessage) (adapter dataprovider.DataAdapter, err erro
 	localAdapter.baseAdapter.Name = "StorageAdapter"
 
 	var sensors struct {
-		Data map[string]*dataprovider.BaseData `json:"data"`
+		Data        map[string]*dataprovider.BaseData `json:"data"`
+		StorageFile string                            `json:"storageFile"`
 	}
 
 	// Parse config
@@ -67,6 +76,17 @@ func New(configJSON json.RawMessage) (adapter dataprovider.DataAdapter, err erro
 	}
 
 	localAdapter.baseAdapter.Data = sensors.Data
+	localAdapter.storageFile = sensors.StorageFile
+
+	for path, data := range sensors.Data {
+		if !data.ReadOnly {
+			localAdapter.writablePaths = append(localAdapter.writablePaths, path)
+		}
+	}
+
+	if localAdapter.storageFile != "" {
+		localAdapter.loadStorage()
+	}
 
 	return localAdapter, nil
 }
@@ -103,6 +123,18 @@ func (adapter *StorageAdapter) IsPathPublic(path string) (result bool, err error
 	return result, nil
 }
 
+// GetMetadata returns metadata by path.
+func (adapter *StorageAdapter) GetMetadata(pathList []string) (
+	metadata map[string]*dataprovider.SignalMetadata, err error,
+) {
+	metadata, err = adapter.baseAdapter.GetMetadata(pathList)
+	if err != nil {
+		return metadata, aoserrors.Wrap(err)
+	}
+
+	return metadata, nil
+}
+
 // GetData returns data by path.
 func (adapter *StorageAdapter) GetData(pathList []string) (data map[string]interface{}, err error) {
 	data, err = adapter.baseAdapter.GetData(pathList)
@@ -113,9 +145,66 @@ func (adapter *StorageAdapter) GetData(pathList []string) (data map[string]inter
 	return data, nil
 }
 
-// SetData sets data by pathes.
+// GetTimestamps returns time when data was sampled.
+func (adapter *StorageAdapter) GetTimestamps(pathList []string) (timestamps map[string]time.Time, err error) {
+	timestamps, err = adapter.baseAdapter.GetTimestamps(pathList)
+	if err != nil {
+		return timestamps, aoserrors.Wrap(err)
+	}
+
+	return timestamps, nil
+}
+
+// SetData sets data by pathes. If storage file is configured, writable values are saved to it before they are
+// changed, so values are not changed if they can't be saved.
 func (adapter *StorageAdapter) SetData(data map[string]interface{}) (err error) {
-	return aoserrors.Wrap(adapter.baseAdapter.SetData(data))
+	adapter.Lock()
+	defer adapter.Unlock()
+
+	if adapter.storageFile == "" {
+		return aoserrors.Wrap(adapter.baseAdapter.SetData(data))
+	}
+
+	for path := range data {
+		baseData, ok := adapter.baseAdapter.Data[path]
+		if !ok {
+			return aoserrors.Errorf("path %s doesn't exist", path)
+		}
+
+		if baseData.ReadOnly {
+			return aoserrors.Errorf("signal %s cannot be set since it is a read only signal", path)
+		}
+	}
+
+	prevData, err := adapter.baseAdapter.GetData(adapter.writablePaths)
+	if err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	storedData := make(map[string]interface{}, len(prevData))
+
+	for path, value := range prevData {
+		storedData[path] = value
+	}
+
+	for path, value := range data {
+		storedData[path] = value
+	}
+
+	if err = adapter.saveStorage(storedData); err != nil {
+		return err
+	}
+
+	if err = adapter.baseAdapter.SetData(data); err != nil {
+		// Values are not changed, so previous ones are restored in storage
+		if saveErr := adapter.saveStorage(prevData); saveErr != nil {
+			log.WithField("file", adapter.storageFile).Errorf("Can't restore storage: %s", saveErr)
+		}
+
+		return aoserrors.Wrap(err)
+	}
+
+	return nil
 }
 
 // GetSubscribeChannel returns channel on which data changes will be sent.
@@ -137,3 +226,96 @@ func (adapter *StorageAdapter) Unsubscribe(pathList []string) (err error) {
 func (adapter *StorageAdapter) UnsubscribeAll() (err error) {
 	return aoserrors.Wrap(adapter.baseAdapter.UnsubscribeAll())
 }
+
+/*******************************************************************************
+ * Private
+ ******************************************************************************/
+
+// loadStorage applies values of storage file. Config values are used if the file is absent or corrupted.
+func (adapter *StorageAdapter) loadStorage() {
+	storageJSON, err := os.ReadFile(adapter.storageFile)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			log.WithField("file", adapter.storageFile).Warnf("Can't read storage, use default values: %s", err)
+		}
+
+		return
+	}
+
+	var storedData map[string]interface{}
+
+	// Numbers are decoded as float64 like values of VIS requests, so setting of the same value is not a change
+	if err = json.Unmarshal(storageJSON, &storedData); err != nil {
+		log.WithField("file", adapter.storageFile).Warnf("Storage is corrupted, use default values: %s", err)
+
+		return
+	}
+
+	for path, value := range storedData {
+		data, ok := adapter.baseAdapter.Data[path]
+		if !ok || data.ReadOnly {
+			log.WithField("path", path).Warn("Skip stored value of unknown or read only path")
+
+			continue
+		}
+
+		data.Value = value
+	}
+
+	log.WithFields(log.Fields{"file": adapter.storageFile, "count": len(storedData)}).Debug("Storage loaded")
+}
+
+// saveStorage writes values to temporary file and renames it to storage file, so the storage file always contains
+// either previous or new values.
+func (adapter *StorageAdapter) saveStorage(data map[string]interface{}) (err error) {
+	storageJSON, err := json.Marshal(data)
+	if err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	storageDir := filepath.Dir(adapter.storageFile)
+
+	if err = os.MkdirAll(storageDir, 0o755); err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	tmpFile, err := os.CreateTemp(storageDir, filepath.Base(adapter.storageFile)+".*.tmp")
+	if err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	defer os.Remove(tmpFile.Name())
+
+	if _, err = tmpFile.Write(storageJSON); err != nil {
+		tmpFile.Close()
+
+		return aoserrors.Wrap(err)
+	}
+
+	if err = tmpFile.Sync(); err != nil {
+		tmpFile.Close()
+
+		return aoserrors.Wrap(err)
+	}
+
+	if err = tmpFile.Close(); err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	if err = os.Rename(tmpFile.Name(), adapter.storageFile); err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	return syncDir(storageDir)
+}
+
+// syncDir flushes directory entry of renamed file.
+func syncDir(dirName string) (err error) {
+	dir, err := os.Open(dirName)
+	if err != nil {
+		return aoserrors.Wrap(err)
+	}
+	defer dir.Close()
+
+	return aoserrors.Wrap(dir.Sync())
+}
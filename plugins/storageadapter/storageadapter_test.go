@@ -19,6 +19,7 @@ package storageadapter_test
 
 import (
 	"os"
+	"path/filepath"
 	"testing"
 
 	log "github.com/sirupsen/logrus"
@@ -140,14 +141,139 @@ func TestPublicPath(t *testing.T) {
 	}
 }
 
+func TestGetMetadata(t *testing.T) {
+	if err := dataadaptertest.GetMetadata(&adapterInfo); err != nil {
+		t.Errorf("Test get metadata error: %s", err)
+	}
+}
+
 func TestGetSetData(t *testing.T) {
 	if err := dataadaptertest.GetSetData(&adapterInfo); err != nil {
 		t.Errorf("Test get set data error: %s", err)
 	}
 }
 
+func TestGetTimestamps(t *testing.T) {
+	if err := dataadaptertest.GetTimestamps(&adapterInfo); err != nil {
+		t.Errorf("Test get timestamps error: %s", err)
+	}
+}
+
 func TestSubscribeUnsubscribe(t *testing.T) {
 	if err := dataadaptertest.SubscribeUnsubscribe(&adapterInfo); err != nil {
 		t.Errorf("Test subscribe unsubscribe error: %s", err)
 	}
 }
+
+func TestStorageFile(t *testing.T) {
+	storageDir := t.TempDir()
+	storageFile := filepath.Join(storageDir, "storage", "data.json")
+
+	configJSON := []byte(`{"StorageFile": "` + storageFile + `", "Data": {
+		"Attribute.Vehicle.VehicleIdentification.VIN": {"Value": "TestVIN", "ReadOnly": true},
+		"Attribute.Car.Message":                       {"Value": "Default"},
+		"Attribute.Car.Speed":                         {"Value": 0}
+	}}`)
+
+	storageAdapter, err := storageadapter.New(configJSON)
+	if err != nil {
+		t.Fatalf("Can't create storage adapter: %s", err)
+	}
+
+	if err = storageAdapter.SetData(map[string]interface{}{
+		"Attribute.Car.Message": "Hello", "Attribute.Car.Speed": 5.0,
+	}); err != nil {
+		t.Fatalf("Can't set data: %s", err)
+	}
+
+	storageAdapter.Close()
+
+	// Values should be restored after restart
+	if storageAdapter, err = storageadapter.New(configJSON); err != nil {
+		t.Fatalf("Can't create storage adapter: %s", err)
+	}
+
+	data, err := storageAdapter.GetData([]string{
+		"Attribute.Car.Message", "Attribute.Car.Speed", "Attribute.Vehicle.VehicleIdentification.VIN",
+	})
+	if err != nil {
+		t.Fatalf("Can't get data: %s", err)
+	}
+
+	// Restored numbers are float64 as values of VIS requests
+	if data["Attribute.Car.Message"] != "Hello" || data["Attribute.Car.Speed"] != 5.0 ||
+		data["Attribute.Vehicle.VehicleIdentification.VIN"] != "TestVIN" {
+		t.Errorf("Wrong restored data: %v", data)
+	}
+
+	// Values are not changed if storage can't be saved
+	if err = os.RemoveAll(filepath.Dir(storageFile)); err != nil {
+		t.Fatalf("Can't remove storage dir: %s", err)
+	}
+
+	if err = os.WriteFile(filepath.Dir(storageFile), nil, 0o600); err != nil {
+		t.Fatalf("Can't write file: %s", err)
+	}
+
+	if err = storageAdapter.SetData(map[string]interface{}{"Attribute.Car.Message": "Bye"}); err == nil {
+		t.Error("Error expected")
+	}
+
+	if data, err = storageAdapter.GetData([]string{"Attribute.Car.Message"}); err != nil {
+		t.Fatalf("Can't get data: %s", err)
+	}
+
+	if data["Attribute.Car.Message"] != "Hello" {
+		t.Errorf("Value should not be changed: %v", data)
+	}
+
+	// Read only values are not set
+	if err = storageAdapter.SetData(map[string]interface{}{
+		"Attribute.Vehicle.VehicleIdentification.VIN": "NewVIN",
+	}); err == nil {
+		t.Error("Error expected")
+	}
+
+	storageAdapter.Close()
+
+	if err = os.Remove(filepath.Dir(storageFile)); err != nil {
+		t.Fatalf("Can't remove file: %s", err)
+	}
+
+	if storageAdapter, err = storageadapter.New(configJSON); err != nil {
+		t.Fatalf("Can't create storage adapter: %s", err)
+	}
+
+	if err = storageAdapter.SetData(map[string]interface{}{"Attribute.Car.Message": "Hello"}); err != nil {
+		t.Fatalf("Can't set data: %s", err)
+	}
+
+	storageAdapter.Close()
+
+	entries, err := os.ReadDir(filepath.Dir(storageFile))
+	if err != nil {
+		t.Fatalf("Can't read storage dir: %s", err)
+	}
+
+	if len(entries) != 1 {
+		t.Errorf("Temporary files should be removed: %v", entries)
+	}
+
+	// Corrupted storage should be replaced by config values
+	if err = os.WriteFile(storageFile, []byte(`{"Attribute.Car.Message": "Hel`), 0o600); err != nil {
+		t.Fatalf("Can't write storage file: %s", err)
+	}
+
+	if storageAdapter, err = storageadapter.New(configJSON); err != nil {
+		t.Fatalf("Can't create storage adapter: %s", err)
+	}
+	defer storageAdapter.Close()
+
+	if data, err = storageAdapter.GetData([]string{"Attribute.Car.Message"}); err != nil {
+		t.Fatalf("Can't get data: %s", err)
+	}
+
+	if data["Attribute.Car.Message"] != "Default" {
+		t.Errorf("Wrong data of corrupted storage: %v", data)
+	}
+}
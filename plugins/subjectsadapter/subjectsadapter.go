@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 
@@ -42,6 +43,7 @@ const subscribeChannelSize = 2
 
 type subjectsAdapter struct {
 	subjects         []string
+	timestamp        time.Time
 	subscribed       bool
 	subscribeChannel chan map[string]interface{}
 	config           adapterConfig
@@ -105,6 +107,29 @@ func (adapter *subjectsAdapter) IsPathPublic(path string) (result bool, err erro
 	return true, nil
 }
 
+// GetMetadata returns metadata by path.
+func (adapter *subjectsAdapter) GetMetadata(pathList []string) (
+	metadata map[string]*dataprovider.SignalMetadata, err error,
+) {
+	metadata = make(map[string]*dataprovider.SignalMetadata)
+
+	for _, path := range pathList {
+		if path != adapter.config.VISPath {
+			return nil, aoserrors.Errorf("path %s doesn't exits", path)
+		}
+
+		metadata[path] = &dataprovider.SignalMetadata{
+			Type:     dataprovider.NodeTypeAttribute,
+			DataType: "string[]",
+			Readable: true,
+			Writable: true,
+			Public:   true,
+		}
+	}
+
+	return metadata, nil
+}
+
 // GetData returns data by path.
 func (adapter *subjectsAdapter) GetData(pathList []string) (data map[string]interface{}, err error) {
 	log.WithField("subjects", adapter.subjects).Debug("Get subjects")
@@ -122,6 +147,21 @@ func (adapter *subjectsAdapter) GetData(pathList []string) (data map[string]inte
 	return data, nil
 }
 
+// GetTimestamps returns time when data was sampled.
+func (adapter *subjectsAdapter) GetTimestamps(pathList []string) (timestamps map[string]time.Time, err error) {
+	timestamps = make(map[string]time.Time)
+
+	for _, path := range pathList {
+		if path != adapter.config.VISPath {
+			return nil, aoserrors.Errorf("path %s doesn't exits", path)
+		}
+
+		timestamps[path] = adapter.timestamp
+	}
+
+	return timestamps, nil
+}
+
 // SetData sets data by pathes.
 func (adapter *subjectsAdapter) SetData(data map[string]interface{}) (err error) {
 	for path, value := range data {
@@ -132,6 +172,7 @@ func (adapter *subjectsAdapter) SetData(data map[string]interface{}) (err error)
 			}
 
 			adapter.subjects = []string{}
+			adapter.timestamp = time.Now()
 
 			for _, subject := range subjects {
 				subjectStr, ok := subject.(string)
@@ -211,6 +252,7 @@ func (adapter *subjectsAdapter) readSubjects() (err error) {
 	scanner := bufio.NewScanner(file)
 
 	adapter.subjects = nil
+	adapter.timestamp = time.Now()
 
 	for scanner.Scan() {
 		adapter.subjects = append(adapter.subjects, scanner.Text())
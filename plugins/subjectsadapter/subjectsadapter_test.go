@@ -96,6 +96,28 @@ func TestGetName(t *testing.T) {
 	}
 }
 
+func TestGetMetadata(t *testing.T) {
+	adapter, err := subjectsadapter.New(generateConfig(path.Join(tmpDir, "subject.txt")))
+	if err != nil {
+		t.Fatalf("Can't create adapter: %s", err)
+	}
+	defer adapter.Close()
+
+	metadata, err := adapter.GetMetadata([]string{subjectsVISPath})
+	if err != nil {
+		t.Fatalf("Can't get metadata: %s", err)
+	}
+
+	signalMetadata, ok := metadata[subjectsVISPath]
+	if !ok {
+		t.Fatal("Subject metadata not found")
+	}
+
+	if signalMetadata.DataType != "string[]" || !signalMetadata.Writable || !signalMetadata.Public {
+		t.Errorf("Wrong subjects metadata: %v", *signalMetadata)
+	}
+}
+
 func TestEmptysubject(t *testing.T) {
 	subjectFile := path.Join(tmpDir, "subjects.txt")
 	if err := os.RemoveAll(subjectFile); err != nil {
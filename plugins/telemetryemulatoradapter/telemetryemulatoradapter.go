@@ -142,6 +142,24 @@ func (adapter *TelemetryEmulatorAdapter) IsPathPublic(path string) (result bool,
 	return true, nil
 }
 
+// GetMetadata returns metadata by path.
+func (adapter *TelemetryEmulatorAdapter) GetMetadata(pathList []string) (
+	metadata map[string]*dataprovider.SignalMetadata, err error,
+) {
+	metadata, err = adapter.baseAdapter.GetMetadata(pathList)
+	if err != nil {
+		return metadata, aoserrors.Wrap(err)
+	}
+
+	// Only emulator attributes can be set
+	for path, signalMetadata := range metadata {
+		signalMetadata.Writable = strings.HasPrefix(path, "Attribute.Emulator.")
+		signalMetadata.Public = true
+	}
+
+	return metadata, nil
+}
+
 // GetData returns data by path.
 func (adapter *TelemetryEmulatorAdapter) GetData(pathList []string) (data map[string]interface{}, err error) {
 	data, err = adapter.baseAdapter.GetData(pathList)
@@ -152,6 +170,16 @@ func (adapter *TelemetryEmulatorAdapter) GetData(pathList []string) (data map[st
 	return data, nil
 }
 
+// GetTimestamps returns time when data was sampled.
+func (adapter *TelemetryEmulatorAdapter) GetTimestamps(pathList []string) (timestamps map[string]time.Time, err error) {
+	timestamps, err = adapter.baseAdapter.GetTimestamps(pathList)
+	if err != nil {
+		return timestamps, aoserrors.Wrap(err)
+	}
+
+	return timestamps, nil
+}
+
 // SetData sets data by pathes.
 func (adapter *TelemetryEmulatorAdapter) SetData(data map[string]interface{}) (err error) {
 	sendData, err := convertVisFormatToData(data)
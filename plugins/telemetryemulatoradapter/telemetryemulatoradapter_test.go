@@ -120,6 +120,12 @@ func TestPublicPath(t *testing.T) {
 	}
 }
 
+func TestGetMetadata(t *testing.T) {
+	if err := dataadaptertest.GetMetadata(&adapterInfo); err != nil {
+		t.Errorf("Test get metadata error: %s", err)
+	}
+}
+
 func TestGetSetData(t *testing.T) {
 	if err := dataadaptertest.GetSetData(&adapterInfo); err != nil {
 		t.Errorf("Test get set data error: %s", err)
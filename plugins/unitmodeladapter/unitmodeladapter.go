@@ -20,6 +20,7 @@ package unitmodeladapter
 import (
 	"encoding/json"
 	"os"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 
@@ -39,6 +40,7 @@ const defaultUnitModel = "unit;1.0"
 
 type unitModelAdapter struct {
 	unitModel string
+	timestamp time.Time
 	config    adapterConfig
 }
 
@@ -78,6 +80,7 @@ func New(configJSON json.RawMessage) (adapter dataprovider.DataAdapter, err erro
 	}
 
 	localAdapter.unitModel = string(unitModel)
+	localAdapter.timestamp = time.Now()
 
 	log.WithField("Unit model", localAdapter.unitModel).Debug("UnitModel adapter")
 
@@ -104,6 +107,29 @@ func (adapter *unitModelAdapter) IsPathPublic(path string) (result bool, err err
 	return true, nil
 }
 
+// GetMetadata returns metadata by path.
+func (adapter *unitModelAdapter) GetMetadata(pathList []string) (
+	metadata map[string]*dataprovider.SignalMetadata, err error,
+) {
+	metadata = make(map[string]*dataprovider.SignalMetadata)
+
+	for _, path := range pathList {
+		if path != adapter.config.VISPath {
+			return nil, aoserrors.Errorf("path %s doesn't exits", path)
+		}
+
+		metadata[path] = &dataprovider.SignalMetadata{
+			Type:     dataprovider.NodeTypeAttribute,
+			DataType: "string",
+			Readable: true,
+			Writable: false,
+			Public:   true,
+		}
+	}
+
+	return metadata, nil
+}
+
 // GetData returns data by path.
 func (adapter *unitModelAdapter) GetData(pathList []string) (data map[string]interface{}, err error) {
 	data = make(map[string]interface{})
@@ -119,6 +145,21 @@ func (adapter *unitModelAdapter) GetData(pathList []string) (data map[string]int
 	return data, nil
 }
 
+// GetTimestamps returns time when data was sampled.
+func (adapter *unitModelAdapter) GetTimestamps(pathList []string) (timestamps map[string]time.Time, err error) {
+	timestamps = make(map[string]time.Time)
+
+	for _, path := range pathList {
+		if path != adapter.config.VISPath {
+			return nil, aoserrors.Errorf("path %s doesn't exits", path)
+		}
+
+		timestamps[path] = adapter.timestamp
+	}
+
+	return timestamps, nil
+}
+
 // SetData sets data by paths.
 func (adapter *unitModelAdapter) SetData(data map[string]interface{}) (err error) {
 	if len(data) == 0 {
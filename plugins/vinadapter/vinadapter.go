@@ -23,6 +23,7 @@ import (
 	"math/big"
 	"os"
 	"path/filepath"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 
@@ -41,8 +42,9 @@ const vinLength = 17
  ******************************************************************************/
 
 type vinAdapter struct {
-	vin    string
-	config adapterConfig
+	vin       string
+	timestamp time.Time
+	config    adapterConfig
 }
 
 type adapterConfig struct {
@@ -84,6 +86,7 @@ func New(configJSON json.RawMessage) (adapter dataprovider.DataAdapter, err erro
 	}
 
 	localAdapter.vin = string(vin)
+	localAdapter.timestamp = time.Now()
 
 	log.WithField("VIN", localAdapter.vin).Debug("VIN adapter")
 
@@ -110,6 +113,29 @@ func (adapter *vinAdapter) IsPathPublic(path string) (result bool, err error) {
 	return true, nil
 }
 
+// GetMetadata returns metadata by path.
+func (adapter *vinAdapter) GetMetadata(pathList []string) (
+	metadata map[string]*dataprovider.SignalMetadata, err error,
+) {
+	metadata = make(map[string]*dataprovider.SignalMetadata)
+
+	for _, path := range pathList {
+		if path != adapter.config.VISPath {
+			return nil, aoserrors.Errorf("path %s doesn't exits", path)
+		}
+
+		metadata[path] = &dataprovider.SignalMetadata{
+			Type:     dataprovider.NodeTypeAttribute,
+			DataType: "string",
+			Readable: true,
+			Writable: false,
+			Public:   true,
+		}
+	}
+
+	return metadata, nil
+}
+
 // GetData returns data by path.
 func (adapter *vinAdapter) GetData(pathList []string) (data map[string]interface{}, err error) {
 	data = make(map[string]interface{})
@@ -125,6 +151,21 @@ func (adapter *vinAdapter) GetData(pathList []string) (data map[string]interface
 	return data, nil
 }
 
+// GetTimestamps returns time when data was sampled.
+func (adapter *vinAdapter) GetTimestamps(pathList []string) (timestamps map[string]time.Time, err error) {
+	timestamps = make(map[string]time.Time)
+
+	for _, path := range pathList {
+		if path != adapter.config.VISPath {
+			return nil, aoserrors.Errorf("path %s doesn't exits", path)
+		}
+
+		timestamps[path] = adapter.timestamp
+	}
+
+	return timestamps, nil
+}
+
 // SetData sets data by pathes.
 func (adapter *vinAdapter) SetData(data map[string]interface{}) (err error) {
 	if len(data) == 0 {
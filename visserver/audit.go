@@ -0,0 +1,114 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2021 Renesas Electronics Corporation.
+// Copyright (C) 2021 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package visserver
+
+import (
+	"time"
+
+	"github.com/aosedge/aos_common/aoserrors"
+
+	"github.com/aosedge/aos_vis/audit"
+	"github.com/aosedge/aos_vis/dataprovider"
+)
+
+/*******************************************************************************
+ * Types
+ ******************************************************************************/
+
+// IdentityProvider optional permission provider interface to get client identity and token validity along with
+// permissions. Zero expiresAt means token validity is not limited by provider.
+type IdentityProvider interface {
+	GetVisIdentityByToken(token string) (
+		permissions map[string]string, identity *dataprovider.ClientIdentity, expiresAt time.Time, err error)
+}
+
+/*******************************************************************************
+ * Private
+ ******************************************************************************/
+
+// authorizeByToken returns permissions and, if provider supports it, identity of token owner and token expiration
+// time.
+func authorizeByToken(
+	permissionProvider PermissionProvider, token string,
+) (permissions map[string]string, identity *dataprovider.ClientIdentity, expiresAt time.Time, err error) {
+	if identityProvider, ok := permissionProvider.(IdentityProvider); ok {
+		return identityProvider.GetVisIdentityByToken(token)
+	}
+
+	permissions, err = permissionProvider.GetVisPermissionByToken(token)
+
+	return permissions, nil, time.Time{}, err
+}
+
+// setDataWithAudit sets data and records set operation outcome. Values before set are requested from adapters only
+// if audit is enabled.
+func setDataWithAudit(
+	dataProvider *dataprovider.DataProvider, logger *audit.Logger, protocol, remoteAddr string,
+	authInfo *dataprovider.AuthInfo, path string, value interface{},
+) (err error) {
+	if logger == nil {
+		return aoserrors.Wrap(dataProvider.SetData(path, value, authInfo))
+	}
+
+	changes, err := dataProvider.SetDataWithChanges(path, value, authInfo)
+
+	record := audit.Record{
+		Action:     audit.ActionSet,
+		Protocol:   protocol,
+		RemoteAddr: remoteAddr,
+		Path:       path,
+		Value:      value,
+		Changes:    changes,
+	}
+
+	if authInfo != nil {
+		record.Identity = authInfo.Identity
+	}
+
+	logger.Log(setAuditResult(record, err))
+
+	return aoserrors.Wrap(err)
+}
+
+// auditAuthorize records authorize operation outcome.
+func auditAuthorize(
+	logger *audit.Logger, protocol, remoteAddr string, identity *dataprovider.ClientIdentity, err error,
+) {
+	logger.Log(setAuditResult(audit.Record{
+		Action:     audit.ActionAuthorize,
+		Protocol:   protocol,
+		RemoteAddr: remoteAddr,
+		Identity:   identity,
+	}, err))
+}
+
+func setAuditResult(record audit.Record, err error) (result audit.Record) {
+	if err == nil {
+		record.Result = audit.ResultSuccess
+
+		return record
+	}
+
+	errorInfo := createErrorInfo(err, protocolVersion1)
+
+	record.Result = audit.ResultFailure
+	record.ErrorNumber = errorInfo.Number
+	record.Error = errorInfo.Message
+
+	return record
+}
@@ -0,0 +1,205 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2021 Renesas Electronics Corporation.
+// Copyright (C) 2021 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package visserver
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"time"
+
+	"github.com/aosedge/aos_common/aoserrors"
+
+	"github.com/aosedge/aos_vis/dataprovider"
+)
+
+/*******************************************************************************
+ * Types
+ ******************************************************************************/
+
+// subscribeFilter W3C VIS subscription filter.
+type subscribeFilter struct {
+	interval   time.Duration
+	above      *float64
+	below      *float64
+	minChange  *float64
+	lastValues map[string]float64
+	lastSent   time.Time
+}
+
+type filterJSON struct {
+	Interval  *int64     `json:"interval"`
+	Range     *rangeJSON `json:"range"`
+	MinChange *float64   `json:"minChange"`
+}
+
+type getFilterJSON struct {
+	History *int64 `json:"history"`
+}
+
+type rangeJSON struct {
+	Above *float64 `json:"above"`
+	Below *float64 `json:"below"`
+}
+
+/*******************************************************************************
+ * Private
+ ******************************************************************************/
+
+// parseFilter creates subscription filter from filters request field. Filters could be specified as JSON object or
+// as string containing JSON object. Nil filter is returned if filters are not specified.
+func parseFilter(filtersJSON json.RawMessage) (filter *subscribeFilter, err error) {
+	var parsedFilter filterJSON
+
+	if ok, err := decodeFilters(filtersJSON, &parsedFilter); !ok || err != nil {
+		return nil, err
+	}
+
+	filter = &subscribeFilter{lastValues: make(map[string]float64)}
+
+	if parsedFilter.Interval != nil {
+		if *parsedFilter.Interval < 0 {
+			return nil, aoserrors.New("invalid filter: interval should not be negative")
+		}
+
+		filter.interval = time.Duration(*parsedFilter.Interval) * time.Millisecond
+	}
+
+	if parsedFilter.Range != nil {
+		if parsedFilter.Range.Above == nil && parsedFilter.Range.Below == nil {
+			return nil, aoserrors.New("invalid filter: range should contain above or below value")
+		}
+
+		if parsedFilter.Range.Above != nil && parsedFilter.Range.Below != nil &&
+			*parsedFilter.Range.Above >= *parsedFilter.Range.Below {
+			return nil, aoserrors.New("invalid filter: range above value should be less than below value")
+		}
+
+		filter.above, filter.below = parsedFilter.Range.Above, parsedFilter.Range.Below
+	}
+
+	if parsedFilter.MinChange != nil {
+		if *parsedFilter.MinChange < 0 {
+			return nil, aoserrors.New("invalid filter: min change should not be negative")
+		}
+
+		filter.minChange = parsedFilter.MinChange
+	}
+
+	return filter, nil
+}
+
+// parseGetFilter returns history window from filters field of get request. False is returned if history is not
+// requested. Zero window means all stored history.
+func parseGetFilter(filtersJSON json.RawMessage) (history bool, window time.Duration, err error) {
+	var parsedFilter getFilterJSON
+
+	if ok, err := decodeFilters(filtersJSON, &parsedFilter); !ok || err != nil {
+		return false, 0, err
+	}
+
+	if parsedFilter.History == nil {
+		return false, 0, nil
+	}
+
+	if *parsedFilter.History < 0 {
+		return false, 0, aoserrors.New("invalid filter: history should not be negative")
+	}
+
+	return true, time.Duration(*parsedFilter.History) * time.Millisecond, nil
+}
+
+// decodeFilters decodes filters specified as JSON object or as string containing JSON object. False is returned if
+// filters are not specified.
+func decodeFilters(filtersJSON json.RawMessage, parsedFilter interface{}) (ok bool, err error) {
+	filtersJSON = bytes.TrimSpace(filtersJSON)
+
+	if len(filtersJSON) == 0 || bytes.Equal(filtersJSON, []byte("null")) {
+		return false, nil
+	}
+
+	if filtersJSON[0] == '"' {
+		var filtersStr string
+
+		if err = json.Unmarshal(filtersJSON, &filtersStr); err != nil {
+			return false, aoserrors.Errorf("invalid filter: %v", err)
+		}
+
+		if filtersStr == "" {
+			return false, nil
+		}
+
+		filtersJSON = []byte(filtersStr)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(filtersJSON))
+	decoder.DisallowUnknownFields()
+
+	if err = decoder.Decode(parsedFilter); err != nil {
+		return false, aoserrors.Errorf("invalid filter: %v", err)
+	}
+
+	return true, nil
+}
+
+// filterValues applies range and min change filters. Non numeric values are passed as is.
+func (filter *subscribeFilter) filterValues(
+	values map[string]dataprovider.DataPoint,
+) (result map[string]dataprovider.DataPoint) {
+	result = make(map[string]dataprovider.DataPoint)
+
+	for path, value := range values {
+		numValue, ok := dataprovider.GetNumericValue(value.Value)
+		if !ok {
+			result[path] = value
+			continue
+		}
+
+		if filter.above != nil && numValue <= *filter.above {
+			continue
+		}
+
+		if filter.below != nil && numValue >= *filter.below {
+			continue
+		}
+
+		if filter.minChange != nil {
+			if lastValue, ok := filter.lastValues[path]; ok && math.Abs(numValue-lastValue) < *filter.minChange {
+				continue
+			}
+		}
+
+		filter.lastValues[path] = numValue
+		result[path] = value
+	}
+
+	return result
+}
+
+// waitInterval returns time left till next notification is allowed.
+func (filter *subscribeFilter) waitInterval(now time.Time) (wait time.Duration) {
+	if filter.interval == 0 || filter.lastSent.IsZero() {
+		return 0
+	}
+
+	if wait = filter.interval - now.Sub(filter.lastSent); wait < 0 {
+		return 0
+	}
+
+	return wait
+}
@@ -0,0 +1,1034 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2021 Renesas Electronics Corporation.
+// Copyright (C) 2021 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package visserver
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aosedge/aos_common/aoserrors"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	kuksa "github.com/aosedge/aos_vis/api/kuksa/val/v1"
+	"github.com/aosedge/aos_vis/dataprovider"
+	"github.com/aosedge/aos_vis/metrics"
+)
+
+/*******************************************************************************
+ * Consts
+ ******************************************************************************/
+
+const (
+	grpcServerName      = "aos_vis"
+	actionGetServerInfo = "getServerInfo"
+)
+
+/*******************************************************************************
+ * Types
+ ******************************************************************************/
+
+// valServer implements kuksa.val.v1 VAL service on top of VIS data provider.
+type valServer struct {
+	kuksa.UnimplementedVALServer
+	server *Server
+}
+
+/*******************************************************************************
+ * Vars
+ ******************************************************************************/
+
+// Version server version reported by GetServerInfo.
+var Version string //nolint:gochecknoglobals // set at startup
+
+//nolint:gochecknoglobals // constant table
+var kuksaDataTypes = map[string]kuksa.DataType{
+	"string":  kuksa.DataType_DATA_TYPE_STRING,
+	"boolean": kuksa.DataType_DATA_TYPE_BOOLEAN,
+	"int8":    kuksa.DataType_DATA_TYPE_INT8,
+	"int16":   kuksa.DataType_DATA_TYPE_INT16,
+	"int32":   kuksa.DataType_DATA_TYPE_INT32,
+	"int64":   kuksa.DataType_DATA_TYPE_INT64,
+	"uint8":   kuksa.DataType_DATA_TYPE_UINT8,
+	"uint16":  kuksa.DataType_DATA_TYPE_UINT16,
+	"uint32":  kuksa.DataType_DATA_TYPE_UINT32,
+	"uint64":  kuksa.DataType_DATA_TYPE_UINT64,
+	"float":   kuksa.DataType_DATA_TYPE_FLOAT,
+	"double":  kuksa.DataType_DATA_TYPE_DOUBLE,
+
+	"string[]":  kuksa.DataType_DATA_TYPE_STRING_ARRAY,
+	"boolean[]": kuksa.DataType_DATA_TYPE_BOOLEAN_ARRAY,
+	"int8[]":    kuksa.DataType_DATA_TYPE_INT8_ARRAY,
+	"int16[]":   kuksa.DataType_DATA_TYPE_INT16_ARRAY,
+	"int32[]":   kuksa.DataType_DATA_TYPE_INT32_ARRAY,
+	"int64[]":   kuksa.DataType_DATA_TYPE_INT64_ARRAY,
+	"uint8[]":   kuksa.DataType_DATA_TYPE_UINT8_ARRAY,
+	"uint16[]":  kuksa.DataType_DATA_TYPE_UINT16_ARRAY,
+	"uint32[]":  kuksa.DataType_DATA_TYPE_UINT32_ARRAY,
+	"uint64[]":  kuksa.DataType_DATA_TYPE_UINT64_ARRAY,
+	"float[]":   kuksa.DataType_DATA_TYPE_FLOAT_ARRAY,
+	"double[]":  kuksa.DataType_DATA_TYPE_DOUBLE_ARRAY,
+}
+
+/*******************************************************************************
+ * Private
+ ******************************************************************************/
+
+// startGRPCServer starts gRPC server which serves kuksa.val.v1 VAL service.
+func (server *Server) startGRPCServer(url, cert, key string) (err error) {
+	creds, err := credentials.NewServerTLSFromFile(cert, key)
+	if err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	listener, err := net.Listen("tcp", url)
+	if err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	server.grpcServer = grpc.NewServer(grpc.Creds(creds),
+		grpc.UnaryInterceptor(observeGRPCRequest), grpc.StreamInterceptor(observeGRPCStream))
+
+	kuksa.RegisterVALServer(server.grpcServer, &valServer{server: server})
+
+	go func() {
+		log.WithFields(log.Fields{"address": url, "crt": cert, "key": key}).Debug("Listen for gRPC clients")
+
+		if err := server.grpcServer.Serve(listener); err != nil {
+			log.Error("gRPC server listening error: ", aoserrors.Wrap(err))
+		}
+	}()
+
+	return nil
+}
+
+// observeGRPCRequest updates request metrics after unary request is handled.
+func observeGRPCRequest(
+	ctx context.Context, request any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler,
+) (response any, err error) {
+	start := time.Now()
+
+	response, err = handler(ctx, request)
+
+	metrics.ObserveRequest(metrics.ProtocolGRPC, getGRPCMethodName(info.FullMethod), start, int(status.Code(err)))
+
+	return response, err
+}
+
+// observeGRPCStream updates request metrics after stream is finished. Duration of stream request is its lifetime.
+func observeGRPCStream(
+	srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler,
+) (err error) {
+	start := time.Now()
+
+	err = handler(srv, stream)
+
+	metrics.ObserveRequest(metrics.ProtocolGRPC, getGRPCMethodName(info.FullMethod), start, int(status.Code(err)))
+
+	return err
+}
+
+// getGRPCMethodName returns method name of full gRPC method: /kuksa.val.v1.VAL/Get.
+func getGRPCMethodName(fullMethod string) (name string) {
+	return fullMethod[strings.LastIndex(fullMethod, "/")+1:]
+}
+
+// Get returns requested entries. Errors of particular entries are reported in response errors field.
+func (handler *valServer) Get(ctx context.Context, request *kuksa.GetRequest) (*kuksa.GetResponse, error) {
+	authInfo, token, _, err := handler.getAuthInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = handler.checkRequestRate(ctx, authInfo, token, ActionGet); err != nil {
+		return nil, err
+	}
+
+	response := &kuksa.GetResponse{}
+
+	for _, entryRequest := range request.GetEntries() {
+		entries, err := handler.getEntries(entryRequest.GetPath(), entryRequest.GetView(), entryRequest.GetFields(),
+			authInfo)
+		if err != nil {
+			response.Errors = append(response.Errors, createDataEntryError(entryRequest.GetPath(), err))
+			continue
+		}
+
+		response.Entries = append(response.Entries, entries...)
+	}
+
+	response.Error = createResponseError(response.Errors)
+
+	return response, nil
+}
+
+// Set sets entries values. Actuator target is set as VIS data as VIS doesn't distinguish target and current value.
+func (handler *valServer) Set(ctx context.Context, request *kuksa.SetRequest) (*kuksa.SetResponse, error) {
+	authInfo, token, _, err := handler.getAuthInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = handler.checkRequestRate(ctx, authInfo, token, ActionSet); err != nil {
+		return nil, err
+	}
+
+	response := &kuksa.SetResponse{}
+
+	for _, update := range request.GetUpdates() {
+		path := update.GetEntry().GetPath()
+
+		if err := handler.setEntry(ctx, update, authInfo); err != nil {
+			response.Errors = append(response.Errors, createDataEntryError(path, err))
+		}
+	}
+
+	response.Error = createResponseError(response.Errors)
+
+	return response, nil
+}
+
+// Subscribe sends current values of requested entries and then their changes until client cancels the stream,
+// authorization expires or subscription queue overflows with disconnect policy.
+func (handler *valServer) Subscribe(
+	request *kuksa.SubscribeRequest, stream grpc.ServerStreamingServer[kuksa.SubscribeResponse],
+) error {
+	authInfo, token, expiresAt, err := handler.getAuthInfo(stream.Context())
+	if err != nil {
+		return err
+	}
+
+	ctx := stream.Context()
+
+	// Authorized stream lives not longer than websocket authorization does
+	if token != "" {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithDeadline(ctx, time.Now().Add(getAuthTTL(handler.server.authTTL, expiresAt)))
+		defer cancel()
+	}
+
+	if err = handler.checkRequestRate(stream.Context(), authInfo, token, ActionSubscribe); err != nil {
+		return err
+	}
+
+	if len(request.GetEntries()) == 0 {
+		return status.Error(codes.InvalidArgument, "subscribe entries are not specified")
+	}
+
+	peerKey := getPeerKey(getPeerAddr(stream.Context()), token, authInfo)
+
+	if err = handler.server.acquirePeerSubscriptions(peerKey, authInfo, len(request.GetEntries())); err != nil {
+		return status.Error(codes.ResourceExhausted, err.Error())
+	}
+	defer handler.server.releasePeerSubscriptions(peerKey, len(request.GetEntries()))
+
+	var (
+		sendMutex     sync.Mutex
+		subscriptions []*dataprovider.Subscription
+		wg            sync.WaitGroup
+		overflowOnce  sync.Once
+	)
+
+	overflowChannel := make(chan struct{})
+
+	defer func() {
+		for _, subscription := range subscriptions {
+			if subscription.Overflowed() {
+				continue
+			}
+
+			if err := handler.server.dataProvider.Unsubscribe(subscription.ID, authInfo); err != nil {
+				log.Errorf("Can't unsubscribe gRPC subscription: %s", err)
+			}
+		}
+
+		wg.Wait()
+	}()
+
+	for _, entry := range request.GetEntries() {
+		subscription, dataTypes, err := handler.subscribeEntry(entry.GetPath(), authInfo)
+		if err != nil {
+			return status.Error(getStatusCode(err), err.Error())
+		}
+
+		subscriptions = append(subscriptions, subscription)
+
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			// The first notification is snapshot of current values
+			for notification := range subscription.Channel {
+				if lost := subscription.TakeLost(); lost != 0 {
+					log.WithFields(log.Fields{
+						"subscribeID": subscription.ID, "lost": lost,
+					}).Warn("gRPC subscription notifications are lost")
+				}
+
+				if err := sendSubscribeResponse(stream, &sendMutex, notification.Data, dataTypes); err != nil {
+					log.Errorf("Can't send gRPC subscription update: %s", err)
+
+					continue
+				}
+
+				subscription.NotificationSent()
+			}
+
+			if subscription.Overflowed() {
+				overflowOnce.Do(func() { close(overflowChannel) })
+			}
+		}()
+	}
+
+	select {
+	case <-ctx.Done():
+		if stream.Context().Err() != nil {
+			return nil
+		}
+
+		log.WithField("remoteAddr", getPeerAddr(ctx)).Debug("gRPC subscription authorization expired")
+
+		return status.Error(codes.Unauthenticated, "the access token has expired")
+
+	case <-overflowChannel:
+		return status.Error(codes.ResourceExhausted, "subscription queue overflow")
+	}
+}
+
+// GetServerInfo returns server name and version.
+func (handler *valServer) GetServerInfo(
+	ctx context.Context, request *kuksa.GetServerInfoRequest,
+) (*kuksa.GetServerInfoResponse, error) {
+	if err := handler.checkRequestRate(ctx, &dataprovider.AuthInfo{}, "", actionGetServerInfo); err != nil {
+		return nil, err
+	}
+
+	return &kuksa.GetServerInfoResponse{Name: grpcServerName, Version: Version}, nil
+}
+
+// getAuthInfo returns client authorization info, token from bearer token of authorization metadata and token
+// expiration time. Not authorized info is returned if metadata is absent.
+func (handler *valServer) getAuthInfo(
+	ctx context.Context,
+) (authInfo *dataprovider.AuthInfo, token string, expiresAt time.Time, err error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok || len(md.Get("authorization")) == 0 {
+		return &dataprovider.AuthInfo{}, "", time.Time{}, nil
+	}
+
+	authorization := md.Get("authorization")[0]
+
+	if !strings.HasPrefix(authorization, bearerPrefix) {
+		return nil, "", time.Time{}, handler.authorizationFailed(ctx, "unsupported authorization scheme")
+	}
+
+	token = strings.TrimSpace(strings.TrimPrefix(authorization, bearerPrefix))
+	if token == "" {
+		return nil, "", time.Time{}, handler.authorizationFailed(ctx, "empty token authorization")
+	}
+
+	permissions, identity, expiresAt, err := authorizeByToken(handler.server.GetPermissionProvider(), token)
+	if err == nil && getAuthTTL(handler.server.authTTL, expiresAt) <= 0 {
+		err = aoserrors.New("token is expired")
+	}
+
+	if err == nil {
+		authInfo, err = dataprovider.NewAuthInfo(permissions, identity)
+	}
+
+	if err != nil {
+		log.Errorf("gRPC authorization error: %s", err)
+
+		return nil, "", time.Time{}, handler.authorizationFailed(ctx, "service not authorized")
+	}
+
+	return authInfo, token, expiresAt, nil
+}
+
+// checkRequestRate returns resource exhausted status error if request rate limit of peer is exceeded.
+func (handler *valServer) checkRequestRate(
+	ctx context.Context, authInfo *dataprovider.AuthInfo, token, action string,
+) (err error) {
+	remoteAddr := getPeerAddr(ctx)
+
+	if err = handler.server.checkPeerRequestRate(
+		getPeerKey(remoteAddr, token, authInfo), authInfo, action, time.Now()); err != nil {
+		log.WithField("remoteAddr", remoteAddr).Warn(err)
+
+		return status.Error(codes.ResourceExhausted, err.Error())
+	}
+
+	return nil
+}
+
+// authorizationFailed records authorization failure and returns unauthenticated status error.
+func (handler *valServer) authorizationFailed(ctx context.Context, message string) (err error) {
+	auditAuthorize(handler.server.auditLogger, metrics.ProtocolGRPC, getPeerAddr(ctx), nil, aoserrors.New(message))
+
+	return status.Error(codes.Unauthenticated, message)
+}
+
+func (handler *valServer) getEntries(
+	path string, view kuksa.View, fields []kuksa.Field, authInfo *dataprovider.AuthInfo,
+) (entries []*kuksa.DataEntry, err error) {
+	withValue, withMetadata, err := getViewFields(view, fields)
+	if err != nil {
+		return nil, err
+	}
+
+	signals, err := handler.getSignalsMetadata(path, authInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	var dataPoints map[string]dataprovider.DataPoint
+
+	if withValue {
+		if dataPoints, err = handler.server.dataProvider.GetDataPoints(path, authInfo); err != nil {
+			return nil, err
+		}
+	}
+
+	paths := make([]string, 0, len(signals))
+
+	for signalPath := range signals {
+		paths = append(paths, signalPath)
+	}
+
+	sort.Strings(paths)
+
+	for _, signalPath := range paths {
+		entry := &kuksa.DataEntry{Path: signalPath}
+
+		if dataPoint, ok := dataPoints[signalPath]; ok {
+			if entry.Value, err = convertToDatapoint(dataPoint, signals[signalPath].DataType); err != nil {
+				return nil, err
+			}
+		}
+
+		if withMetadata {
+			entry.Metadata = convertToKuksaMetadata(signals[signalPath])
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+func (handler *valServer) setEntry(
+	ctx context.Context, update *kuksa.EntryUpdate, authInfo *dataprovider.AuthInfo,
+) (err error) {
+	entry := update.GetEntry()
+	if entry == nil || entry.GetPath() == "" {
+		return aoserrors.New("data path is not specified")
+	}
+
+	datapoint := entry.GetValue()
+
+	for _, field := range update.GetFields() {
+		if field == kuksa.Field_FIELD_ACTUATOR_TARGET {
+			datapoint = entry.GetActuatorTarget()
+		}
+	}
+
+	if datapoint == nil {
+		return aoserrors.New("value is not specified")
+	}
+
+	value, err := convertFromDatapoint(datapoint)
+	if err != nil {
+		return err
+	}
+
+	return setDataWithAudit(handler.server.dataProvider, handler.server.auditLogger, metrics.ProtocolGRPC,
+		getPeerAddr(ctx), authInfo, entry.GetPath(), value)
+}
+
+func (handler *valServer) subscribeEntry(
+	path string, authInfo *dataprovider.AuthInfo,
+) (subscription *dataprovider.Subscription, dataTypes map[string]string, err error) {
+	signals, err := handler.getSignalsMetadata(path, authInfo)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	dataTypes = make(map[string]string)
+
+	for signalPath, signal := range signals {
+		dataTypes[signalPath] = signal.DataType
+	}
+
+	if subscription, err = handler.server.dataProvider.SubscribeWithOptions(
+		path, authInfo, dataprovider.SubscribeOptions{
+			Snapshot: true, MaxPaths: getTierLimits(&handler.server.limits, authInfo).MaxSubscriptionPaths,
+		}); err != nil {
+		return nil, nil, aoserrors.Wrap(err)
+	}
+
+	return subscription, dataTypes, nil
+}
+
+// getSignalsMetadata returns flat map of signal metadata of all signals matched to requested path.
+func (handler *valServer) getSignalsMetadata(
+	path string, authInfo *dataprovider.AuthInfo,
+) (signals map[string]*dataprovider.SignalMetadata, err error) {
+	metadata, err := handler.server.dataProvider.GetMetadata(path, authInfo)
+	if err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	signals = make(map[string]*dataprovider.SignalMetadata)
+
+	for name, node := range metadata {
+		addSignalsMetadata(signals, name, node)
+	}
+
+	return signals, nil
+}
+
+func addSignalsMetadata(
+	signals map[string]*dataprovider.SignalMetadata, path string, node *dataprovider.MetadataNode,
+) {
+	if len(node.Children) == 0 {
+		signalMetadata := node.SignalMetadata
+		signals[path] = &signalMetadata
+
+		return
+	}
+
+	for name, child := range node.Children {
+		addSignalsMetadata(signals, path+"."+name, child)
+	}
+}
+
+func sendSubscribeResponse(
+	stream grpc.ServerStreamingServer[kuksa.SubscribeResponse], sendMutex sync.Locker,
+	dataPoints map[string]dataprovider.DataPoint, dataTypes map[string]string,
+) (err error) {
+	response := &kuksa.SubscribeResponse{}
+
+	paths := make([]string, 0, len(dataPoints))
+
+	for path := range dataPoints {
+		paths = append(paths, path)
+	}
+
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		datapoint, err := convertToDatapoint(dataPoints[path], dataTypes[path])
+		if err != nil {
+			return err
+		}
+
+		response.Updates = append(response.Updates, &kuksa.EntryUpdate{
+			Entry:  &kuksa.DataEntry{Path: path, Value: datapoint},
+			Fields: []kuksa.Field{kuksa.Field_FIELD_VALUE},
+		})
+	}
+
+	if len(response.Updates) == 0 {
+		return nil
+	}
+
+	sendMutex.Lock()
+	defer sendMutex.Unlock()
+
+	return aoserrors.Wrap(stream.Send(response))
+}
+
+// getViewFields returns which entry parts should be populated for requested view and fields.
+func getViewFields(view kuksa.View, fields []kuksa.Field) (withValue, withMetadata bool, err error) {
+	switch view {
+	case kuksa.View_VIEW_UNSPECIFIED:
+		if len(fields) == 0 {
+			return true, false, nil
+		}
+
+	case kuksa.View_VIEW_CURRENT_VALUE:
+		return true, false, nil
+
+	case kuksa.View_VIEW_METADATA:
+		return false, true, nil
+
+	case kuksa.View_VIEW_ALL:
+		return true, true, nil
+
+	case kuksa.View_VIEW_FIELDS:
+
+	default:
+		return false, false, aoserrors.Errorf("view %s is not supported", view)
+	}
+
+	for _, field := range fields {
+		switch {
+		case field == kuksa.Field_FIELD_UNSPECIFIED:
+			withValue, withMetadata = true, true
+
+		case field == kuksa.Field_FIELD_VALUE:
+			withValue = true
+
+		case field >= kuksa.Field_FIELD_METADATA:
+			withMetadata = true
+		}
+	}
+
+	return withValue, withMetadata, nil
+}
+
+func createDataEntryError(path string, err error) (entryError *kuksa.DataEntryError) {
+	errorInfo := createErrorInfo(err, protocolVersion2)
+
+	return &kuksa.DataEntryError{
+		Path: path,
+		Error: &kuksa.Error{
+			Code: uint32(errorInfo.Number), Reason: errorInfo.Reason, Message: errorInfo.Message, //nolint:gosec
+		},
+	}
+}
+
+// createResponseError returns global response error which reports code of first failed entry.
+func createResponseError(entryErrors []*kuksa.DataEntryError) (responseError *kuksa.Error) {
+	if len(entryErrors) == 0 {
+		return nil
+	}
+
+	return &kuksa.Error{
+		Code:    entryErrors[0].GetError().GetCode(),
+		Reason:  entryErrors[0].GetError().GetReason(),
+		Message: "one or more entries failed",
+	}
+}
+
+func getStatusCode(err error) (code codes.Code) {
+	switch createErrorInfo(err, protocolVersion2).Number {
+	case 401:
+		return codes.Unauthenticated
+
+	case 403:
+		return codes.PermissionDenied
+
+	case 404:
+		return codes.NotFound
+
+	case errorNumberTooManyRequests:
+		return codes.ResourceExhausted
+
+	default:
+		return codes.InvalidArgument
+	}
+}
+
+// convertToDatapoint converts VIS data point to kuksa datapoint. If signal datatype is unknown, it is detected by
+// value.
+func convertToDatapoint(
+	dataPoint dataprovider.DataPoint, dataType string,
+) (datapoint *kuksa.Datapoint, err error) {
+	datapoint = &kuksa.Datapoint{}
+
+	if dataPoint.Value != nil {
+		if dataType == "" {
+			dataType = detectDataType(dataPoint.Value)
+		}
+
+		if strings.HasSuffix(dataType, dataprovider.ArraySuffix) {
+			datapoint, err = convertToArrayDatapoint(dataPoint.Value, strings.TrimSuffix(dataType, dataprovider.ArraySuffix))
+		} else {
+			datapoint, err = convertToScalarDatapoint(dataPoint.Value, dataType)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	datapoint.Timestamp = timestamppb.New(dataPoint.Timestamp)
+
+	return datapoint, nil
+}
+
+func detectDataType(value interface{}) (dataType string) {
+	switch value.(type) {
+	case bool:
+		return "boolean"
+
+	case string:
+		return "string"
+	}
+
+	if _, ok := dataprovider.GetNumericValue(value); ok {
+		return "double"
+	}
+
+	reflectValue := reflect.ValueOf(value)
+
+	if (reflectValue.Kind() == reflect.Slice || reflectValue.Kind() == reflect.Array) && reflectValue.Len() > 0 {
+		return detectDataType(reflectValue.Index(0).Interface()) + dataprovider.ArraySuffix
+	}
+
+	return "string"
+}
+
+func convertToScalarDatapoint(value interface{}, dataType string) (datapoint *kuksa.Datapoint, err error) {
+	switch dataType {
+	case "boolean":
+		boolValue, ok := value.(bool)
+		if !ok {
+			return nil, aoserrors.Errorf("%v is not boolean", value)
+		}
+
+		return &kuksa.Datapoint{Value: &kuksa.Datapoint_Bool{Bool: boolValue}}, nil
+
+	case "string":
+		return &kuksa.Datapoint{Value: &kuksa.Datapoint_String_{String_: toString(value)}}, nil
+	}
+
+	numValue, ok := dataprovider.GetNumericValue(value)
+	if !ok {
+		return nil, aoserrors.Errorf("%v is not %s", value, dataType)
+	}
+
+	switch dataType {
+	case "int8", "int16", "int32":
+		return &kuksa.Datapoint{Value: &kuksa.Datapoint_Int32{Int32: int32(numValue)}}, nil
+
+	case "int64":
+		return &kuksa.Datapoint{Value: &kuksa.Datapoint_Int64{Int64: int64(numValue)}}, nil
+
+	case "uint8", "uint16", "uint32":
+		return &kuksa.Datapoint{Value: &kuksa.Datapoint_Uint32{Uint32: uint32(numValue)}}, nil
+
+	case "uint64":
+		return &kuksa.Datapoint{Value: &kuksa.Datapoint_Uint64{Uint64: uint64(numValue)}}, nil
+
+	case "float":
+		return &kuksa.Datapoint{Value: &kuksa.Datapoint_Float{Float: float32(numValue)}}, nil
+
+	default:
+		return &kuksa.Datapoint{Value: &kuksa.Datapoint_Double{Double: numValue}}, nil
+	}
+}
+
+//nolint:cyclop // one case per data type
+func convertToArrayDatapoint(value interface{}, itemType string) (datapoint *kuksa.Datapoint, err error) {
+	reflectValue := reflect.ValueOf(value)
+
+	if reflectValue.Kind() != reflect.Slice && reflectValue.Kind() != reflect.Array {
+		return nil, aoserrors.Errorf("%v is not %s array", value, itemType)
+	}
+
+	items := make([]*kuksa.Datapoint, 0, reflectValue.Len())
+
+	for i := 0; i < reflectValue.Len(); i++ {
+		item, err := convertToScalarDatapoint(reflectValue.Index(i).Interface(), itemType)
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, item)
+	}
+
+	switch itemType {
+	case "boolean":
+		return &kuksa.Datapoint{Value: &kuksa.Datapoint_BoolArray{
+			BoolArray: &kuksa.BoolArray{Values: convertItems(items, (*kuksa.Datapoint).GetBool)},
+		}}, nil
+
+	case "string":
+		return &kuksa.Datapoint{Value: &kuksa.Datapoint_StringArray{
+			StringArray: &kuksa.StringArray{Values: convertItems(items, (*kuksa.Datapoint).GetString_)},
+		}}, nil
+
+	case "int8", "int16", "int32":
+		return &kuksa.Datapoint{Value: &kuksa.Datapoint_Int32Array{
+			Int32Array: &kuksa.Int32Array{Values: convertItems(items, (*kuksa.Datapoint).GetInt32)},
+		}}, nil
+
+	case "int64":
+		return &kuksa.Datapoint{Value: &kuksa.Datapoint_Int64Array{
+			Int64Array: &kuksa.Int64Array{Values: convertItems(items, (*kuksa.Datapoint).GetInt64)},
+		}}, nil
+
+	case "uint8", "uint16", "uint32":
+		return &kuksa.Datapoint{Value: &kuksa.Datapoint_Uint32Array{
+			Uint32Array: &kuksa.Uint32Array{Values: convertItems(items, (*kuksa.Datapoint).GetUint32)},
+		}}, nil
+
+	case "uint64":
+		return &kuksa.Datapoint{Value: &kuksa.Datapoint_Uint64Array{
+			Uint64Array: &kuksa.Uint64Array{Values: convertItems(items, (*kuksa.Datapoint).GetUint64)},
+		}}, nil
+
+	case "float":
+		return &kuksa.Datapoint{Value: &kuksa.Datapoint_FloatArray{
+			FloatArray: &kuksa.FloatArray{Values: convertItems(items, (*kuksa.Datapoint).GetFloat)},
+		}}, nil
+
+	default:
+		return &kuksa.Datapoint{Value: &kuksa.Datapoint_DoubleArray{
+			DoubleArray: &kuksa.DoubleArray{Values: convertItems(items, (*kuksa.Datapoint).GetDouble)},
+		}}, nil
+	}
+}
+
+// convertFromDatapoint converts kuksa datapoint to VIS value. Numbers are converted to float64 and arrays to
+// []interface{} as VIS values received through JSON.
+//
+//nolint:cyclop // one case per data type
+func convertFromDatapoint(datapoint *kuksa.Datapoint) (value interface{}, err error) {
+	switch datapointValue := datapoint.GetValue().(type) {
+	case *kuksa.Datapoint_String_:
+		return datapointValue.String_, nil
+
+	case *kuksa.Datapoint_Bool:
+		return datapointValue.Bool, nil
+
+	case *kuksa.Datapoint_Int32:
+		return float64(datapointValue.Int32), nil
+
+	case *kuksa.Datapoint_Int64:
+		return float64(datapointValue.Int64), nil
+
+	case *kuksa.Datapoint_Uint32:
+		return float64(datapointValue.Uint32), nil
+
+	case *kuksa.Datapoint_Uint64:
+		return float64(datapointValue.Uint64), nil
+
+	case *kuksa.Datapoint_Float:
+		return float32ToFloat64(datapointValue.Float), nil
+
+	case *kuksa.Datapoint_Double:
+		return datapointValue.Double, nil
+
+	case *kuksa.Datapoint_StringArray:
+		return toInterfaceArray(datapointValue.StringArray.GetValues()), nil
+
+	case *kuksa.Datapoint_BoolArray:
+		return toInterfaceArray(datapointValue.BoolArray.GetValues()), nil
+
+	case *kuksa.Datapoint_Int32Array:
+		return toFloatArray(datapointValue.Int32Array.GetValues()), nil
+
+	case *kuksa.Datapoint_Int64Array:
+		return toFloatArray(datapointValue.Int64Array.GetValues()), nil
+
+	case *kuksa.Datapoint_Uint32Array:
+		return toFloatArray(datapointValue.Uint32Array.GetValues()), nil
+
+	case *kuksa.Datapoint_Uint64Array:
+		return toFloatArray(datapointValue.Uint64Array.GetValues()), nil
+
+	case *kuksa.Datapoint_FloatArray:
+		result := make([]interface{}, 0, len(datapointValue.FloatArray.GetValues()))
+
+		for _, item := range datapointValue.FloatArray.GetValues() {
+			result = append(result, float32ToFloat64(item))
+		}
+
+		return result, nil
+
+	case *kuksa.Datapoint_DoubleArray:
+		return toFloatArray(datapointValue.DoubleArray.GetValues()), nil
+
+	default:
+		return nil, aoserrors.New("value is not specified")
+	}
+}
+
+func convertToKuksaMetadata(signal *dataprovider.SignalMetadata) (metadata *kuksa.Metadata) {
+	metadata = &kuksa.Metadata{DataType: kuksaDataTypes[signal.DataType]}
+
+	if signal.Description != "" {
+		metadata.Description = &signal.Description
+	}
+
+	if signal.Unit != "" {
+		metadata.Unit = &signal.Unit
+	}
+
+	switch signal.Type {
+	case dataprovider.NodeTypeActuator:
+		metadata.EntryType = kuksa.EntryType_ENTRY_TYPE_ACTUATOR
+		metadata.EntrySpecific = &kuksa.Metadata_Actuator{Actuator: &kuksa.Actuator{}}
+
+	case dataprovider.NodeTypeSensor:
+		metadata.EntryType = kuksa.EntryType_ENTRY_TYPE_SENSOR
+		metadata.EntrySpecific = &kuksa.Metadata_Sensor{Sensor: &kuksa.Sensor{}}
+
+	case dataprovider.NodeTypeAttribute:
+		metadata.EntryType = kuksa.EntryType_ENTRY_TYPE_ATTRIBUTE
+		metadata.EntrySpecific = &kuksa.Metadata_Attribute{Attribute: &kuksa.Attribute{}}
+	}
+
+	metadata.ValueRestriction = convertToValueRestriction(signal)
+
+	return metadata
+}
+
+func convertToValueRestriction(signal *dataprovider.SignalMetadata) (restriction *kuksa.ValueRestriction) {
+	if signal.Min == nil && signal.Max == nil && len(signal.Allowed) == 0 {
+		return nil
+	}
+
+	minValue, hasMin := dataprovider.GetNumericValue(signal.Min)
+	maxValue, hasMax := dataprovider.GetNumericValue(signal.Max)
+
+	switch strings.TrimSuffix(signal.DataType, dataprovider.ArraySuffix) {
+	case "string":
+		stringRestriction := &kuksa.ValueRestrictionString{}
+
+		for _, allowed := range signal.Allowed {
+			stringRestriction.AllowedValues = append(stringRestriction.AllowedValues, toString(allowed))
+		}
+
+		return &kuksa.ValueRestriction{Type: &kuksa.ValueRestriction_String_{String_: stringRestriction}}
+
+	case "int8", "int16", "int32", "int64":
+		signedRestriction := &kuksa.ValueRestrictionInt{}
+
+		if hasMin {
+			signedRestriction.Min = &[]int64{int64(minValue)}[0]
+		}
+
+		if hasMax {
+			signedRestriction.Max = &[]int64{int64(maxValue)}[0]
+		}
+
+		for _, allowed := range signal.Allowed {
+			if numValue, ok := dataprovider.GetNumericValue(allowed); ok {
+				signedRestriction.AllowedValues = append(signedRestriction.AllowedValues, int64(numValue))
+			}
+		}
+
+		return &kuksa.ValueRestriction{Type: &kuksa.ValueRestriction_Signed{Signed: signedRestriction}}
+
+	case "uint8", "uint16", "uint32", "uint64":
+		unsignedRestriction := &kuksa.ValueRestrictionUint{}
+
+		if hasMin {
+			unsignedRestriction.Min = &[]uint64{uint64(minValue)}[0]
+		}
+
+		if hasMax {
+			unsignedRestriction.Max = &[]uint64{uint64(maxValue)}[0]
+		}
+
+		for _, allowed := range signal.Allowed {
+			if numValue, ok := dataprovider.GetNumericValue(allowed); ok {
+				unsignedRestriction.AllowedValues = append(unsignedRestriction.AllowedValues, uint64(numValue))
+			}
+		}
+
+		return &kuksa.ValueRestriction{Type: &kuksa.ValueRestriction_Unsigned{Unsigned: unsignedRestriction}}
+
+	default:
+		floatRestriction := &kuksa.ValueRestrictionFloat{}
+
+		if hasMin {
+			floatRestriction.Min = &minValue
+		}
+
+		if hasMax {
+			floatRestriction.Max = &maxValue
+		}
+
+		for _, allowed := range signal.Allowed {
+			if numValue, ok := dataprovider.GetNumericValue(allowed); ok {
+				floatRestriction.AllowedValues = append(floatRestriction.AllowedValues, numValue)
+			}
+		}
+
+		return &kuksa.ValueRestriction{Type: &kuksa.ValueRestriction_FloatingPoint{FloatingPoint: floatRestriction}}
+	}
+}
+
+func convertItems[T any](items []*kuksa.Datapoint, getValue func(*kuksa.Datapoint) T) (result []T) {
+	result = make([]T, 0, len(items))
+
+	for _, item := range items {
+		result = append(result, getValue(item))
+	}
+
+	return result
+}
+
+func toInterfaceArray[T any](values []T) (result []interface{}) {
+	result = make([]interface{}, 0, len(values))
+
+	for _, value := range values {
+		result = append(result, value)
+	}
+
+	return result
+}
+
+func toFloatArray[T int32 | int64 | uint32 | uint64 | float64](values []T) (result []interface{}) {
+	result = make([]interface{}, 0, len(values))
+
+	for _, value := range values {
+		result = append(result, float64(value))
+	}
+
+	return result
+}
+
+// float32ToFloat64 converts float32 to float64 by shortest decimal representation, so 0.1 stays 0.1.
+func float32ToFloat64(value float32) (result float64) {
+	result, _ = strconv.ParseFloat(strconv.FormatFloat(float64(value), 'g', -1, 32), 64)
+
+	return result
+}
+
+func toString(value interface{}) (result string) {
+	if stringValue, ok := value.(string); ok {
+		return stringValue
+	}
+
+	valueJSON, err := json.Marshal(value)
+	if err != nil {
+		return ""
+	}
+
+	return string(valueJSON)
+}
+
+// getPeerAddr returns remote address of gRPC client.
+func getPeerAddr(ctx context.Context) (addr string) {
+	if peerInfo, ok := peer.FromContext(ctx); ok && peerInfo.Addr != nil {
+		return peerInfo.Addr.String()
+	}
+
+	return ""
+}
@@ -0,0 +1,250 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2021 Renesas Electronics Corporation.
+// Copyright (C) 2021 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package visserver
+
+import (
+	"encoding/json"
+	"math"
+	"net"
+	"time"
+
+	"github.com/aosedge/aos_common/aoserrors"
+	"github.com/aosedge/aos_common/api/visprotocol"
+
+	"github.com/aosedge/aos_vis/config"
+	"github.com/aosedge/aos_vis/dataprovider"
+)
+
+/*******************************************************************************
+ * Consts
+ ******************************************************************************/
+
+const (
+	errorNumberTooManyRequests = 429
+	anyAction                  = "*"
+	peerLimiterTTL             = time.Minute
+)
+
+/*******************************************************************************
+ * Types
+ ******************************************************************************/
+
+// requestLimiter limits request rate per action. Each listed action has token bucket which capacity is one second
+// of requests, so short bursts up to the rate are allowed.
+type requestLimiter struct {
+	limits  *config.ClientLimitsConfig
+	buckets map[string]*tokenBucket
+}
+
+// peerLimiter limits requests of REST and gRPC peer. Authorized peers are identified by token, not authorized ones
+// by remote host.
+type peerLimiter struct {
+	limiter       requestLimiter
+	subscriptions int
+	lastTime      time.Time
+}
+
+type tokenBucket struct {
+	rate     float64
+	tokens   float64
+	lastTime time.Time
+}
+
+type limitErrorResponse struct {
+	visprotocol.MessageHeader
+	Error *visprotocol.ErrorInfo `json:"error"`
+}
+
+/*******************************************************************************
+ * Private
+ ******************************************************************************/
+
+// getLimits returns limits of client tier.
+func (client *clientInfo) getLimits() (limits *config.ClientLimitsConfig) {
+	return getTierLimits(client.limits, client.authInfo)
+}
+
+// checkRequestRate returns error if request rate limit of action is exceeded.
+func (client *clientInfo) checkRequestRate(action string, now time.Time) (err error) {
+	return client.limiter.check(client.getLimits(), action, now)
+}
+
+// checkSubscriptionsLimit returns error if client has max allowed number of subscriptions.
+func (client *clientInfo) checkSubscriptionsLimit() (err error) {
+	if maxSubscriptions := client.getLimits().MaxSubscriptions; maxSubscriptions > 0 &&
+		len(client.subscriptions) >= maxSubscriptions {
+		return aoserrors.Errorf("subscriptions limit exceeded: %d allowed", maxSubscriptions)
+	}
+
+	return nil
+}
+
+// checkPeerRequestRate returns error if request rate limit of REST or gRPC peer is exceeded.
+func (server *Server) checkPeerRequestRate(
+	peerKey string, authInfo *dataprovider.AuthInfo, action string, now time.Time,
+) (err error) {
+	server.peerLimitersMutex.Lock()
+	defer server.peerLimitersMutex.Unlock()
+
+	return server.getPeerLimiter(peerKey, now).limiter.check(getTierLimits(&server.limits, authInfo), action, now)
+}
+
+// acquirePeerSubscriptions returns error if peer subscriptions limit doesn't allow count more subscriptions.
+// Acquired subscriptions should be released by releasePeerSubscriptions.
+func (server *Server) acquirePeerSubscriptions(
+	peerKey string, authInfo *dataprovider.AuthInfo, count int,
+) (err error) {
+	server.peerLimitersMutex.Lock()
+	defer server.peerLimitersMutex.Unlock()
+
+	peer := server.getPeerLimiter(peerKey, time.Now())
+
+	if maxSubscriptions := getTierLimits(&server.limits, authInfo).MaxSubscriptions; maxSubscriptions > 0 &&
+		peer.subscriptions+count > maxSubscriptions {
+		return aoserrors.Errorf("subscriptions limit exceeded: %d allowed", maxSubscriptions)
+	}
+
+	peer.subscriptions += count
+
+	return nil
+}
+
+// releasePeerSubscriptions releases subscriptions acquired by acquirePeerSubscriptions.
+func (server *Server) releasePeerSubscriptions(peerKey string, count int) {
+	server.peerLimitersMutex.Lock()
+	defer server.peerLimitersMutex.Unlock()
+
+	server.getPeerLimiter(peerKey, time.Now()).subscriptions -= count
+}
+
+// getPeerLimiter returns limiter of peer. Limiters of peers which have no subscriptions and were not used for
+// peerLimiterTTL are removed.
+func (server *Server) getPeerLimiter(peerKey string, now time.Time) (peer *peerLimiter) {
+	if now.Sub(server.peerLimitersCleanup) > peerLimiterTTL {
+		for key, peer := range server.peerLimiters {
+			if peer.subscriptions == 0 && now.Sub(peer.lastTime) > peerLimiterTTL {
+				delete(server.peerLimiters, key)
+			}
+		}
+
+		server.peerLimitersCleanup = now
+	}
+
+	peer, ok := server.peerLimiters[peerKey]
+	if !ok {
+		peer = &peerLimiter{}
+		server.peerLimiters[peerKey] = peer
+	}
+
+	peer.lastTime = now
+
+	return peer
+}
+
+// getPeerKey returns key which identifies REST or gRPC peer for limits.
+func getPeerKey(remoteAddr, token string, authInfo *dataprovider.AuthInfo) (peerKey string) {
+	if authInfo.IsAuthorized {
+		return "token:" + token
+	}
+
+	if host, _, err := net.SplitHostPort(remoteAddr); err == nil {
+		return "host:" + host
+	}
+
+	return "host:" + remoteAddr
+}
+
+// getTierLimits returns limits of authorization tier.
+func getTierLimits(
+	limits *config.LimitsConfig, authInfo *dataprovider.AuthInfo,
+) (tierLimits *config.ClientLimitsConfig) {
+	if authInfo.IsAuthorized && limits.Authorized != nil {
+		return limits.Authorized
+	}
+
+	return &limits.Default
+}
+
+// createLimitErrorResponse creates response to request rejected due to limits.
+func createLimitErrorResponse(
+	header visprotocol.MessageHeader, errorInfo *visprotocol.ErrorInfo,
+) (response []byte, err error) {
+	if response, err = json.Marshal(&limitErrorResponse{MessageHeader: header, Error: errorInfo}); err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	return response, nil
+}
+
+// check returns error if request rate limit of action is exceeded.
+func (limiter *requestLimiter) check(limits *config.ClientLimitsConfig, action string, now time.Time) (err error) {
+	// Buckets are reset when client changes limits tier
+	if limiter.limits != limits {
+		*limiter = requestLimiter{limits: limits, buckets: make(map[string]*tokenBucket)}
+	}
+
+	if !limiter.allow(action, now) {
+		return aoserrors.Errorf("request rate limit exceeded for action %s", action)
+	}
+
+	return nil
+}
+
+func (limiter *requestLimiter) allow(action string, now time.Time) (result bool) {
+	rate, ok := limiter.limits.RequestRates[action]
+	if !ok {
+		// Not listed actions share the same bucket
+		action = anyAction
+		rate = limiter.limits.RequestRates[anyAction]
+	}
+
+	if rate <= 0 {
+		return true
+	}
+
+	bucket, ok := limiter.buckets[action]
+	if !ok {
+		bucket = newTokenBucket(rate, now)
+		limiter.buckets[action] = bucket
+	}
+
+	return bucket.take(now)
+}
+
+func newTokenBucket(rate float64, now time.Time) (bucket *tokenBucket) {
+	return &tokenBucket{rate: rate, tokens: bucketCapacity(rate), lastTime: now}
+}
+
+func (bucket *tokenBucket) take(now time.Time) (result bool) {
+	if elapsed := now.Sub(bucket.lastTime); elapsed > 0 {
+		bucket.tokens = math.Min(bucketCapacity(bucket.rate), bucket.tokens+elapsed.Seconds()*bucket.rate)
+		bucket.lastTime = now
+	}
+
+	if bucket.tokens < 1 {
+		return false
+	}
+
+	bucket.tokens--
+
+	return true
+}
+
+func bucketCapacity(rate float64) (capacity float64) {
+	return math.Max(1, rate)
+}
@@ -0,0 +1,318 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2021 Renesas Electronics Corporation.
+// Copyright (C) 2021 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package visserver
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/aosedge/aos_common/aoserrors"
+	"github.com/aosedge/aos_common/api/visprotocol"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/aosedge/aos_vis/dataprovider"
+	"github.com/aosedge/aos_vis/metrics"
+)
+
+/*******************************************************************************
+ * Consts
+ ******************************************************************************/
+
+const (
+	restMaxBodySize = 1 << 20
+	bearerPrefix    = "Bearer "
+)
+
+/*******************************************************************************
+ * Types
+ ******************************************************************************/
+
+type restSetRequest struct {
+	Value json.RawMessage `json:"value"`
+}
+
+// restStatusWriter keeps response status for request metrics.
+type restStatusWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+type restErrorResponse struct {
+	Error     *visprotocol.ErrorInfo `json:"error"`
+	Timestamp int64                  `json:"timestamp"`
+}
+
+type restErrorResponseV2 struct {
+	Error     *visprotocol.ErrorInfo `json:"error"`
+	Timestamp string                 `json:"ts"`
+}
+
+/*******************************************************************************
+ * Private
+ ******************************************************************************/
+
+// startRESTServer starts HTTP server which serves VIS requests according to VISS v2 HTTP binding:
+// GET /Signal/Vehicle/Speed gets data, PUT /Signal/Vehicle/Speed with {"value": ...} body sets data.
+func (server *Server) startRESTServer(url, cert, key string) (err error) {
+	certificate, err := tls.LoadX509KeyPair(cert, key)
+	if err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	listener, err := net.Listen("tcp", url)
+	if err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	serveMux := http.NewServeMux()
+	serveMux.HandleFunc("/", observeRESTRequest(server.handleRESTRequest))
+
+	server.restServer = &http.Server{
+		Addr: url, Handler: serveMux, ReadHeaderTimeout: time.Second,
+		TLSConfig: &tls.Config{Certificates: []tls.Certificate{certificate}, MinVersion: tls.VersionTLS12},
+	}
+
+	go func() {
+		log.WithFields(log.Fields{"address": url, "crt": cert, "key": key}).Debug("Listen for REST clients")
+
+		if err := server.restServer.ServeTLS(listener, "", ""); !errors.Is(err, http.ErrServerClosed) {
+			log.Error("REST server listening error: ", aoserrors.Wrap(err))
+		}
+	}()
+
+	return nil
+}
+
+// observeRESTRequest updates request metrics after request is handled.
+func observeRESTRequest(handler http.HandlerFunc) (observedHandler http.HandlerFunc) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		writer := &restStatusWriter{ResponseWriter: w, status: http.StatusOK}
+
+		handler(writer, r)
+
+		errorNumber := 0
+		if writer.status != http.StatusOK {
+			errorNumber = writer.status
+		}
+
+		metrics.ObserveRequest(metrics.ProtocolREST, getRESTAction(r.Method), start, errorNumber)
+	}
+}
+
+// getRESTAction returns VIS action of REST method.
+func getRESTAction(method string) (action string) {
+	switch method {
+	case http.MethodGet:
+		return ActionGet
+
+	case http.MethodPut:
+		return ActionSet
+
+	default:
+		return method
+	}
+}
+
+func (writer *restStatusWriter) WriteHeader(status int) {
+	writer.status = status
+
+	writer.ResponseWriter.WriteHeader(status)
+}
+
+func (server *Server) handleRESTRequest(w http.ResponseWriter, r *http.Request) {
+	path := strings.ReplaceAll(strings.Trim(r.URL.Path, "/"), "/", ".")
+
+	log.WithFields(log.Fields{
+		"method": r.Method, "path": path, "remoteAddr": r.RemoteAddr,
+	}).Debug("Process REST request")
+
+	if path == "" {
+		server.writeRESTResponse(w, nil, createErrorInfo(aoserrors.New("data path is not specified"), server.protocolVersion))
+		return
+	}
+
+	authInfo, token, err := server.getRESTAuthInfo(r)
+	if err != nil {
+		log.Errorf("REST authorization error: %s", err)
+
+		err = aoserrors.New("service not authorized")
+
+		auditAuthorize(server.auditLogger, metrics.ProtocolREST, r.RemoteAddr, nil, err)
+
+		server.writeRESTResponse(w, nil, createErrorInfo(err, server.protocolVersion))
+
+		return
+	}
+
+	if err = server.checkPeerRequestRate(
+		getPeerKey(r.RemoteAddr, token, authInfo), authInfo, getRESTAction(r.Method), time.Now()); err != nil {
+		log.WithField("remoteAddr", r.RemoteAddr).Warn(err)
+
+		server.writeRESTResponse(w, nil, createErrorInfo(err, server.protocolVersion))
+
+		return
+	}
+
+	var (
+		response  interface{}
+		errorInfo *visprotocol.ErrorInfo
+	)
+
+	switch r.Method {
+	case http.MethodGet:
+		response, errorInfo = server.processRESTGetRequest(path, authInfo)
+
+	case http.MethodPut:
+		response, errorInfo = server.processRESTSetRequest(path, r, authInfo)
+
+	default:
+		w.Header().Set("Allow", strings.Join([]string{http.MethodGet, http.MethodPut}, ", "))
+
+		errorInfo = &visprotocol.ErrorInfo{
+			Number: http.StatusMethodNotAllowed, Message: "method " + r.Method + " is not allowed",
+		}
+	}
+
+	server.writeRESTResponse(w, response, errorInfo)
+}
+
+// getRESTAuthInfo returns client authorization info and token from bearer token of Authorization header.
+// Not authorized info is returned if header is absent.
+func (server *Server) getRESTAuthInfo(
+	r *http.Request,
+) (authInfo *dataprovider.AuthInfo, token string, err error) {
+	authorization := r.Header.Get("Authorization")
+	if authorization == "" {
+		return &dataprovider.AuthInfo{}, "", nil
+	}
+
+	if !strings.HasPrefix(authorization, bearerPrefix) {
+		return nil, "", aoserrors.New("unsupported authorization scheme")
+	}
+
+	token = strings.TrimSpace(strings.TrimPrefix(authorization, bearerPrefix))
+	if token == "" {
+		return nil, "", aoserrors.New("empty token authorization")
+	}
+
+	permissions, identity, expiresAt, err := authorizeByToken(server.GetPermissionProvider(), token)
+	if err != nil {
+		return nil, "", aoserrors.Wrap(err)
+	}
+
+	if getAuthTTL(server.authTTL, expiresAt) <= 0 {
+		return nil, "", aoserrors.New("token is expired")
+	}
+
+	if authInfo, err = dataprovider.NewAuthInfo(permissions, identity); err != nil {
+		return nil, "", aoserrors.Wrap(err)
+	}
+
+	return authInfo, token, nil
+}
+
+func (server *Server) processRESTGetRequest(
+	path string, authInfo *dataprovider.AuthInfo,
+) (response interface{}, errorInfo *visprotocol.ErrorInfo) {
+	if server.protocolVersion == protocolVersion2 {
+		dataPoints, err := server.dataProvider.GetDataPoints(path, authInfo)
+		if err != nil {
+			return nil, createErrorInfo(err, server.protocolVersion)
+		}
+
+		return &getResponseV2{
+			MessageHeader: visprotocol.MessageHeader{Action: ActionGet},
+			Data:          convertDataPointsV2(dataPoints),
+			Timestamp:     formatTimestampV2(time.Now()),
+		}, nil
+	}
+
+	vehicleData, err := server.dataProvider.GetData(path, authInfo)
+	if err != nil {
+		return nil, createErrorInfo(err, server.protocolVersion)
+	}
+
+	return &visprotocol.GetResponse{
+		MessageHeader: visprotocol.MessageHeader{Action: ActionGet},
+		Value:         vehicleData,
+		Timestamp:     getCurTime(),
+	}, nil
+}
+
+func (server *Server) processRESTSetRequest(
+	path string, r *http.Request, authInfo *dataprovider.AuthInfo,
+) (response interface{}, errorInfo *visprotocol.ErrorInfo) {
+	var request restSetRequest
+
+	if err := json.NewDecoder(io.LimitReader(r.Body, restMaxBodySize)).Decode(&request); err != nil {
+		return nil, createErrorInfo(aoserrors.Errorf("invalid request body: %v", err), server.protocolVersion)
+	}
+
+	if len(request.Value) == 0 {
+		return nil, createErrorInfo(aoserrors.New("value is not specified"), server.protocolVersion)
+	}
+
+	var value interface{}
+
+	if err := json.Unmarshal(request.Value, &value); err != nil {
+		return nil, createErrorInfo(aoserrors.Errorf("invalid value: %v", err), server.protocolVersion)
+	}
+
+	if err := setDataWithAudit(
+		server.dataProvider, server.auditLogger, metrics.ProtocolREST, r.RemoteAddr, authInfo, path, value,
+	); err != nil {
+		return nil, createErrorInfo(err, server.protocolVersion)
+	}
+
+	return &visprotocol.SetResponse{
+		MessageHeader: visprotocol.MessageHeader{Action: ActionSet},
+		Timestamp:     getCurTime(),
+	}, nil
+}
+
+// writeRESTResponse writes response body. In case of error, HTTP status is set to VIS error number and error object
+// is sent as body.
+func (server *Server) writeRESTResponse(
+	w http.ResponseWriter, response interface{}, errorInfo *visprotocol.ErrorInfo,
+) {
+	status := http.StatusOK
+
+	if errorInfo != nil {
+		status = errorInfo.Number
+
+		if server.protocolVersion == protocolVersion2 {
+			response = &restErrorResponseV2{Error: errorInfo, Timestamp: formatTimestampV2(time.Now())}
+		} else {
+			response = &restErrorResponse{Error: errorInfo, Timestamp: getCurTime()}
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		log.Errorf("Can't write REST response: %s", err)
+	}
+}
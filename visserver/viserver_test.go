@@ -19,10 +19,20 @@ package visserver_test
 
 import (
 	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
 	"net/url"
 	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -30,19 +40,45 @@ import (
 	"github.com/aosedge/aos_common/api/visprotocol"
 	"github.com/aosedge/aos_common/wsclient"
 	log "github.com/sirupsen/logrus"
-
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	kuksa "github.com/aosedge/aos_vis/api/kuksa/val/v1"
+	"github.com/aosedge/aos_vis/audit"
 	"github.com/aosedge/aos_vis/config"
 	"github.com/aosedge/aos_vis/dataprovider"
 	"github.com/aosedge/aos_vis/visserver"
 )
 
 const (
-	serverURL = "wss://localhost:443"
-	caCert    = "../data/rootCA.pem"
+	serverURL     = "wss://localhost:443"
+	restServerURL = "https://localhost:8088"
+	grpcServerURL = "localhost:8089"
+	caCert        = "../data/rootCA.pem"
+	expiredToken  = "expiredUID"
 )
 
 type permissionProvider struct{}
 
+type identityProvider struct {
+	permissionProvider
+	validity time.Duration
+}
+
+type slowPermissionProvider struct {
+	permissionProvider
+	release chan struct{}
+}
+
+/*******************************************************************************
+ * Vars
+ ******************************************************************************/
+
+var serverConfig config.Config
+
 /*******************************************************************************
  * Init
  ******************************************************************************/
@@ -64,6 +100,33 @@ func (provider *permissionProvider) GetVisPermissionByToken(token string) (permi
 	return permission, nil
 }
 
+func (provider *slowPermissionProvider) GetVisPermissionByToken(token string) (
+	permissions map[string]string, err error,
+) {
+	<-provider.release
+
+	return provider.permissionProvider.GetVisPermissionByToken(token)
+}
+
+func (provider *identityProvider) GetVisIdentityByToken(token string) (
+	permissions map[string]string, identity *dataprovider.ClientIdentity, expiresAt time.Time, err error,
+) {
+	if permissions, err = provider.GetVisPermissionByToken(token); err != nil {
+		return nil, nil, time.Time{}, err
+	}
+
+	if provider.validity != 0 {
+		expiresAt = time.Now().Add(provider.validity)
+	}
+
+	if token == expiredToken {
+		expiresAt = time.Now().Add(-time.Second)
+	}
+
+	return permissions, &dataprovider.ClientIdentity{ServiceID: "service1", SubjectID: "subject1", Instance: 1},
+		expiresAt, nil
+}
+
 /*******************************************************************************
  * Main
  ******************************************************************************/
@@ -74,6 +137,7 @@ func TestMain(m *testing.M) {
 	configJSON := `{
 		"VISCert": "../data/wwwivi.crt.pem",
 		"VISKey":  "../data/wwwivi.key.pem",
+		"History": [{"Path": "Signal.Cabin.Door.Row2.Left.Window.Position", "MaxCount": 2}],
 		"Adapters":[
 			{
 				"Plugin":"testadapter",
@@ -84,7 +148,7 @@ func TestMain(m *testing.M) {
 		
 						"Signal.Drivetrain.InternalCombustionEngine.RPM": {"Value": 1000, "ReadOnly": true},
 			
-						"Signal.Body.Trunk.IsLocked":                     {"Value": false},
+						"Signal.Body.Trunk.IsLocked":                     {"Value": false, "DataType": "boolean"},
 						"Signal.Body.Trunk.IsOpen":                       {"Value": true},
 			
 						"Signal.Cabin.Door.Row1.Right.IsLocked":          {"Value": true},
@@ -116,6 +180,14 @@ func TestMain(m *testing.M) {
 
 	cfg.ServerURL = url.Host
 
+	if url, err = url.Parse(restServerURL); err != nil {
+		log.Fatalf("Can't parse url: %s", err)
+	}
+
+	cfg.RESTServerURL = url.Host
+	cfg.GRPCServerURL = grpcServerURL
+	serverConfig = cfg
+
 	dataprovider.RegisterPlugin("testadapter", func(configJSON json.RawMessage) (
 		adapter dataprovider.DataAdapter, err error,
 	) {
@@ -184,6 +256,62 @@ func TestGetNoAuth(t *testing.T) {
 	}
 }
 
+func TestGetMetadata(t *testing.T) {
+	client, err := wsclient.New("TestClient", wsclient.ClientParam{CaCertFile: caCert}, nil)
+	if err != nil {
+		t.Fatalf("Can't create client: %s", err)
+	}
+	defer client.Close()
+
+	if err = client.Connect(serverURL); err != nil {
+		t.Fatalf("Can't connect to server: %s", err)
+	}
+
+	metadataRequest := visprotocol.MetadataRequest{
+		MessageHeader: visprotocol.MessageHeader{
+			Action:    visprotocol.ActionGetMetadata,
+			RequestID: "8766",
+		},
+		Path: "Attribute.*",
+	}
+	metadataResponse := visprotocol.MetadataResponse{}
+
+	if err = client.SendRequest("RequestID", metadataRequest.RequestID, &metadataRequest, &metadataResponse); err != nil {
+		t.Errorf("Send request error: %s", err)
+	}
+
+	if metadataResponse.Error != nil {
+		t.Fatalf("Get metadata request error: %s", metadataResponse.Error.Message)
+	}
+
+	metadata, ok := metadataResponse.Metadata.(map[string]interface{})
+	if !ok {
+		t.Fatalf("Wrong metadata type: %T", metadataResponse.Metadata)
+	}
+
+	attribute, ok := metadata["Attribute"].(map[string]interface{})
+	if !ok {
+		t.Fatal("Attribute branch not found")
+	}
+
+	if attribute["type"] != "branch" {
+		t.Errorf("Wrong attribute node type: %v", attribute["type"])
+	}
+
+	// Private paths require authorization
+
+	metadataRequest.Path = "Signal.Body.*"
+	metadataResponse = visprotocol.MetadataResponse{}
+
+	if err = client.SendRequest("RequestID", metadataRequest.RequestID, &metadataRequest, &metadataResponse); err != nil {
+		t.Errorf("Send request error: %s", err)
+	}
+
+	if metadataResponse.Error == nil || metadataResponse.Error.Number != 401 {
+		t.Fatal("Should be error 401")
+	}
+}
+
 func TestGet(t *testing.T) {
 	client, err := wsclient.New("TestClient", wsclient.ClientParam{CaCertFile: caCert}, nil)
 	if err != nil {
@@ -240,6 +368,114 @@ func TestGet(t *testing.T) {
 	}
 }
 
+func TestGetHistory(t *testing.T) {
+	client, err := wsclient.New("TestClient", wsclient.ClientParam{CaCertFile: caCert}, nil)
+	if err != nil {
+		t.Fatalf("Can't create client: %s", err)
+	}
+	defer client.Close()
+
+	if err = client.Connect(serverURL); err != nil {
+		t.Fatalf("Can't connect to server: %s", err)
+	}
+
+	authRequest := visprotocol.AuthRequest{
+		MessageHeader: visprotocol.MessageHeader{Action: visprotocol.ActionAuth, RequestID: "historyAuth"},
+		Tokens:        visprotocol.Tokens{Authorization: "appUID"},
+	}
+	authResponse := visprotocol.AuthResponse{}
+
+	if err = client.SendRequest("RequestID", authRequest.RequestID, &authRequest, &authResponse); err != nil {
+		t.Fatalf("Send request error: %s", err)
+	}
+
+	for i, position := range []int{10, 20, 30} {
+		setRequest := visprotocol.SetRequest{
+			MessageHeader: visprotocol.MessageHeader{
+				Action: visprotocol.ActionSet, RequestID: "historySet" + strconv.Itoa(i),
+			},
+			Path:  "Signal.Cabin.Door.Row2.Left.Window.Position",
+			Value: position,
+		}
+		setResponse := visprotocol.SetResponse{}
+
+		if err = client.SendRequest("RequestID", setRequest.RequestID, &setRequest, &setResponse); err != nil {
+			t.Fatalf("Send request error: %s", err)
+		}
+
+		if setResponse.Error != nil {
+			t.Fatalf("Set request error: %s", setResponse.Error.Message)
+		}
+	}
+
+	type historyResponse struct {
+		visprotocol.MessageHeader
+		Error *visprotocol.ErrorInfo `json:"error"`
+		Value map[string][]struct {
+			Value     interface{} `json:"value"`
+			Timestamp int64       `json:"timestamp"`
+		} `json:"value"`
+	}
+
+	type historyRequest struct {
+		visprotocol.MessageHeader
+		Path    string `json:"path"`
+		Filters string `json:"filters"`
+	}
+
+	request := historyRequest{
+		MessageHeader: visprotocol.MessageHeader{Action: visprotocol.ActionGet, RequestID: "history"},
+		Path:          "Signal.Cabin.Door.Row2.Left.Window.Position",
+		Filters:       `{"history": 60000}`,
+	}
+
+	// History is recorded asynchronously
+	for start := time.Now(); ; time.Sleep(10 * time.Millisecond) {
+		var response historyResponse
+
+		if err = client.SendRequest("RequestID", request.RequestID, &request, &response); err != nil {
+			t.Fatalf("Send request error: %s", err)
+		}
+
+		if response.Error != nil {
+			t.Fatalf("Get history error: %s", response.Error.Message)
+		}
+
+		dataPoints := response.Value["Signal.Cabin.Door.Row2.Left.Window.Position"]
+
+		if len(dataPoints) == 2 && dataPoints[0].Value == 20.0 && dataPoints[1].Value == 30.0 &&
+			dataPoints[0].Timestamp <= dataPoints[1].Timestamp {
+			break
+		}
+
+		if time.Since(start) > time.Second {
+			t.Fatalf("Wrong history: %v", dataPoints)
+		}
+	}
+
+	request.Path = "Signal.Cabin.Door.Row2.Right.Window.Position"
+
+	var response historyResponse
+
+	if err = client.SendRequest("RequestID", request.RequestID, &request, &response); err != nil {
+		t.Fatalf("Send request error: %s", err)
+	}
+
+	if response.Error == nil || response.Error.Number != 400 {
+		t.Errorf("Error 400 expected: %v", response.Error)
+	}
+
+	request.Filters = `{"history": -1}`
+
+	if err = client.SendRequest("RequestID", request.RequestID, &request, &response); err != nil {
+		t.Fatalf("Send request error: %s", err)
+	}
+
+	if response.Error == nil || response.Error.Number != 400 {
+		t.Errorf("Error 400 expected: %v", response.Error)
+	}
+}
+
 func TestSet(t *testing.T) {
 	client, err := wsclient.New("TestClient", wsclient.ClientParam{CaCertFile: caCert}, nil)
 	if err != nil {
@@ -292,6 +528,29 @@ func TestSet(t *testing.T) {
 	if setResponse.Error != nil {
 		t.Fatalf("Set request error: %s", setResponse.Error.Message)
 	}
+
+	// Invalid value type
+	setRequest = visprotocol.SetRequest{
+		MessageHeader: visprotocol.MessageHeader{
+			Action:    visprotocol.ActionSet,
+			RequestID: "8889",
+		},
+		Path:  "Signal.Body.Trunk.IsLocked",
+		Value: "yes",
+	}
+	setResponse = visprotocol.GetResponse{}
+
+	if err = client.SendRequest("RequestID", setRequest.RequestID, &setRequest, &setResponse); err != nil {
+		t.Errorf("Send request error: %s", err)
+	}
+
+	if setResponse.Error == nil {
+		t.Fatal("Error expected for invalid value")
+	}
+
+	if setResponse.Error.Number != 400 || !strings.Contains(setResponse.Error.Message, "Signal.Body.Trunk.IsLocked") {
+		t.Errorf("Wrong error: %d %s", setResponse.Error.Number, setResponse.Error.Message)
+	}
 }
 
 func TestSubscribeUnsubscribe(t *testing.T) {
@@ -437,6 +696,39 @@ func TestSubscribeUnsubscribe(t *testing.T) {
 		t.Fatalf("Unsubscribe request error: %s", unsubscribeResponse.Error.Message)
 	}
 
+	// Subscribe with snapshot
+
+	snapshotRequest := struct {
+		visprotocol.SubscribeRequest
+		Snapshot bool `json:"snapshot"`
+	}{
+		SubscribeRequest: visprotocol.SubscribeRequest{
+			MessageHeader: visprotocol.MessageHeader{Action: visprotocol.ActionSubscribe, RequestID: "1005"},
+			Path:          "Signal.Cabin.Door.Row1.Right.Window.Position",
+		},
+		Snapshot: true,
+	}
+	snapshotResponse := visprotocol.SubscribeResponse{}
+
+	if err = client.SendRequest(
+		"RequestID", snapshotRequest.RequestID, &snapshotRequest, &snapshotResponse); err != nil {
+		t.Errorf("Send request error: %s", err)
+	}
+
+	if snapshotResponse.Error != nil {
+		t.Fatalf("Subscribe request error: %s", snapshotResponse.Error.Message)
+	}
+
+	select {
+	case notification := <-notificationChannel:
+		if notification.SubscriptionID != snapshotResponse.SubscriptionID || notification.Value != 123.0 {
+			t.Errorf("Unexpected snapshot notification: %v", notification)
+		}
+
+	case <-time.After(1 * time.Second):
+		t.Fatal("Waiting for snapshot notification timeout")
+	}
+
 	// UnsubscribeAll
 
 	unsubscribeAllRequest := visprotocol.UnsubscribeAllRequest{
@@ -455,3 +747,1730 @@ func TestSubscribeUnsubscribe(t *testing.T) {
 		t.Fatalf("Unsubscribe all request error: %s", setResponse.Error.Message)
 	}
 }
+
+func TestSubscribeOverflowPolicy(t *testing.T) {
+	client, err := wsclient.New("TestClient", wsclient.ClientParam{CaCertFile: caCert}, nil)
+	if err != nil {
+		t.Fatalf("Can't create client: %s", err)
+	}
+	defer client.Close()
+
+	if err = client.Connect(serverURL); err != nil {
+		t.Fatalf("Can't connect to server: %s", err)
+	}
+
+	authRequest := visprotocol.AuthRequest{
+		MessageHeader: visprotocol.MessageHeader{Action: visprotocol.ActionAuth, RequestID: "5001"},
+		Tokens:        visprotocol.Tokens{Authorization: "appUID"},
+	}
+	authResponse := visprotocol.AuthResponse{}
+
+	if err = client.SendRequest("RequestID", authRequest.RequestID, &authRequest, &authResponse); err != nil {
+		t.Fatalf("Send request error: %s", err)
+	}
+
+	for i, item := range []struct {
+		overflow    string
+		errorNumber int
+	}{{"coalesce", 0}, {"disconnect", 0}, {"unknown", 400}} {
+		subscribeRequest := struct {
+			visprotocol.SubscribeRequest
+			Overflow string `json:"overflow"`
+		}{
+			SubscribeRequest: visprotocol.SubscribeRequest{
+				MessageHeader: visprotocol.MessageHeader{
+					Action: visprotocol.ActionSubscribe, RequestID: fmt.Sprintf("500%d", i+2),
+				},
+				Path: "Signal.Cabin.Door.Row1.*",
+			},
+			Overflow: item.overflow,
+		}
+		subscribeResponse := visprotocol.SubscribeResponse{}
+
+		if err = client.SendRequest(
+			"RequestID", subscribeRequest.RequestID, &subscribeRequest, &subscribeResponse); err != nil {
+			t.Fatalf("Send request error: %s", err)
+		}
+
+		errorNumber := 0
+		if subscribeResponse.Error != nil {
+			errorNumber = subscribeResponse.Error.Number
+		}
+
+		if errorNumber != item.errorNumber {
+			t.Errorf("Wrong %s subscribe error: %v", item.overflow, subscribeResponse.Error)
+		}
+	}
+
+	unsubscribeAllRequest := visprotocol.UnsubscribeAllRequest{
+		MessageHeader: visprotocol.MessageHeader{Action: visprotocol.ActionUnsubscribeAll, RequestID: "5010"},
+	}
+	unsubscribeAllResponse := visprotocol.UnsubscribeAllResponse{}
+
+	if err = client.SendRequest(
+		"RequestID", unsubscribeAllRequest.RequestID, &unsubscribeAllRequest, &unsubscribeAllResponse); err != nil {
+		t.Fatalf("Send request error: %s", err)
+	}
+}
+
+func TestSubscribeFilter(t *testing.T) {
+	notificationChannel := make(chan visprotocol.SubscriptionNotification, 1)
+
+	client, err := wsclient.New("TestClient", wsclient.ClientParam{CaCertFile: caCert}, func(data []byte) {
+		var notification visprotocol.SubscriptionNotification
+
+		if err := json.Unmarshal(data, &notification); err != nil {
+			t.Errorf("Error parsing notification: %s", err)
+		}
+
+		notificationChannel <- notification
+	})
+	if err != nil {
+		t.Fatalf("Can't create client: %s", err)
+	}
+	defer client.Close()
+
+	if err = client.Connect(serverURL); err != nil {
+		t.Fatalf("Can't connect to server: %s", err)
+	}
+
+	authRequest := visprotocol.AuthRequest{
+		MessageHeader: visprotocol.MessageHeader{
+			Action:    visprotocol.ActionAuth,
+			RequestID: "12345",
+		},
+		Tokens: visprotocol.Tokens{
+			Authorization: "appUID",
+		},
+	}
+	authResponse := visprotocol.AuthResponse{}
+
+	if err = client.SendRequest("RequestID", authRequest.RequestID, &authRequest, &authResponse); err != nil {
+		t.Errorf("Send request error: %s", err)
+	}
+
+	if authResponse.Error != nil {
+		t.Fatalf("Auth request error: %s", authResponse.Error.Message)
+	}
+
+	// Malformed filter
+
+	subscribeRequest := visprotocol.SubscribeRequest{
+		MessageHeader: visprotocol.MessageHeader{
+			Action:    visprotocol.ActionSubscribe,
+			RequestID: "2001",
+		},
+		Path:    "Signal.Cabin.Door.Row2.Right.Window.Position",
+		Filters: `{"range": {"above": 200, "below": 100}}`,
+	}
+	subscribeResponse := visprotocol.SubscribeResponse{}
+
+	if err = client.SendRequest(
+		"RequestID", subscribeRequest.RequestID, &subscribeRequest, &subscribeResponse); err != nil {
+		t.Errorf("Send request error: %s", err)
+	}
+
+	if subscribeResponse.Error == nil || subscribeResponse.Error.Number != 400 {
+		t.Fatal("Should be error 400")
+	}
+
+	// Range and min change filter
+
+	subscribeRequest.RequestID = "2002"
+	subscribeRequest.Filters = `{"range": {"above": 10, "below": 90}, "minChange": 5}`
+	subscribeResponse = visprotocol.SubscribeResponse{}
+
+	if err = client.SendRequest(
+		"RequestID", subscribeRequest.RequestID, &subscribeRequest, &subscribeResponse); err != nil {
+		t.Errorf("Send request error: %s", err)
+	}
+
+	if subscribeResponse.Error != nil {
+		t.Fatalf("Subscribe request error: %s", subscribeResponse.Error.Message)
+	}
+
+	type testData struct {
+		value    int
+		notified bool
+	}
+
+	for _, item := range []testData{{50, true}, {5, false}, {52, false}, {60, true}, {95, false}, {30, true}} {
+		setRequest := visprotocol.SetRequest{
+			MessageHeader: visprotocol.MessageHeader{
+				Action:    visprotocol.ActionSet,
+				RequestID: "2003",
+			},
+			Path:  "Signal.Cabin.Door.Row2.Right.Window.Position",
+			Value: item.value,
+		}
+		setResponse := visprotocol.SetResponse{}
+
+		if err = client.SendRequest("RequestID", setRequest.RequestID, &setRequest, &setResponse); err != nil {
+			t.Errorf("Send request error: %s", err)
+		}
+
+		if setResponse.Error != nil {
+			t.Fatalf("Set request error: %s", setResponse.Error.Message)
+		}
+
+		select {
+		case notification := <-notificationChannel:
+			if !item.notified {
+				t.Errorf("Unexpected notification for value: %d", item.value)
+				continue
+			}
+
+			if notification.SubscriptionID != subscribeResponse.SubscriptionID {
+				t.Errorf("Unexpected subscription ID: %s", notification.SubscriptionID)
+			}
+
+			if value, ok := notification.Value.(float64); !ok || value != float64(item.value) {
+				t.Errorf("Unexpected value: %v", notification.Value)
+			}
+
+		case <-time.After(200 * time.Millisecond):
+			if item.notified {
+				t.Errorf("Waiting for notification timeout, value: %d", item.value)
+			}
+		}
+	}
+
+	unsubscribeAllRequest := visprotocol.UnsubscribeAllRequest{
+		MessageHeader: visprotocol.MessageHeader{
+			Action:    visprotocol.ActionUnsubscribeAll,
+			RequestID: "2004",
+		},
+	}
+	unsubscribeAllResponse := visprotocol.UnsubscribeAllResponse{}
+
+	if err = client.SendRequest(
+		"RequestID", unsubscribeAllRequest.RequestID, &unsubscribeAllRequest, &unsubscribeAllResponse); err != nil {
+		t.Errorf("Send request error: %s", err)
+	}
+
+	// Interval filter
+
+	subscribeRequest.RequestID = "2005"
+	subscribeRequest.Filters = `{"interval": 300}`
+	subscribeResponse = visprotocol.SubscribeResponse{}
+
+	if err = client.SendRequest(
+		"RequestID", subscribeRequest.RequestID, &subscribeRequest, &subscribeResponse); err != nil {
+		t.Errorf("Send request error: %s", err)
+	}
+
+	if subscribeResponse.Error != nil {
+		t.Fatalf("Subscribe request error: %s", subscribeResponse.Error.Message)
+	}
+
+	for i, value := range []int{1, 2, 3} {
+		setRequest := visprotocol.SetRequest{
+			MessageHeader: visprotocol.MessageHeader{
+				Action:    visprotocol.ActionSet,
+				RequestID: "2006" + strconv.Itoa(i),
+			},
+			Path:  "Signal.Cabin.Door.Row2.Right.Window.Position",
+			Value: value,
+		}
+		setResponse := visprotocol.SetResponse{}
+
+		if err = client.SendRequest("RequestID", setRequest.RequestID, &setRequest, &setResponse); err != nil {
+			t.Errorf("Send request error: %s", err)
+		}
+	}
+
+	// First value is sent immediately, last one after interval
+
+	for _, expectedValue := range []float64{1, 3} {
+		select {
+		case notification := <-notificationChannel:
+			if value, ok := notification.Value.(float64); !ok || value != expectedValue {
+				t.Errorf("Unexpected value: %v", notification.Value)
+			}
+
+		case <-time.After(time.Second):
+			t.Fatal("Waiting for subscription notification timeout")
+		}
+	}
+
+	select {
+	case notification := <-notificationChannel:
+		t.Errorf("Unexpected notification: %v", notification.Value)
+
+	case <-time.After(500 * time.Millisecond):
+	}
+
+	for i, value := range []int{4, 5} {
+		setRequest := visprotocol.SetRequest{
+			MessageHeader: visprotocol.MessageHeader{
+				Action:    visprotocol.ActionSet,
+				RequestID: "2007" + strconv.Itoa(i),
+			},
+			Path:  "Signal.Cabin.Door.Row2.Right.Window.Position",
+			Value: value,
+		}
+		setResponse := visprotocol.SetResponse{}
+
+		if err = client.SendRequest("RequestID", setRequest.RequestID, &setRequest, &setResponse); err != nil {
+			t.Errorf("Send request error: %s", err)
+		}
+	}
+
+	if err = client.SendRequest(
+		"RequestID", unsubscribeAllRequest.RequestID, &unsubscribeAllRequest, &unsubscribeAllResponse); err != nil {
+		t.Errorf("Send request error: %s", err)
+	}
+
+	// Value held back by interval is sent on unsubscribe
+
+	for _, expectedValue := range []float64{4, 5} {
+		select {
+		case notification := <-notificationChannel:
+			if value, ok := notification.Value.(float64); !ok || value != expectedValue {
+				t.Errorf("Unexpected value: %v", notification.Value)
+			}
+
+		case <-time.After(time.Second):
+			t.Fatal("Waiting for subscription notification timeout")
+		}
+	}
+}
+
+func TestAuthExpiration(t *testing.T) {
+	const ttlServerURL = "wss://localhost:8443"
+
+	cfg := serverConfig
+	cfg.ServerURL = "localhost:8443"
+	cfg.RESTServerURL = ""
+	cfg.GRPCServerURL = ""
+	cfg.AuthTTL = 1
+
+	server, err := visserver.New(&cfg, &permissionProvider{})
+	if err != nil {
+		t.Fatalf("Can't create ws server: %s", err)
+	}
+	defer server.Close()
+
+	time.Sleep(time.Second)
+
+	notificationChannel := make(chan visprotocol.SubscriptionNotification, 1)
+
+	client, err := wsclient.New("TestClient", wsclient.ClientParam{CaCertFile: caCert}, func(data []byte) {
+		var notification visprotocol.SubscriptionNotification
+
+		if err := json.Unmarshal(data, &notification); err != nil {
+			t.Errorf("Error parsing notification: %s", err)
+		}
+
+		notificationChannel <- notification
+	})
+	if err != nil {
+		t.Fatalf("Can't create client: %s", err)
+	}
+	defer client.Close()
+
+	if err = client.Connect(ttlServerURL); err != nil {
+		t.Fatalf("Can't connect to server: %s", err)
+	}
+
+	authRequest := visprotocol.AuthRequest{
+		MessageHeader: visprotocol.MessageHeader{
+			Action:    visprotocol.ActionAuth,
+			RequestID: "3001",
+		},
+		Tokens: visprotocol.Tokens{
+			Authorization: "appUID",
+		},
+	}
+	authResponse := visprotocol.AuthResponse{}
+
+	if err = client.SendRequest("RequestID", authRequest.RequestID, &authRequest, &authResponse); err != nil {
+		t.Errorf("Send request error: %s", err)
+	}
+
+	if authResponse.Error != nil {
+		t.Fatalf("Auth request error: %s", authResponse.Error.Message)
+	}
+
+	if authResponse.TTL != 1 {
+		t.Errorf("Wrong TTL: %d", authResponse.TTL)
+	}
+
+	subscribeRequest := visprotocol.SubscribeRequest{
+		MessageHeader: visprotocol.MessageHeader{
+			Action:    visprotocol.ActionSubscribe,
+			RequestID: "3002",
+		},
+		Path: "Signal.Cabin.Door.Row1.Left.Window.Position",
+	}
+	subscribeResponse := visprotocol.SubscribeResponse{}
+
+	if err = client.SendRequest(
+		"RequestID", subscribeRequest.RequestID, &subscribeRequest, &subscribeResponse); err != nil {
+		t.Errorf("Send request error: %s", err)
+	}
+
+	if subscribeResponse.Error != nil {
+		t.Fatalf("Subscribe request error: %s", subscribeResponse.Error.Message)
+	}
+
+	// Wait for expiration notification
+
+	select {
+	case notification := <-notificationChannel:
+		if notification.SubscriptionID != subscribeResponse.SubscriptionID {
+			t.Errorf("Unexpected subscription ID: %s", notification.SubscriptionID)
+		}
+
+		if notification.Error == nil || notification.Error.Number != 401 {
+			t.Errorf("Should be error 401")
+		}
+
+	case <-time.After(2 * time.Second):
+		t.Fatal("Waiting for expiration notification timeout")
+	}
+
+	getRequest := visprotocol.GetRequest{
+		MessageHeader: visprotocol.MessageHeader{
+			Action:    visprotocol.ActionGet,
+			RequestID: "3003",
+		},
+		Path: "Signal.Drivetrain.InternalCombustionEngine.RPM",
+	}
+	getResponse := visprotocol.GetResponse{}
+
+	if err = client.SendRequest("RequestID", getRequest.RequestID, &getRequest, &getResponse); err != nil {
+		t.Errorf("Send request error: %s", err)
+	}
+
+	if getResponse.Error == nil || getResponse.Error.Number != 401 {
+		t.Fatal("Should be error 401")
+	}
+
+	// Reauthorize on the same connection
+
+	authResponse = visprotocol.AuthResponse{}
+
+	if err = client.SendRequest("RequestID", authRequest.RequestID, &authRequest, &authResponse); err != nil {
+		t.Errorf("Send request error: %s", err)
+	}
+
+	if authResponse.Error != nil {
+		t.Fatalf("Auth request error: %s", authResponse.Error.Message)
+	}
+
+	getResponse = visprotocol.GetResponse{}
+
+	if err = client.SendRequest("RequestID", getRequest.RequestID, &getRequest, &getResponse); err != nil {
+		t.Errorf("Send request error: %s", err)
+	}
+
+	if getResponse.Error != nil {
+		t.Fatalf("Get request error: %s", getResponse.Error.Message)
+	}
+}
+
+func TestAuthTokenExpiration(t *testing.T) {
+	const (
+		tokenServerURL     = "wss://localhost:8450"
+		tokenRESTServerURL = "https://localhost:8456"
+	)
+
+	cfg := serverConfig
+	cfg.ServerURL = "localhost:8450"
+	cfg.RESTServerURL = "localhost:8456"
+	cfg.GRPCServerURL = ""
+
+	server, err := visserver.New(&cfg, &identityProvider{validity: time.Second})
+	if err != nil {
+		t.Fatalf("Can't create ws server: %s", err)
+	}
+	defer server.Close()
+
+	time.Sleep(time.Second)
+
+	client, err := wsclient.New("TestClient", wsclient.ClientParam{CaCertFile: caCert}, nil)
+	if err != nil {
+		t.Fatalf("Can't create client: %s", err)
+	}
+	defer client.Close()
+
+	if err = client.Connect(tokenServerURL); err != nil {
+		t.Fatalf("Can't connect to server: %s", err)
+	}
+
+	authRequest := visprotocol.AuthRequest{
+		MessageHeader: visprotocol.MessageHeader{Action: visprotocol.ActionAuth, RequestID: "3101"},
+		Tokens:        visprotocol.Tokens{Authorization: "appUID"},
+	}
+	authResponse := visprotocol.AuthResponse{}
+
+	if err = client.SendRequest("RequestID", authRequest.RequestID, &authRequest, &authResponse); err != nil {
+		t.Errorf("Send request error: %s", err)
+	}
+
+	if authResponse.Error != nil {
+		t.Fatalf("Auth request error: %s", authResponse.Error.Message)
+	}
+
+	// Token validity is less than configured authorization TTL
+	if authResponse.TTL != 1 {
+		t.Errorf("Wrong TTL: %d", authResponse.TTL)
+	}
+
+	time.Sleep(1500 * time.Millisecond)
+
+	getRequest := visprotocol.GetRequest{
+		MessageHeader: visprotocol.MessageHeader{Action: visprotocol.ActionGet, RequestID: "3102"},
+		Path:          "Signal.Drivetrain.InternalCombustionEngine.RPM",
+	}
+	getResponse := visprotocol.GetResponse{}
+
+	if err = client.SendRequest("RequestID", getRequest.RequestID, &getRequest, &getResponse); err != nil {
+		t.Errorf("Send request error: %s", err)
+	}
+
+	if getResponse.Error == nil || getResponse.Error.Number != 401 {
+		t.Error("Should be error 401")
+	}
+
+	// Already expired token is rejected over REST as well
+	caPEM, err := os.ReadFile(caCert)
+	if err != nil {
+		t.Fatalf("Can't read CA cert: %s", err)
+	}
+
+	certPool := x509.NewCertPool()
+	certPool.AppendCertsFromPEM(caPEM)
+
+	restClient := &http.Client{
+		Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: certPool, MinVersion: tls.VersionTLS12}},
+		Timeout:   5 * time.Second,
+	}
+
+	request, err := http.NewRequest(http.MethodGet, tokenRESTServerURL+"/Signal/Body/Trunk/IsLocked", nil)
+	if err != nil {
+		t.Fatalf("Can't create request: %s", err)
+	}
+
+	request.Header.Set("Authorization", "Bearer "+expiredToken)
+
+	response, err := restClient.Do(request)
+	if err != nil {
+		t.Fatalf("Can't send request: %s", err)
+	}
+	response.Body.Close()
+
+	if response.StatusCode != http.StatusUnauthorized {
+		t.Errorf("Wrong expired token status: %d", response.StatusCode)
+	}
+}
+
+func TestReload(t *testing.T) {
+	const reloadServerURL = "wss://localhost:8445"
+
+	cfg := serverConfig
+	cfg.ServerURL = "localhost:8445"
+	cfg.RESTServerURL = ""
+	cfg.GRPCServerURL = ""
+	cfg.History = nil
+	cfg.Adapters = []config.AdapterConfig{{
+		Plugin: "testadapter",
+		Params: json.RawMessage(
+			`{"Data": {"Signal.Body.Trunk.IsLocked": {"Value": false}, "Signal.Body.Trunk.IsOpen": {"Value": true}}}`),
+	}}
+
+	server, err := visserver.New(&cfg, &permissionProvider{})
+	if err != nil {
+		t.Fatalf("Can't create ws server: %s", err)
+	}
+	defer server.Close()
+
+	time.Sleep(time.Second)
+
+	notificationChannel := make(chan visprotocol.SubscriptionNotification, 2)
+
+	client, err := wsclient.New("TestClient", wsclient.ClientParam{CaCertFile: caCert}, func(data []byte) {
+		var notification visprotocol.SubscriptionNotification
+
+		if err := json.Unmarshal(data, &notification); err != nil {
+			t.Errorf("Error parsing notification: %s", err)
+		}
+
+		notificationChannel <- notification
+	})
+	if err != nil {
+		t.Fatalf("Can't create client: %s", err)
+	}
+	defer client.Close()
+
+	if err = client.Connect(reloadServerURL); err != nil {
+		t.Fatalf("Can't connect to server: %s", err)
+	}
+
+	authRequest := visprotocol.AuthRequest{
+		MessageHeader: visprotocol.MessageHeader{Action: visprotocol.ActionAuth, RequestID: "4001"},
+		Tokens:        visprotocol.Tokens{Authorization: "appUID"},
+	}
+	authResponse := visprotocol.AuthResponse{}
+
+	if err = client.SendRequest("RequestID", authRequest.RequestID, &authRequest, &authResponse); err != nil {
+		t.Fatalf("Send request error: %s", err)
+	}
+
+	subscriptionIDs := make(map[string]string)
+
+	for i, path := range []string{"Signal.Body.Trunk.*", "Signal.Body.Trunk.IsOpen"} {
+		subscribeRequest := visprotocol.SubscribeRequest{
+			MessageHeader: visprotocol.MessageHeader{
+				Action: visprotocol.ActionSubscribe, RequestID: fmt.Sprintf("400%d", i+2),
+			},
+			Path: path,
+		}
+		subscribeResponse := visprotocol.SubscribeResponse{}
+
+		if err = client.SendRequest(
+			"RequestID", subscribeRequest.RequestID, &subscribeRequest, &subscribeResponse); err != nil {
+			t.Fatalf("Send request error: %s", err)
+		}
+
+		if subscribeResponse.Error != nil {
+			t.Fatalf("Subscribe request error: %s", subscribeResponse.Error.Message)
+		}
+
+		subscriptionIDs[subscribeResponse.SubscriptionID] = path
+	}
+
+	// Signal.Body.Trunk.IsOpen is removed
+	cfg.Adapters = []config.AdapterConfig{{
+		Plugin: "testadapter",
+		Params: json.RawMessage(`{"Data": {"Signal.Body.Trunk.IsLocked": {"Value": false}}}`),
+	}}
+
+	if err = server.Reload(&cfg); err != nil {
+		t.Fatalf("Can't reload server: %s", err)
+	}
+
+	notified := make(map[string]bool)
+
+	for range subscriptionIDs {
+		select {
+		case notification := <-notificationChannel:
+			if _, ok := subscriptionIDs[notification.SubscriptionID]; !ok || notified[notification.SubscriptionID] {
+				t.Errorf("Unexpected subscription ID: %s", notification.SubscriptionID)
+			}
+
+			if notification.Error == nil || notification.Error.Number != 404 {
+				t.Errorf("Should be error 404")
+			}
+
+			notified[notification.SubscriptionID] = true
+
+		case <-time.After(2 * time.Second):
+			t.Fatal("Waiting for removed paths notification timeout")
+		}
+	}
+
+	// Subscription without paths is terminated, wildcard subscription is kept
+	for i, item := range []struct {
+		path       string
+		terminated bool
+	}{{"Signal.Body.Trunk.*", false}, {"Signal.Body.Trunk.IsOpen", true}} {
+		var subscriptionID string
+
+		for id, path := range subscriptionIDs {
+			if path == item.path {
+				subscriptionID = id
+			}
+		}
+
+		unsubscribeRequest := visprotocol.UnsubscribeRequest{
+			MessageHeader: visprotocol.MessageHeader{
+				Action: visprotocol.ActionUnsubscribe, RequestID: fmt.Sprintf("401%d", i),
+			},
+			SubscriptionID: subscriptionID,
+		}
+		unsubscribeResponse := visprotocol.UnsubscribeResponse{}
+
+		if err = client.SendRequest(
+			"RequestID", unsubscribeRequest.RequestID, &unsubscribeRequest, &unsubscribeResponse); err != nil {
+			t.Fatalf("Send request error: %s", err)
+		}
+
+		if (unsubscribeResponse.Error != nil) != item.terminated {
+			t.Errorf("Wrong unsubscribe %s result: %v", item.path, unsubscribeResponse.Error)
+		}
+	}
+}
+
+func TestAudit(t *testing.T) {
+	const auditServerURL = "wss://localhost:8446"
+
+	cfg := serverConfig
+	cfg.ServerURL = "localhost:8446"
+	cfg.RESTServerURL = ""
+	cfg.GRPCServerURL = ""
+	cfg.Audit = config.AuditConfig{File: filepath.Join(t.TempDir(), "audit.log")}
+
+	server, err := visserver.New(&cfg, &identityProvider{})
+	if err != nil {
+		t.Fatalf("Can't create ws server: %s", err)
+	}
+	defer server.Close()
+
+	time.Sleep(time.Second)
+
+	client, err := wsclient.New("TestClient", wsclient.ClientParam{CaCertFile: caCert}, nil)
+	if err != nil {
+		t.Fatalf("Can't create client: %s", err)
+	}
+	defer client.Close()
+
+	if err = client.Connect(auditServerURL); err != nil {
+		t.Fatalf("Can't connect to server: %s", err)
+	}
+
+	for i, token := range []string{"", "appUID"} {
+		authRequest := visprotocol.AuthRequest{
+			MessageHeader: visprotocol.MessageHeader{Action: visprotocol.ActionAuth, RequestID: fmt.Sprintf("600%d", i)},
+			Tokens:        visprotocol.Tokens{Authorization: token},
+		}
+		authResponse := visprotocol.AuthResponse{}
+
+		if err = client.SendRequest("RequestID", authRequest.RequestID, &authRequest, &authResponse); err != nil {
+			t.Fatalf("Send request error: %s", err)
+		}
+	}
+
+	for i, item := range []struct {
+		path  string
+		value interface{}
+	}{
+		{"Signal.Body.Trunk.IsLocked", true},
+		{"Signal.Drivetrain.InternalCombustionEngine.RPM", 2000},
+	} {
+		setRequest := visprotocol.SetRequest{
+			MessageHeader: visprotocol.MessageHeader{Action: visprotocol.ActionSet, RequestID: fmt.Sprintf("601%d", i)},
+			Path:          item.path,
+			Value:         item.value,
+		}
+		setResponse := visprotocol.SetResponse{}
+
+		if err = client.SendRequest("RequestID", setRequest.RequestID, &setRequest, &setResponse); err != nil {
+			t.Fatalf("Send request error: %s", err)
+		}
+	}
+
+	records, err := readAuditRecords(cfg.Audit.File)
+	if err != nil {
+		t.Fatalf("Can't read audit records: %s", err)
+	}
+
+	if len(records) != 4 {
+		t.Fatalf("Wrong audit records count: %d", len(records))
+	}
+
+	identity := dataprovider.ClientIdentity{ServiceID: "service1", SubjectID: "subject1", Instance: 1}
+
+	for _, record := range records {
+		if record.Protocol != "ws" || record.RemoteAddr == "" {
+			t.Errorf("Wrong audit record client: %v", record)
+		}
+	}
+
+	if records[0].Action != audit.ActionAuthorize || records[0].Result != audit.ResultFailure ||
+		records[0].ErrorNumber != 400 {
+		t.Errorf("Wrong authorize failure record: %v", records[0])
+	}
+
+	if records[1].Action != audit.ActionAuthorize || records[1].Result != audit.ResultSuccess ||
+		records[1].Identity == nil || *records[1].Identity != identity {
+		t.Errorf("Wrong authorize success record: %v", records[1])
+	}
+
+	if records[2].Action != audit.ActionSet || records[2].Result != audit.ResultSuccess ||
+		records[2].Path != "Signal.Body.Trunk.IsLocked" || records[2].Identity == nil ||
+		!reflect.DeepEqual(records[2].Changes, []dataprovider.DataChange{
+			{Path: "Signal.Body.Trunk.IsLocked", OldValue: false, NewValue: true},
+		}) {
+		t.Errorf("Wrong set success record: %v", records[2])
+	}
+
+	if records[3].Action != audit.ActionSet || records[3].Result != audit.ResultFailure ||
+		records[3].Path != "Signal.Drivetrain.InternalCombustionEngine.RPM" || records[3].Error == "" {
+		t.Errorf("Wrong set failure record: %v", records[3])
+	}
+}
+
+func TestLimits(t *testing.T) {
+	const limitsServerURL = "wss://localhost:8447"
+
+	cfg := serverConfig
+	cfg.ServerURL = "localhost:8447"
+	cfg.RESTServerURL = ""
+	cfg.GRPCServerURL = ""
+	cfg.Limits = config.LimitsConfig{
+		Default:    config.ClientLimitsConfig{RequestRates: map[string]float64{"get": 2}},
+		Authorized: &config.ClientLimitsConfig{MaxSubscriptions: 1, MaxSubscriptionPaths: 2},
+	}
+
+	server, err := visserver.New(&cfg, &permissionProvider{})
+	if err != nil {
+		t.Fatalf("Can't create ws server: %s", err)
+	}
+	defer server.Close()
+
+	time.Sleep(time.Second)
+
+	client, err := wsclient.New("TestClient", wsclient.ClientParam{CaCertFile: caCert}, nil)
+	if err != nil {
+		t.Fatalf("Can't create client: %s", err)
+	}
+	defer client.Close()
+
+	if err = client.Connect(limitsServerURL); err != nil {
+		t.Fatalf("Can't connect to server: %s", err)
+	}
+
+	// Get rate of not authorized client is limited
+	for i, errorNumber := range []int{0, 0, 429} {
+		if number := sendLimitsGetRequest(t, client, fmt.Sprintf("700%d", i)); number != errorNumber {
+			t.Errorf("Wrong get request %d error number: %d", i, number)
+		}
+	}
+
+	authRequest := visprotocol.AuthRequest{
+		MessageHeader: visprotocol.MessageHeader{Action: visprotocol.ActionAuth, RequestID: "7010"},
+		Tokens:        visprotocol.Tokens{Authorization: "appUID"},
+	}
+	authResponse := visprotocol.AuthResponse{}
+
+	if err = client.SendRequest("RequestID", authRequest.RequestID, &authRequest, &authResponse); err != nil {
+		t.Fatalf("Send request error: %s", err)
+	}
+
+	if authResponse.Error != nil {
+		t.Fatalf("Auth request error: %s", authResponse.Error.Message)
+	}
+
+	// Get rate of authorized client is not limited
+	for i := 0; i < 3; i++ {
+		if number := sendLimitsGetRequest(t, client, fmt.Sprintf("702%d", i)); number != 0 {
+			t.Errorf("Wrong get request %d error number: %d", i, number)
+		}
+	}
+
+	for i, item := range []struct {
+		path        string
+		errorNumber int
+	}{
+		{"Signal.Cabin.Door.Row1.*", 429},
+		{"Signal.Cabin.Door.Row1.Left.*", 0},
+		{"Signal.Body.Trunk.IsLocked", 429},
+	} {
+		subscribeRequest := visprotocol.SubscribeRequest{
+			MessageHeader: visprotocol.MessageHeader{
+				Action: visprotocol.ActionSubscribe, RequestID: fmt.Sprintf("703%d", i),
+			},
+			Path: item.path,
+		}
+		subscribeResponse := visprotocol.SubscribeResponse{}
+
+		if err = client.SendRequest(
+			"RequestID", subscribeRequest.RequestID, &subscribeRequest, &subscribeResponse); err != nil {
+			t.Fatalf("Send request error: %s", err)
+		}
+
+		errorNumber := 0
+		if subscribeResponse.Error != nil {
+			errorNumber = subscribeResponse.Error.Number
+		}
+
+		if errorNumber != item.errorNumber {
+			t.Errorf("Wrong %s subscribe error: %v", item.path, subscribeResponse.Error)
+		}
+	}
+}
+
+func TestPeerLimits(t *testing.T) {
+	const (
+		limitsRESTServerURL = "https://localhost:8451"
+		limitsGRPCServerURL = "localhost:8452"
+	)
+
+	cfg := serverConfig
+	cfg.ServerURL = "localhost:8453"
+	cfg.RESTServerURL = "localhost:8451"
+	cfg.GRPCServerURL = limitsGRPCServerURL
+	cfg.Limits = config.LimitsConfig{
+		Default:    config.ClientLimitsConfig{RequestRates: map[string]float64{"get": 2}},
+		Authorized: &config.ClientLimitsConfig{RequestRates: map[string]float64{"get": 1}, MaxSubscriptions: 1},
+	}
+
+	server, err := visserver.New(&cfg, &permissionProvider{})
+	if err != nil {
+		t.Fatalf("Can't create ws server: %s", err)
+	}
+	defer server.Close()
+
+	caPEM, err := os.ReadFile(caCert)
+	if err != nil {
+		t.Fatalf("Can't read CA cert: %s", err)
+	}
+
+	certPool := x509.NewCertPool()
+	certPool.AppendCertsFromPEM(caPEM)
+
+	restClient := &http.Client{
+		Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: certPool, MinVersion: tls.VersionTLS12}},
+		Timeout:   5 * time.Second,
+	}
+
+	// REST get rate of not authorized peer is limited
+	for i, statusCode := range []int{http.StatusOK, http.StatusOK, http.StatusTooManyRequests} {
+		response, err := restClient.Get(limitsRESTServerURL + "/Attribute/Vehicle/VehicleIdentification/VIN")
+		if err != nil {
+			t.Fatalf("Can't send request: %s", err)
+		}
+
+		response.Body.Close()
+
+		if response.StatusCode != statusCode {
+			t.Errorf("Wrong REST get request %d status: %d", i, response.StatusCode)
+		}
+	}
+
+	creds, err := credentials.NewClientTLSFromFile(caCert, "")
+	if err != nil {
+		t.Fatalf("Can't create credentials: %s", err)
+	}
+
+	connection, err := grpc.NewClient(limitsGRPCServerURL, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		t.Fatalf("Can't connect to gRPC server: %s", err)
+	}
+	defer connection.Close()
+
+	client := kuksa.NewVALClient(connection)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	authCtx := metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer appUID")
+
+	// gRPC get rate of authorized peer is limited
+	for i, code := range []codes.Code{codes.OK, codes.ResourceExhausted} {
+		_, err := client.Get(authCtx, &kuksa.GetRequest{Entries: []*kuksa.EntryRequest{
+			{Path: "Signal.Body.Trunk.IsLocked"},
+		}})
+		if status.Code(err) != code {
+			t.Errorf("Wrong gRPC get request %d error: %v", i, err)
+		}
+	}
+
+	// gRPC subscriptions of authorized peer are limited
+	stream, err := client.Subscribe(authCtx, &kuksa.SubscribeRequest{Entries: []*kuksa.SubscribeEntry{
+		{Path: "Signal.Cabin.Door.Row1.Left.IsLocked"}, {Path: "Signal.Body.Trunk.IsLocked"},
+	}})
+	if err != nil {
+		t.Fatalf("Can't subscribe: %s", err)
+	}
+
+	if _, err = stream.Recv(); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("Wrong subscriptions limit error: %v", err)
+	}
+
+	if stream, err = client.Subscribe(authCtx, &kuksa.SubscribeRequest{Entries: []*kuksa.SubscribeEntry{
+		{Path: "Signal.Cabin.Door.Row1.Left.IsLocked"},
+	}}); err != nil {
+		t.Fatalf("Can't subscribe: %s", err)
+	}
+
+	if _, err = stream.Recv(); err != nil {
+		t.Errorf("Can't receive subscription update: %s", err)
+	}
+}
+
+func TestSlowAuthorization(t *testing.T) {
+	const slowServerURL = "wss://localhost:8448"
+
+	cfg := serverConfig
+	cfg.ServerURL = "localhost:8448"
+	cfg.RESTServerURL = ""
+	cfg.GRPCServerURL = ""
+
+	provider := &slowPermissionProvider{release: make(chan struct{})}
+
+	server, err := visserver.New(&cfg, provider)
+	if err != nil {
+		t.Fatalf("Can't create ws server: %s", err)
+	}
+	defer server.Close()
+
+	time.Sleep(time.Second)
+
+	authClient, err := wsclient.New("AuthClient", wsclient.ClientParam{CaCertFile: caCert}, nil)
+	if err != nil {
+		t.Fatalf("Can't create client: %s", err)
+	}
+	defer authClient.Close()
+
+	getClient, err := wsclient.New("GetClient", wsclient.ClientParam{CaCertFile: caCert}, nil)
+	if err != nil {
+		t.Fatalf("Can't create client: %s", err)
+	}
+	defer getClient.Close()
+
+	if err = authClient.Connect(slowServerURL); err != nil {
+		t.Fatalf("Can't connect to server: %s", err)
+	}
+
+	if err = getClient.Connect(slowServerURL); err != nil {
+		t.Fatalf("Can't connect to server: %s", err)
+	}
+
+	authDone := make(chan visprotocol.AuthResponse, 1)
+
+	go func() {
+		authRequest := visprotocol.AuthRequest{
+			MessageHeader: visprotocol.MessageHeader{Action: visprotocol.ActionAuth, RequestID: "8001"},
+			Tokens:        visprotocol.Tokens{Authorization: "appUID"},
+		}
+		authResponse := visprotocol.AuthResponse{}
+
+		if err := authClient.SendRequest("RequestID", authRequest.RequestID, &authRequest, &authResponse); err != nil {
+			t.Errorf("Send request error: %s", err)
+		}
+
+		authDone <- authResponse
+	}()
+
+	// Wait for auth request is being processed
+	time.Sleep(100 * time.Millisecond)
+
+	getRequest := visprotocol.GetRequest{
+		MessageHeader: visprotocol.MessageHeader{Action: visprotocol.ActionGet, RequestID: "8002"},
+		Path:          "Attribute.Vehicle.VehicleIdentification.VIN",
+	}
+	getResponse := visprotocol.GetResponse{}
+
+	// Other clients are served while authorization is in progress
+	if err = getClient.SendRequest("RequestID", getRequest.RequestID, &getRequest, &getResponse); err != nil {
+		t.Errorf("Send request error: %s", err)
+	}
+
+	if getResponse.Error != nil {
+		t.Errorf("Get request error: %s", getResponse.Error.Message)
+	}
+
+	close(provider.release)
+
+	select {
+	case authResponse := <-authDone:
+		if authResponse.Error != nil {
+			t.Errorf("Auth request error: %s", authResponse.Error.Message)
+		}
+
+	case <-time.After(5 * time.Second):
+		t.Error("Waiting for auth response timeout")
+	}
+}
+
+func TestConcurrentClients(t *testing.T) {
+	const (
+		stressServerURL = "wss://localhost:8449"
+		numClients      = 200
+		numIterations   = 5
+	)
+
+	cfg := serverConfig
+	cfg.ServerURL = "localhost:8449"
+	cfg.RESTServerURL = ""
+	cfg.GRPCServerURL = ""
+
+	server, err := visserver.New(&cfg, &permissionProvider{})
+	if err != nil {
+		t.Fatalf("Can't create ws server: %s", err)
+	}
+	defer server.Close()
+
+	time.Sleep(time.Second)
+
+	clients := make([]*wsclient.Client, 0, numClients)
+
+	defer func() {
+		for _, client := range clients {
+			client.Close()
+		}
+	}()
+
+	// Clients are connected one by one, their requests are sent concurrently
+	for i := 0; i < numClients; i++ {
+		client, err := wsclient.New(fmt.Sprintf("StressClient%d", i), wsclient.ClientParam{CaCertFile: caCert}, nil)
+		if err != nil {
+			t.Fatalf("Can't create client: %s", err)
+		}
+
+		clients = append(clients, client)
+
+		if err = client.Connect(stressServerURL); err != nil {
+			t.Fatalf("Can't connect to server: %s", err)
+		}
+	}
+
+	var wg sync.WaitGroup
+
+	for i, client := range clients {
+		wg.Add(1)
+
+		go func(index int, client *wsclient.Client) {
+			defer wg.Done()
+
+			runStressClient(t, client, index, numIterations)
+		}(i, client)
+	}
+
+	wg.Wait()
+
+	if stats := server.GetSubscriptionStats(); len(stats) != 0 {
+		t.Errorf("Wrong subscriptions count: %d", len(stats))
+	}
+
+	for _, client := range clients {
+		client.Close()
+	}
+
+	clients = nil
+
+	for start := time.Now(); server.GetConnectedClients() != 0; time.Sleep(10 * time.Millisecond) {
+		if time.Since(start) > 5*time.Second {
+			t.Fatalf("Wrong connected clients count: %d", server.GetConnectedClients())
+		}
+	}
+}
+
+func TestREST(t *testing.T) {
+	caPEM, err := os.ReadFile(caCert)
+	if err != nil {
+		t.Fatalf("Can't read CA cert: %s", err)
+	}
+
+	certPool := x509.NewCertPool()
+	certPool.AppendCertsFromPEM(caPEM)
+
+	client := &http.Client{
+		Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: certPool, MinVersion: tls.VersionTLS12}},
+		Timeout:   5 * time.Second,
+	}
+
+	type testData struct {
+		method string
+		path   string
+		token  string
+		body   string
+		status int
+		value  interface{}
+	}
+
+	testItems := []testData{
+		{
+			method: http.MethodGet, path: "/Attribute/Vehicle/VehicleIdentification/VIN",
+			status: http.StatusOK, value: "TestVIN",
+		},
+		{method: http.MethodGet, path: "/Signal/Body/Trunk/IsLocked", status: http.StatusUnauthorized},
+		{method: http.MethodGet, path: "/Signal/Body/Flux", token: "appUID", status: http.StatusNotFound},
+		{
+			method: http.MethodPut, path: "/Signal/Body/Trunk/IsLocked", token: "appUID", body: `{"value": true}`,
+			status: http.StatusOK,
+		},
+		{
+			method: http.MethodGet, path: "/Signal/Body/Trunk/IsLocked", token: "appUID",
+			status: http.StatusOK, value: true,
+		},
+		{
+			method: http.MethodPut, path: "/Signal/Body/Trunk/IsLocked", token: "appUID", body: `{"value": "yes"}`,
+			status: http.StatusBadRequest,
+		},
+		{
+			method: http.MethodPut, path: "/Signal/Body/Trunk/IsLocked", token: "appUID", body: `{}`,
+			status: http.StatusBadRequest,
+		},
+		{method: http.MethodDelete, path: "/Signal/Body/Trunk/IsLocked", status: http.StatusMethodNotAllowed},
+	}
+
+	for _, item := range testItems {
+		request, err := http.NewRequest(item.method, restServerURL+item.path, strings.NewReader(item.body))
+		if err != nil {
+			t.Fatalf("Can't create request: %s", err)
+		}
+
+		if item.token != "" {
+			request.Header.Set("Authorization", "Bearer "+item.token)
+		}
+
+		response, err := client.Do(request)
+		if err != nil {
+			t.Fatalf("Can't send request: %s", err)
+		}
+
+		body, err := io.ReadAll(response.Body)
+		response.Body.Close()
+
+		if err != nil {
+			t.Fatalf("Can't read response: %s", err)
+		}
+
+		if response.StatusCode != item.status {
+			t.Errorf("Wrong status for %s %s: %d, body: %s", item.method, item.path, response.StatusCode, body)
+			continue
+		}
+
+		if item.value == nil {
+			continue
+		}
+
+		var getResponse visprotocol.GetResponse
+
+		if err = json.Unmarshal(body, &getResponse); err != nil {
+			t.Fatalf("Can't parse response: %s", err)
+		}
+
+		if getResponse.Value != item.value {
+			t.Errorf("Wrong value for %s: %v", item.path, getResponse.Value)
+		}
+	}
+}
+
+func TestGRPC(t *testing.T) {
+	creds, err := credentials.NewClientTLSFromFile(caCert, "")
+	if err != nil {
+		t.Fatalf("Can't create credentials: %s", err)
+	}
+
+	connection, err := grpc.NewClient(grpcServerURL, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		t.Fatalf("Can't connect to gRPC server: %s", err)
+	}
+	defer connection.Close()
+
+	client := kuksa.NewVALClient(connection)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	authCtx := metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer appUID")
+
+	serverInfo, err := client.GetServerInfo(ctx, &kuksa.GetServerInfoRequest{})
+	if err != nil {
+		t.Fatalf("Can't get server info: %s", err)
+	}
+
+	if serverInfo.GetName() != "aos_vis" {
+		t.Errorf("Wrong server name: %s", serverInfo.GetName())
+	}
+
+	// Get
+
+	getResponse, err := client.Get(ctx, &kuksa.GetRequest{Entries: []*kuksa.EntryRequest{
+		{Path: "Attribute.Vehicle.VehicleIdentification.VIN"},
+		{Path: "Signal.Cabin.Door.Row1.*.Window.Position"},
+	}})
+	if err != nil {
+		t.Fatalf("Can't get data: %s", err)
+	}
+
+	if len(getResponse.GetEntries()) != 1 ||
+		getResponse.GetEntries()[0].GetValue().GetString_() != "TestVIN" {
+		t.Errorf("Wrong get entries: %v", getResponse.GetEntries())
+	}
+
+	if len(getResponse.GetErrors()) != 1 || getResponse.GetErrors()[0].GetError().GetCode() != 401 ||
+		getResponse.GetError().GetCode() != 401 {
+		t.Errorf("Wrong get errors: %v", getResponse.GetErrors())
+	}
+
+	if getResponse, err = client.Get(authCtx, &kuksa.GetRequest{Entries: []*kuksa.EntryRequest{
+		{Path: "Signal.Cabin.Door.Row2.*.IsLocked"},
+	}}); err != nil {
+		t.Fatalf("Can't get data: %s", err)
+	}
+
+	if len(getResponse.GetEntries()) != 2 || getResponse.GetError() != nil ||
+		getResponse.GetEntries()[0].GetPath() != "Signal.Cabin.Door.Row2.Left.IsLocked" ||
+		!getResponse.GetEntries()[0].GetValue().GetBool() ||
+		getResponse.GetEntries()[1].GetValue().GetBool() ||
+		getResponse.GetEntries()[0].GetValue().GetTimestamp() == nil {
+		t.Errorf("Wrong get entries: %v", getResponse.GetEntries())
+	}
+
+	if getResponse, err = client.Get(authCtx, &kuksa.GetRequest{Entries: []*kuksa.EntryRequest{
+		{Path: "Signal.Body.Trunk.IsLocked", View: kuksa.View_VIEW_METADATA},
+	}}); err != nil {
+		t.Fatalf("Can't get data: %s", err)
+	}
+
+	if len(getResponse.GetEntries()) != 1 || getResponse.GetEntries()[0].GetValue() != nil ||
+		getResponse.GetEntries()[0].GetMetadata().GetDataType() != kuksa.DataType_DATA_TYPE_BOOLEAN {
+		t.Errorf("Wrong get entries: %v", getResponse.GetEntries())
+	}
+
+	// Subscribe
+
+	stream, err := client.Subscribe(authCtx, &kuksa.SubscribeRequest{Entries: []*kuksa.SubscribeEntry{
+		{Path: "Signal.Cabin.Door.Row1.Left.IsLocked"},
+	}})
+	if err != nil {
+		t.Fatalf("Can't subscribe: %s", err)
+	}
+
+	subscribeResponse, err := stream.Recv()
+	if err != nil {
+		t.Fatalf("Can't receive subscription update: %s", err)
+	}
+
+	if len(subscribeResponse.GetUpdates()) != 1 || !subscribeResponse.GetUpdates()[0].GetEntry().GetValue().GetBool() {
+		t.Errorf("Wrong initial subscription update: %v", subscribeResponse.GetUpdates())
+	}
+
+	// Set
+
+	setResponse, err := client.Set(authCtx, &kuksa.SetRequest{Updates: []*kuksa.EntryUpdate{
+		{
+			Entry: &kuksa.DataEntry{
+				Path: "Signal.Cabin.Door.Row1.Left.IsLocked", Value: &kuksa.Datapoint{Value: &kuksa.Datapoint_Bool{}},
+			},
+			Fields: []kuksa.Field{kuksa.Field_FIELD_VALUE},
+		},
+		{
+			Entry: &kuksa.DataEntry{
+				Path: "Signal.Body.Trunk.IsLocked", Value: &kuksa.Datapoint{Value: &kuksa.Datapoint_String_{String_: "yes"}},
+			},
+		},
+	}})
+	if err != nil {
+		t.Fatalf("Can't set data: %s", err)
+	}
+
+	if len(setResponse.GetErrors()) != 1 || setResponse.GetErrors()[0].GetPath() != "Signal.Body.Trunk.IsLocked" ||
+		setResponse.GetErrors()[0].GetError().GetCode() != 400 {
+		t.Errorf("Wrong set errors: %v", setResponse.GetErrors())
+	}
+
+	if subscribeResponse, err = stream.Recv(); err != nil {
+		t.Fatalf("Can't receive subscription update: %s", err)
+	}
+
+	if len(subscribeResponse.GetUpdates()) != 1 || subscribeResponse.GetUpdates()[0].GetEntry().GetValue().GetBool() {
+		t.Errorf("Wrong subscription update: %v", subscribeResponse.GetUpdates())
+	}
+
+	// Numbers are set as float64, so the same value of other type is not a change
+
+	const positionPath = "Signal.Cabin.Door.Row1.Right.Window.Position"
+
+	if stream, err = client.Subscribe(authCtx, &kuksa.SubscribeRequest{Entries: []*kuksa.SubscribeEntry{
+		{Path: positionPath},
+	}}); err != nil {
+		t.Fatalf("Can't subscribe: %s", err)
+	}
+
+	if _, err = stream.Recv(); err != nil {
+		t.Fatalf("Can't receive subscription update: %s", err)
+	}
+
+	for _, item := range []struct {
+		value    *kuksa.Datapoint
+		received float64
+	}{
+		{&kuksa.Datapoint{Value: &kuksa.Datapoint_Float{Float: 0.1}}, 0.1},
+		{&kuksa.Datapoint{Value: &kuksa.Datapoint_Double{Double: 30}}, 30},
+		{&kuksa.Datapoint{Value: &kuksa.Datapoint_Int32{Int32: 30}}, 0},
+		{&kuksa.Datapoint{Value: &kuksa.Datapoint_Uint64{Uint64: 30}}, 0},
+		{&kuksa.Datapoint{Value: &kuksa.Datapoint_Int64{Int64: 31}}, 31},
+	} {
+		if setResponse, err = client.Set(authCtx, &kuksa.SetRequest{Updates: []*kuksa.EntryUpdate{
+			{Entry: &kuksa.DataEntry{Path: positionPath, Value: item.value}},
+		}}); err != nil || setResponse.GetError() != nil {
+			t.Fatalf("Can't set data: %v, %v", err, setResponse.GetError())
+		}
+
+		if item.received == 0 {
+			continue
+		}
+
+		if subscribeResponse, err = stream.Recv(); err != nil {
+			t.Fatalf("Can't receive subscription update: %s", err)
+		}
+
+		if len(subscribeResponse.GetUpdates()) != 1 ||
+			subscribeResponse.GetUpdates()[0].GetEntry().GetValue().GetDouble() != item.received {
+			t.Errorf("Wrong subscription update: %v", subscribeResponse.GetUpdates())
+		}
+	}
+
+	if stream, err = client.Subscribe(ctx, &kuksa.SubscribeRequest{Entries: []*kuksa.SubscribeEntry{
+		{Path: "Signal.Cabin.Door.Row1.Left.IsLocked"},
+	}}); err != nil {
+		t.Fatalf("Can't subscribe: %s", err)
+	}
+
+	if _, err = stream.Recv(); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("Wrong not authorized subscription error: %v", err)
+	}
+}
+
+func TestGRPCTokenExpiration(t *testing.T) {
+	const tokenGRPCServerURL = "localhost:8454"
+
+	cfg := serverConfig
+	cfg.ServerURL = "localhost:8455"
+	cfg.RESTServerURL = ""
+	cfg.GRPCServerURL = tokenGRPCServerURL
+
+	server, err := visserver.New(&cfg, &identityProvider{validity: time.Second})
+	if err != nil {
+		t.Fatalf("Can't create ws server: %s", err)
+	}
+	defer server.Close()
+
+	time.Sleep(time.Second)
+
+	creds, err := credentials.NewClientTLSFromFile(caCert, "")
+	if err != nil {
+		t.Fatalf("Can't create credentials: %s", err)
+	}
+
+	connection, err := grpc.NewClient(tokenGRPCServerURL, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		t.Fatalf("Can't connect to gRPC server: %s", err)
+	}
+	defer connection.Close()
+
+	client := kuksa.NewVALClient(connection)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	authCtx := metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer appUID")
+
+	stream, err := client.Subscribe(authCtx, &kuksa.SubscribeRequest{Entries: []*kuksa.SubscribeEntry{
+		{Path: "Signal.Cabin.Door.Row1.Left.IsLocked"},
+	}})
+	if err != nil {
+		t.Fatalf("Can't subscribe: %s", err)
+	}
+
+	if _, err = stream.Recv(); err != nil {
+		t.Fatalf("Can't receive subscription update: %s", err)
+	}
+
+	// Stream is finished when token expires
+	start := time.Now()
+
+	if _, err = stream.Recv(); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("Wrong expired subscription error: %v", err)
+	}
+
+	if time.Since(start) > 2*time.Second {
+		t.Errorf("Subscription is finished too late: %s", time.Since(start))
+	}
+
+	// Already expired token is rejected
+	expiredCtx := metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+expiredToken)
+
+	if _, err = client.Get(expiredCtx, &kuksa.GetRequest{Entries: []*kuksa.EntryRequest{
+		{Path: "Signal.Cabin.Door.Row1.Left.IsLocked"},
+	}}); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("Wrong expired token error: %v", err)
+	}
+}
+
+func TestProtocolV2(t *testing.T) {
+	const v2ServerURL = "wss://localhost:8444"
+
+	type dataPoint struct {
+		Value     interface{} `json:"value"`
+		Timestamp string      `json:"ts"`
+	}
+
+	type data struct {
+		Path      string    `json:"path"`
+		DataPoint dataPoint `json:"dp"`
+	}
+
+	type responseV2 struct {
+		visprotocol.MessageHeader
+		SubscriptionID string                 `json:"subscriptionId"`
+		Error          *visprotocol.ErrorInfo `json:"error"`
+		Data           []data                 `json:"data"`
+		Timestamp      string                 `json:"ts"`
+	}
+
+	cfg := serverConfig
+	cfg.ServerURL = "localhost:8444"
+	cfg.RESTServerURL = ""
+	cfg.GRPCServerURL = ""
+	cfg.ProtocolVersion = 2
+
+	server, err := visserver.New(&cfg, &permissionProvider{})
+	if err != nil {
+		t.Fatalf("Can't create ws server: %s", err)
+	}
+	defer server.Close()
+
+	time.Sleep(time.Second)
+
+	notificationChannel := make(chan responseV2, 1)
+
+	client, err := wsclient.New("TestClient", wsclient.ClientParam{CaCertFile: caCert}, func(data []byte) {
+		var notification responseV2
+
+		if err := json.Unmarshal(data, &notification); err != nil {
+			t.Errorf("Error parsing notification: %s", err)
+		}
+
+		notificationChannel <- notification
+	})
+	if err != nil {
+		t.Fatalf("Can't create client: %s", err)
+	}
+	defer client.Close()
+
+	if err = client.Connect(v2ServerURL); err != nil {
+		t.Fatalf("Can't connect to server: %s", err)
+	}
+
+	getRequest := visprotocol.GetRequest{
+		MessageHeader: visprotocol.MessageHeader{
+			Action:    visprotocol.ActionGet,
+			RequestID: "4001",
+		},
+		Path: "Attribute.Vehicle.VehicleIdentification.VIN",
+	}
+	getResponse := responseV2{}
+
+	if err = client.SendRequest("RequestID", getRequest.RequestID, &getRequest, &getResponse); err != nil {
+		t.Fatalf("Send request error: %s", err)
+	}
+
+	if getResponse.Error != nil {
+		t.Fatalf("Get request error: %s", getResponse.Error.Message)
+	}
+
+	if len(getResponse.Data) != 1 || getResponse.Data[0].Path != getRequest.Path ||
+		getResponse.Data[0].DataPoint.Value != "TestVIN" {
+		t.Errorf("Wrong data: %v", getResponse.Data)
+	}
+
+	if _, err = time.Parse(time.RFC3339, getResponse.Data[0].DataPoint.Timestamp); err != nil {
+		t.Errorf("Wrong data point timestamp: %s", err)
+	}
+
+	// Not existing path
+
+	getRequest.Path = "Signal.Body.Flux"
+	getResponse = responseV2{}
+
+	if err = client.SendRequest("RequestID", getRequest.RequestID, &getRequest, &getResponse); err != nil {
+		t.Fatalf("Send request error: %s", err)
+	}
+
+	if getResponse.Error == nil || getResponse.Error.Number != 404 ||
+		getResponse.Error.Reason != "unavailable_data" {
+		t.Errorf("Wrong error: %v", getResponse.Error)
+	}
+
+	if _, err = time.Parse(time.RFC3339, getResponse.Timestamp); err != nil {
+		t.Errorf("Wrong response timestamp: %s", err)
+	}
+
+	// Subscription
+
+	authRequest := visprotocol.AuthRequest{
+		MessageHeader: visprotocol.MessageHeader{Action: visprotocol.ActionAuth, RequestID: "4002"},
+		Tokens:        visprotocol.Tokens{Authorization: "appUID"},
+	}
+	authResponse := visprotocol.AuthResponse{}
+
+	if err = client.SendRequest("RequestID", authRequest.RequestID, &authRequest, &authResponse); err != nil {
+		t.Fatalf("Send request error: %s", err)
+	}
+
+	subscribeRequest := visprotocol.SubscribeRequest{
+		MessageHeader: visprotocol.MessageHeader{Action: visprotocol.ActionSubscribe, RequestID: "4003"},
+		Path:          "Signal.Body.Trunk.IsOpen",
+	}
+	subscribeResponse := visprotocol.SubscribeResponse{}
+
+	if err = client.SendRequest(
+		"RequestID", subscribeRequest.RequestID, &subscribeRequest, &subscribeResponse); err != nil {
+		t.Fatalf("Send request error: %s", err)
+	}
+
+	if subscribeResponse.Error != nil {
+		t.Fatalf("Subscribe request error: %s", subscribeResponse.Error.Message)
+	}
+
+	setTime := time.Now().Truncate(time.Millisecond)
+
+	setRequest := visprotocol.SetRequest{
+		MessageHeader: visprotocol.MessageHeader{Action: visprotocol.ActionSet, RequestID: "4004"},
+		Path:          "Signal.Body.Trunk.IsOpen",
+		Value:         false,
+	}
+	setResponse := visprotocol.SetResponse{}
+
+	if err = client.SendRequest("RequestID", setRequest.RequestID, &setRequest, &setResponse); err != nil {
+		t.Fatalf("Send request error: %s", err)
+	}
+
+	if setResponse.Error != nil {
+		t.Fatalf("Set request error: %s", setResponse.Error.Message)
+	}
+
+	select {
+	case notification := <-notificationChannel:
+		if notification.SubscriptionID != subscribeResponse.SubscriptionID || len(notification.Data) != 1 ||
+			notification.Data[0].DataPoint.Value != false {
+			t.Fatalf("Wrong notification: %v", notification)
+		}
+
+		timestamp, err := time.Parse(time.RFC3339, notification.Data[0].DataPoint.Timestamp)
+		if err != nil {
+			t.Fatalf("Wrong data point timestamp: %s", err)
+		}
+
+		if timestamp.Before(setTime) {
+			t.Errorf("Data point timestamp is before set time: %v", timestamp)
+		}
+
+	case <-time.After(2 * time.Second):
+		t.Fatal("Waiting for notification timeout")
+	}
+}
+
+/*******************************************************************************
+ * Private
+ ******************************************************************************/
+
+func readAuditRecords(fileName string) (records []audit.Record, err error) {
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+
+	for decoder.More() {
+		var record audit.Record
+
+		if err = decoder.Decode(&record); err != nil {
+			return nil, aoserrors.Wrap(err)
+		}
+
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+func sendLimitsGetRequest(t *testing.T, client *wsclient.Client, requestID string) (errorNumber int) {
+	t.Helper()
+
+	getRequest := visprotocol.GetRequest{
+		MessageHeader: visprotocol.MessageHeader{Action: visprotocol.ActionGet, RequestID: requestID},
+		Path:          "Attribute.Vehicle.VehicleIdentification.VIN",
+	}
+	getResponse := visprotocol.GetResponse{}
+
+	if err := client.SendRequest("RequestID", getRequest.RequestID, &getRequest, &getResponse); err != nil {
+		t.Fatalf("Send request error: %s", err)
+	}
+
+	if getResponse.Error != nil {
+		return getResponse.Error.Number
+	}
+
+	return 0
+}
+
+// runStressClient authorizes client and sends subscribe, set, get and unsubscribe requests.
+func runStressClient(t *testing.T, client *wsclient.Client, index, numIterations int) {
+	t.Helper()
+
+	authRequest := visprotocol.AuthRequest{
+		MessageHeader: visprotocol.MessageHeader{Action: visprotocol.ActionAuth, RequestID: "auth"},
+		Tokens:        visprotocol.Tokens{Authorization: "appUID"},
+	}
+	authResponse := visprotocol.AuthResponse{}
+
+	if err := client.SendRequest("RequestID", authRequest.RequestID, &authRequest, &authResponse); err != nil ||
+		authResponse.Error != nil {
+		t.Errorf("Auth request failed: %v, %v", err, authResponse.Error)
+		return
+	}
+
+	for i := 0; i < numIterations; i++ {
+		// Notifications are coalesced as they are not read in time by stress clients
+		subscribeRequest := struct {
+			visprotocol.SubscribeRequest
+			Overflow string `json:"overflow"`
+		}{
+			SubscribeRequest: visprotocol.SubscribeRequest{
+				MessageHeader: visprotocol.MessageHeader{
+					Action: visprotocol.ActionSubscribe, RequestID: fmt.Sprintf("sub%d", i),
+				},
+				Path: "Signal.Cabin.Door.*",
+			},
+			Overflow: "coalesce",
+		}
+		subscribeResponse := visprotocol.SubscribeResponse{}
+
+		if err := client.SendRequest(
+			"RequestID", subscribeRequest.RequestID, &subscribeRequest, &subscribeResponse); err != nil ||
+			subscribeResponse.Error != nil {
+			t.Errorf("Subscribe request failed: %v, %v", err, subscribeResponse.Error)
+			return
+		}
+
+		setRequest := visprotocol.SetRequest{
+			MessageHeader: visprotocol.MessageHeader{Action: visprotocol.ActionSet, RequestID: fmt.Sprintf("set%d", i)},
+			Path:          "Signal.Cabin.Door.Row1.Right.Window.Position",
+			Value:         index,
+		}
+		setResponse := visprotocol.SetResponse{}
+
+		if err := client.SendRequest("RequestID", setRequest.RequestID, &setRequest, &setResponse); err != nil ||
+			setResponse.Error != nil {
+			t.Errorf("Set request failed: %v, %v", err, setResponse.Error)
+			return
+		}
+
+		getRequest := visprotocol.GetRequest{
+			MessageHeader: visprotocol.MessageHeader{Action: visprotocol.ActionGet, RequestID: fmt.Sprintf("get%d", i)},
+			Path:          "Signal.Cabin.Door.*",
+		}
+		getResponse := visprotocol.GetResponse{}
+
+		if err := client.SendRequest("RequestID", getRequest.RequestID, &getRequest, &getResponse); err != nil ||
+			getResponse.Error != nil {
+			t.Errorf("Get request failed: %v, %v", err, getResponse.Error)
+			return
+		}
+
+		unsubscribeRequest := visprotocol.UnsubscribeRequest{
+			MessageHeader: visprotocol.MessageHeader{
+				Action: visprotocol.ActionUnsubscribe, RequestID: fmt.Sprintf("unsub%d", i),
+			},
+			SubscriptionID: subscribeResponse.SubscriptionID,
+		}
+		unsubscribeResponse := visprotocol.UnsubscribeResponse{}
+
+		if err := client.SendRequest(
+			"RequestID", unsubscribeRequest.RequestID, &unsubscribeRequest, &unsubscribeResponse); err != nil ||
+			unsubscribeResponse.Error != nil {
+			t.Errorf("Unsubscribe request failed: %v, %v", err, unsubscribeResponse.Error)
+			return
+		}
+	}
+}
@@ -0,0 +1,141 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2021 Renesas Electronics Corporation.
+// Copyright (C) 2021 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package visserver
+
+import (
+	"sort"
+	"time"
+
+	"github.com/aosedge/aos_common/api/visprotocol"
+
+	"github.com/aosedge/aos_vis/dataprovider"
+)
+
+/*******************************************************************************
+ * Consts
+ ******************************************************************************/
+
+// Supported protocol versions.
+const (
+	protocolVersion1 = 1
+	protocolVersion2 = 2
+)
+
+const timestampFormatV2 = "2006-01-02T15:04:05.000Z07:00"
+
+/*******************************************************************************
+ * Types
+ ******************************************************************************/
+
+type dataPointV2 struct {
+	Value     interface{} `json:"value"`
+	Timestamp string      `json:"ts"`
+}
+
+type dataV2 struct {
+	Path      string      `json:"path"`
+	DataPoint dataPointV2 `json:"dp"`
+}
+
+type getResponseV2 struct {
+	visprotocol.MessageHeader
+	Error     *visprotocol.ErrorInfo `json:"error,omitempty"`
+	Data      []dataV2               `json:"data,omitempty"`
+	Timestamp string                 `json:"ts"`
+}
+
+type historyDataV2 struct {
+	Path       string        `json:"path"`
+	DataPoints []dataPointV2 `json:"dp"`
+}
+
+type getHistoryResponseV2 struct {
+	visprotocol.MessageHeader
+	Error     *visprotocol.ErrorInfo `json:"error,omitempty"`
+	Data      []historyDataV2        `json:"data,omitempty"`
+	Timestamp string                 `json:"ts"`
+}
+
+type subscriptionNotificationV2 struct {
+	Action         string                 `json:"action"`
+	SubscriptionID string                 `json:"subscriptionId"`
+	Error          *visprotocol.ErrorInfo `json:"error,omitempty"`
+	Data           []dataV2               `json:"data,omitempty"`
+	Timestamp      string                 `json:"ts"`
+}
+
+/*******************************************************************************
+ * Vars
+ ******************************************************************************/
+
+//nolint:gochecknoglobals // constant table
+var errorReasonsV2 = map[int]string{
+	400: "bad_request",
+	401: "invalid_token",
+	403: "forbidden_request",
+	404: "unavailable_data",
+	429: "too_many_requests",
+	503: "service_unavailable",
+}
+
+/*******************************************************************************
+ * Private
+ ******************************************************************************/
+
+// convertDataPointsV2 converts data points to VISS v2 data array sorted by path.
+func convertDataPointsV2(dataPoints map[string]dataprovider.DataPoint) (data []dataV2) {
+	data = make([]dataV2, 0, len(dataPoints))
+
+	for path, dataPoint := range dataPoints {
+		data = append(data, dataV2{
+			Path: path,
+			DataPoint: dataPointV2{
+				Value: dataPoint.Value, Timestamp: formatTimestampV2(dataPoint.Timestamp),
+			},
+		})
+	}
+
+	sort.Slice(data, func(i, j int) bool { return data[i].Path < data[j].Path })
+
+	return data
+}
+
+// convertHistoryV2 converts history to VISS v2 data array sorted by path.
+func convertHistoryV2(history map[string][]dataprovider.DataPoint) (data []historyDataV2) {
+	data = make([]historyDataV2, 0, len(history))
+
+	for path, dataPoints := range history {
+		historyData := historyDataV2{Path: path, DataPoints: make([]dataPointV2, 0, len(dataPoints))}
+
+		for _, dataPoint := range dataPoints {
+			historyData.DataPoints = append(historyData.DataPoints, dataPointV2{
+				Value: dataPoint.Value, Timestamp: formatTimestampV2(dataPoint.Timestamp),
+			})
+		}
+
+		data = append(data, historyData)
+	}
+
+	sort.Slice(data, func(i, j int) bool { return data[i].Path < data[j].Path })
+
+	return data
+}
+
+func formatTimestampV2(timestamp time.Time) (result string) {
+	return timestamp.UTC().Format(timestampFormatV2)
+}
@@ -18,8 +18,12 @@
 package visserver
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
 	"strconv"
 	"strings"
 	"sync"
@@ -30,9 +34,12 @@ import (
 	"github.com/aosedge/aos_common/wsserver"
 	"github.com/gorilla/websocket"
 	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
 
+	"github.com/aosedge/aos_vis/audit"
 	"github.com/aosedge/aos_vis/config"
 	"github.com/aosedge/aos_vis/dataprovider"
+	"github.com/aosedge/aos_vis/metrics"
 )
 
 /*******************************************************************************
@@ -51,6 +58,7 @@ type PermissionProvider interface {
 // VIS actions.
 const (
 	ActionGet            = "get"
+	ActionGetMetadata    = "getMetadata"
 	ActionSet            = "set"
 	ActionAuth           = "authorize"
 	ActionSubscribe      = "subscribe"
@@ -59,25 +67,64 @@ const (
 	ActionSubscription   = "subscription"
 )
 
+const defaultAuthTTL = 10000 // seconds
+
+const errorNumberOverflow = 503
+
 /*******************************************************************************
  * Types
  ******************************************************************************/
 
-// Server update manager server structure.
+// Server update manager server structure. Server lock protects clients map only, messages of each client are
+// processed under client lock.
 type Server struct {
 	sync.Mutex
-	wsServer           *wsserver.Server
-	dataProvider       *dataprovider.DataProvider
-	clients            map[*wsserver.Client]*clientInfo
-	permissionProvider PermissionProvider
+	wsServer            *wsserver.Server
+	restServer          *http.Server
+	grpcServer          *grpc.Server
+	dataProvider        *dataprovider.DataProvider
+	clients             map[*wsserver.Client]*clientInfo
+	permissionProvider  PermissionProvider
+	auditLogger         *audit.Logger
+	authTTL             time.Duration
+	protocolVersion     int
+	limits              config.LimitsConfig
+	peerLimiters        map[string]*peerLimiter
+	peerLimitersMutex   sync.Mutex
+	peerLimitersCleanup time.Time
+}
+
+type getRequest struct {
+	visprotocol.GetRequest
+	Filters json.RawMessage `json:"filters,omitempty"`
+}
+
+type historyDataPoint struct {
+	Value     interface{} `json:"value"`
+	Timestamp int64       `json:"timestamp"`
+}
+
+type subscribeRequest struct {
+	visprotocol.MessageHeader
+	Path     string          `json:"path"`
+	Filters  json.RawMessage `json:"filters,omitempty"`
+	Overflow string          `json:"overflow,omitempty"`
+	Snapshot bool            `json:"snapshot,omitempty"`
 }
 
 type clientInfo struct {
+	sync.Mutex
 	authInfo           *dataprovider.AuthInfo
-	subscribeChannels  map[uint64]<-chan interface{}
+	authTTL            time.Duration
+	authTimer          *time.Timer
+	subscriptions      map[uint64]string
+	protocolVersion    int
 	dataProvider       *dataprovider.DataProvider
 	wsClient           *wsserver.Client
 	permissionProvider PermissionProvider
+	auditLogger        *audit.Logger
+	limits             *config.LimitsConfig
+	limiter            requestLimiter
 }
 
 /*******************************************************************************
@@ -88,9 +135,30 @@ type clientInfo struct {
 func New(config *config.Config, permissionProvider PermissionProvider) (server *Server, err error) {
 	log.Debug("Create VIS server")
 
-	server = &Server{clients: make(map[*wsserver.Client]*clientInfo), permissionProvider: permissionProvider}
+	server = &Server{
+		clients:            make(map[*wsserver.Client]*clientInfo),
+		permissionProvider: permissionProvider,
+		authTTL:            getConfigAuthTTL(config),
+		protocolVersion:    config.ProtocolVersion,
+		limits:             config.Limits,
+		peerLimiters:       make(map[string]*peerLimiter),
+	}
+
+	if server.protocolVersion == 0 {
+		server.protocolVersion = protocolVersion1
+	}
+
+	if server.protocolVersion != protocolVersion1 && server.protocolVersion != protocolVersion2 {
+		return nil, aoserrors.Errorf("unsupported protocol version: %d", server.protocolVersion)
+	}
+
+	if server.auditLogger, err = audit.New(config.Audit); err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
 
 	if server.dataProvider, err = dataprovider.New(config); err != nil {
+		server.auditLogger.Close()
+
 		return nil, aoserrors.Wrap(err)
 	}
 
@@ -98,16 +166,45 @@ func New(config *config.Config, permissionProvider PermissionProvider) (server *
 		return nil, aoserrors.Wrap(err)
 	}
 
+	if config.RESTServerURL != "" {
+		if err = server.startRESTServer(config.RESTServerURL, config.VISCert, config.VISKey); err != nil {
+			server.Close()
+
+			return nil, aoserrors.Wrap(err)
+		}
+	}
+
+	if config.GRPCServerURL != "" {
+		if err = server.startGRPCServer(config.GRPCServerURL, config.VISCert, config.VISKey); err != nil {
+			server.Close()
+
+			return nil, aoserrors.Wrap(err)
+		}
+	}
+
 	return server, nil
 }
 
 // Close closes web socket server and all connections.
 func (server *Server) Close() {
+	// REST server is closed first as active REST requests use data provider
+	if server.restServer != nil {
+		if err := server.restServer.Shutdown(context.Background()); err != nil {
+			log.Errorf("Can't shutdown REST server: %s", err)
+		}
+	}
+
+	// gRPC server is stopped first as its active subscriptions are unsubscribed on stop
+	if server.grpcServer != nil {
+		server.grpcServer.Stop()
+	}
+
 	server.Lock()
 	defer server.Unlock()
 
 	server.wsServer.Close()
 	server.dataProvider.Close()
+	server.auditLogger.Close()
 }
 
 // ClientConnected connect client notification.
@@ -117,10 +214,14 @@ func (server *Server) ClientConnected(client *wsserver.Client) {
 	log.Info("ClientConnected")
 
 	server.clients[client] = &clientInfo{
-		authInfo:          &dataprovider.AuthInfo{},
-		subscribeChannels: make(map[uint64]<-chan interface{}),
-		dataProvider:      server.dataProvider,
-		wsClient:          client,
+		authInfo:        &dataprovider.AuthInfo{},
+		authTTL:         server.authTTL,
+		subscriptions:   make(map[uint64]string),
+		protocolVersion: server.protocolVersion,
+		dataProvider:    server.dataProvider,
+		wsClient:        client,
+		auditLogger:     server.auditLogger,
+		limits:          &server.limits,
 	}
 
 	log.Info("GetPermissionProvider")
@@ -131,66 +232,107 @@ func (server *Server) ClientConnected(client *wsserver.Client) {
 // ClientDisconnected disconnect client notification.
 func (server *Server) ClientDisconnected(wsClient *wsserver.Client) {
 	server.Lock()
-	defer server.Unlock()
 
 	client, ok := server.clients[wsClient]
 	if !ok {
+		server.Unlock()
+
 		log.Error("Disconnect unknown client")
+
 		return
 	}
 
+	delete(server.clients, wsClient)
+
+	server.Unlock()
+
+	client.Lock()
+	defer client.Unlock()
+
+	if client.authTimer != nil {
+		client.authTimer.Stop()
+		client.authTimer = nil
+	}
+
 	if err := client.unsubscribeAll(); err != nil {
 		log.Errorf("Can't unsubscribe on client disconnect: %v", err)
 	}
-
-	delete(server.clients, wsClient)
 }
 
 // ProcessMessage processes incoming messages.
 func (server *Server) ProcessMessage(
 	wsClient *wsserver.Client, messageType int, message []byte,
 ) (response []byte, err error) {
-	server.Lock()
-	defer server.Unlock()
+	var errorInfo *visprotocol.ErrorInfo
+
+	start := time.Now()
+	action := ""
+
+	defer func() {
+		metrics.ObserveRequest(metrics.ProtocolWS, action, start, getErrorNumber(errorInfo, err))
+	}()
 
 	if messageType != websocket.TextMessage {
 		return nil, aoserrors.New("incoming message in unsupported format")
 	}
 
-	client, ok := server.clients[wsClient]
+	client, ok := server.getClient(wsClient)
 	if !ok {
 		return nil, aoserrors.New("message from unknown client")
 	}
 
+	// Messages of different clients are processed concurrently
+	client.Lock()
+	defer client.Unlock()
+
 	var header visprotocol.MessageHeader
 
 	if err = json.Unmarshal(message, &header); err != nil {
 		return nil, aoserrors.Wrap(err)
 	}
 
+	action = header.Action
+
 	var responseItf interface{}
 
+	switch header.Action {
+	case ActionGet, ActionGetMetadata, ActionSet, ActionAuth, ActionSubscribe, ActionUnsubscribe, ActionUnsubscribeAll:
+		if err = client.checkRequestRate(header.Action, start); err != nil {
+			log.WithField("remoteAddr", wsClient.RemoteAddr).Warn(err)
+
+			errorInfo = createErrorInfo(err, client.protocolVersion)
+
+			return createLimitErrorResponse(header, errorInfo)
+		}
+
+	default:
+		// Unsupported actions are not distinguished in metrics to limit labels
+		action = "unsupported"
+
+		return nil, aoserrors.Errorf("unsupported action type: %s", header.Action)
+	}
+
 	switch header.Action {
 	case ActionGet:
-		responseItf, err = client.processGetRequest(message)
+		responseItf, errorInfo, err = client.processGetRequest(message)
+
+	case ActionGetMetadata:
+		responseItf, errorInfo, err = client.processGetMetadataRequest(message)
 
 	case ActionSet:
-		responseItf, err = client.processSetRequest(message)
+		responseItf, errorInfo, err = client.processSetRequest(message)
 
 	case ActionAuth:
-		responseItf, err = client.processAuthRequest(message)
+		responseItf, errorInfo, err = client.processAuthRequest(message)
 
 	case ActionSubscribe:
-		responseItf, err = client.processSubscribeRequest(message)
+		responseItf, errorInfo, err = client.processSubscribeRequest(message)
 
 	case ActionUnsubscribe:
-		responseItf, err = client.processUnsubscribeRequest(message)
+		responseItf, errorInfo, err = client.processUnsubscribeRequest(message)
 
 	case ActionUnsubscribeAll:
-		responseItf, err = client.processUnsubscribeAllRequest(message)
-
-	default:
-		err = aoserrors.Errorf("unsupported action type: %s", header.Action)
+		responseItf, errorInfo, err = client.processUnsubscribeAllRequest(message)
 	}
 
 	if err != nil {
@@ -204,6 +346,61 @@ func (server *Server) ProcessMessage(
 	return response, nil
 }
 
+// StartRecording starts recording of adapter changes.
+func (server *Server) StartRecording() (err error) {
+	return aoserrors.Wrap(server.dataProvider.StartRecording())
+}
+
+// StopRecording stops recording of adapter changes.
+func (server *Server) StopRecording() (err error) {
+	return aoserrors.Wrap(server.dataProvider.StopRecording())
+}
+
+// Reload reloads data adapters. Clients are notified about subscribed paths which are not provided anymore.
+func (server *Server) Reload(config *config.Config) (err error) {
+	if getConfigAuthTTL(config) != server.authTTL {
+		log.WithField("parameter", "AuthTTL").Warn("Config parameter change is ignored on reload")
+	}
+
+	if !reflect.DeepEqual(config.Limits, server.limits) {
+		log.WithField("parameter", "Limits").Warn("Config parameter change is ignored on reload")
+	}
+
+	// Data provider is reloaded before lock as closing adapters could wait for subscribers
+	removed, err := server.dataProvider.Reload(config)
+
+	server.Lock()
+
+	clients := make([]*clientInfo, 0, len(server.clients))
+
+	for _, client := range server.clients {
+		clients = append(clients, client)
+	}
+
+	server.Unlock()
+
+	for _, client := range clients {
+		client.Lock()
+		client.pathsRemoved(removed)
+		client.Unlock()
+	}
+
+	return aoserrors.Wrap(err)
+}
+
+// GetConnectedClients returns number of connected websocket clients.
+func (server *Server) GetConnectedClients() (count int) {
+	server.Lock()
+	defer server.Unlock()
+
+	return len(server.clients)
+}
+
+// GetSubscriptionStats returns notification counters of active subscriptions.
+func (server *Server) GetSubscriptionStats() (stats []metrics.SubscriptionStats) {
+	return server.dataProvider.GetSubscriptionStats()
+}
+
 // GetPermissionProvider returns permission provider interface.
 func (server *Server) GetPermissionProvider() (permissionProvider PermissionProvider) {
 	return server.permissionProvider
@@ -213,36 +410,158 @@ func (server *Server) GetPermissionProvider() (permissionProvider PermissionProv
  * Private
  ******************************************************************************/
 
+func (server *Server) getClient(wsClient *wsserver.Client) (client *clientInfo, ok bool) {
+	server.Lock()
+	defer server.Unlock()
+
+	client, ok = server.clients[wsClient]
+
+	return client, ok
+}
+
 // process Get request.
-func (client *clientInfo) processGetRequest(requestJSON []byte) (response *visprotocol.GetResponse, err error) {
-	var request visprotocol.GetRequest
+func (client *clientInfo) processGetRequest(
+	requestJSON []byte,
+) (responseItf interface{}, errorInfo *visprotocol.ErrorInfo, err error) {
+	var request getRequest
 
 	if err = json.Unmarshal(requestJSON, &request); err != nil {
-		return nil, aoserrors.Wrap(err)
+		return nil, nil, aoserrors.Wrap(err)
 	}
 
-	response = &visprotocol.GetResponse{
+	history, window, err := parseGetFilter(request.Filters)
+	if err != nil || history {
+		responseItf, errorInfo = client.processGetHistoryRequest(request.GetRequest, window, err)
+
+		return responseItf, errorInfo, nil
+	}
+
+	if client.protocolVersion == protocolVersion2 {
+		responseItf, errorInfo = client.processGetRequestV2(request.GetRequest)
+
+		return responseItf, errorInfo, nil
+	}
+
+	response := &visprotocol.GetResponse{
 		MessageHeader: request.MessageHeader,
 		Timestamp:     getCurTime(),
 	}
 
 	vehicleData, err := client.dataProvider.GetData(request.Path, client.authInfo)
 	if err != nil {
-		response.Error = createErrorInfo(err)
-		return response, nil
+		response.Error = createErrorInfo(err, client.protocolVersion)
+		return response, response.Error, nil
 	}
 
 	response.Value = vehicleData
 
-	return response, nil
+	return response, nil, nil
+}
+
+// process Get request in VISS v2 format.
+func (client *clientInfo) processGetRequestV2(
+	request visprotocol.GetRequest,
+) (response *getResponseV2, errorInfo *visprotocol.ErrorInfo) {
+	response = &getResponseV2{MessageHeader: request.MessageHeader}
+
+	dataPoints, err := client.dataProvider.GetDataPoints(request.Path, client.authInfo)
+	if err != nil {
+		response.Error = createErrorInfo(err, client.protocolVersion)
+	} else {
+		response.Data = convertDataPointsV2(dataPoints)
+	}
+
+	response.Timestamp = formatTimestampV2(time.Now())
+
+	return response, response.Error
+}
+
+// process Get request with history filter. Value contains data points of each path in chronological order.
+func (client *clientInfo) processGetHistoryRequest(
+	request visprotocol.GetRequest, window time.Duration, filterErr error,
+) (responseItf interface{}, errorInfo *visprotocol.ErrorInfo) {
+	var dataPoints map[string][]dataprovider.DataPoint
+
+	err := filterErr
+	if err == nil {
+		dataPoints, err = client.dataProvider.GetHistory(request.Path, window, client.authInfo)
+	}
+
+	if client.protocolVersion == protocolVersion2 {
+		response := &getHistoryResponseV2{MessageHeader: request.MessageHeader}
+
+		if err != nil {
+			response.Error = createErrorInfo(err, client.protocolVersion)
+		} else {
+			response.Data = convertHistoryV2(dataPoints)
+		}
+
+		response.Timestamp = formatTimestampV2(time.Now())
+
+		return response, response.Error
+	}
+
+	response := &visprotocol.GetResponse{
+		MessageHeader: request.MessageHeader,
+		Timestamp:     getCurTime(),
+	}
+
+	if err != nil {
+		response.Error = createErrorInfo(err, client.protocolVersion)
+		return response, response.Error
+	}
+
+	value := make(map[string][]historyDataPoint)
+
+	for path, pathDataPoints := range dataPoints {
+		value[path] = make([]historyDataPoint, 0, len(pathDataPoints))
+
+		for _, dataPoint := range pathDataPoints {
+			value[path] = append(value[path], historyDataPoint{
+				Value: dataPoint.Value, Timestamp: dataPoint.Timestamp.UnixNano() / 1000000, //nolint:gomnd
+			})
+		}
+	}
+
+	response.Value = value
+
+	return response, response.Error
+}
+
+// process GetMetadata request.
+func (client *clientInfo) processGetMetadataRequest(
+	requestJSON []byte,
+) (response *visprotocol.MetadataResponse, errorInfo *visprotocol.ErrorInfo, err error) {
+	var request visprotocol.MetadataRequest
+
+	if err = json.Unmarshal(requestJSON, &request); err != nil {
+		return nil, nil, aoserrors.Wrap(err)
+	}
+
+	response = &visprotocol.MetadataResponse{
+		MessageHeader: request.MessageHeader,
+		Timestamp:     getCurTime(),
+	}
+
+	metadata, err := client.dataProvider.GetMetadata(request.Path, client.authInfo)
+	if err != nil {
+		response.Error = createErrorInfo(err, client.protocolVersion)
+		return response, response.Error, nil
+	}
+
+	response.Metadata = metadata
+
+	return response, nil, nil
 }
 
 // process Set request.
-func (client *clientInfo) processSetRequest(requestJSON []byte) (response *visprotocol.SetResponse, err error) {
+func (client *clientInfo) processSetRequest(
+	requestJSON []byte,
+) (response *visprotocol.SetResponse, errorInfo *visprotocol.ErrorInfo, err error) {
 	var request visprotocol.SetRequest
 
 	if err = json.Unmarshal(requestJSON, &request); err != nil {
-		return nil, aoserrors.Wrap(err)
+		return nil, nil, aoserrors.Wrap(err)
 	}
 
 	response = &visprotocol.SetResponse{
@@ -250,20 +569,23 @@ func (client *clientInfo) processSetRequest(requestJSON []byte) (response *vispr
 		Timestamp:     getCurTime(),
 	}
 
-	if err = client.dataProvider.SetData(request.Path, request.Value, client.authInfo); err != nil {
-		response.Error = createErrorInfo(err)
-		return response, nil
+	if err = setDataWithAudit(client.dataProvider, client.auditLogger, metrics.ProtocolWS,
+		client.wsClient.RemoteAddr, client.authInfo, request.Path, request.Value); err != nil {
+		response.Error = createErrorInfo(err, client.protocolVersion)
+		return response, response.Error, nil
 	}
 
-	return response, nil
+	return response, nil, nil
 }
 
 // process Auth request.
-func (client *clientInfo) processAuthRequest(requestJSON []byte) (response *visprotocol.AuthResponse, err error) {
+func (client *clientInfo) processAuthRequest(
+	requestJSON []byte,
+) (response *visprotocol.AuthResponse, errorInfo *visprotocol.ErrorInfo, err error) {
 	var request visprotocol.AuthRequest
 
 	if err = json.Unmarshal(requestJSON, &request); err != nil {
-		return nil, aoserrors.Wrap(err)
+		return nil, nil, aoserrors.Wrap(err)
 	}
 
 	response = &visprotocol.AuthResponse{
@@ -271,35 +593,82 @@ func (client *clientInfo) processAuthRequest(requestJSON []byte) (response *visp
 	}
 
 	if request.Tokens.Authorization == "" {
-		response.Error = createErrorInfo(aoserrors.New("empty token authorization"))
-		return response, nil
+		err = aoserrors.New("empty token authorization")
+
+		auditAuthorize(client.auditLogger, metrics.ProtocolWS, client.wsClient.RemoteAddr, nil, err)
+
+		response.Error = createErrorInfo(err, client.protocolVersion)
+
+		return response, response.Error, nil
 	}
 
-	if client.authInfo.Permissions,
-		err = client.permissionProvider.GetVisPermissionByToken(request.Tokens.Authorization); err != nil {
+	// Client lock is released during token check as permission provider request could be slow. Messages of the client
+	// are processed sequentially, so the client is not disconnected meanwhile.
+	client.Unlock()
+	permissions, identity, expiresAt, err := authorizeByToken(client.permissionProvider, request.Tokens.Authorization)
+	client.Lock()
+
+	authTTL := getAuthTTL(client.authTTL, expiresAt)
+
+	if err == nil && authTTL <= 0 {
+		err = aoserrors.New("token is expired")
+	}
+
+	var authInfo *dataprovider.AuthInfo
+
+	if err == nil {
+		authInfo, err = dataprovider.NewAuthInfo(permissions, identity)
+	}
+
+	if err != nil {
 		log.Error("err: ", err)
 
-		response.Error = createErrorInfo(aoserrors.New("service not authorized"))
+		err = aoserrors.New("service not authorized")
+
+		auditAuthorize(client.auditLogger, metrics.ProtocolWS, client.wsClient.RemoteAddr, nil, err)
 
-		return response, nil
+		response.Error = createErrorInfo(err, client.protocolVersion)
+
+		return response, response.Error, nil
 	}
 
-	client.authInfo.IsAuthorized = true
-	response.TTL = 10000
+	auditAuthorize(client.auditLogger, metrics.ProtocolWS, client.wsClient.RemoteAddr, identity, nil)
 
-	return response, nil
+	if client.authTimer != nil {
+		client.authTimer.Stop()
+	}
+
+	client.authInfo = authInfo
+
+	var authTimer *time.Timer
+
+	authTimer = time.AfterFunc(authTTL, func() {
+		client.Lock()
+		defer client.Unlock()
+
+		// Client was reauthorized or disconnected meanwhile
+		if client.authTimer != authTimer {
+			return
+		}
+
+		client.authExpired()
+	})
+
+	client.authTimer = authTimer
+
+	response.TTL = int64(authTTL.Round(time.Second) / time.Second)
+
+	return response, nil, nil
 }
 
 // process Subscribe request.
-func (client *clientInfo) processSubscribeRequest(requestJSON []byte) (responseItf interface{}, err error) {
-	var request visprotocol.SubscribeRequest
+func (client *clientInfo) processSubscribeRequest(
+	requestJSON []byte,
+) (responseItf interface{}, errorInfo *visprotocol.ErrorInfo, err error) {
+	var request subscribeRequest
 
 	if err = json.Unmarshal(requestJSON, &request); err != nil {
-		return nil, aoserrors.Wrap(err)
-	}
-
-	if request.Filters != "" {
-		log.Warn("Filter currently not implemented. Filters will be ignored")
+		return nil, nil, aoserrors.Wrap(err)
 	}
 
 	response := visprotocol.SubscribeResponse{
@@ -307,28 +676,44 @@ func (client *clientInfo) processSubscribeRequest(requestJSON []byte) (responseI
 		Timestamp:     getCurTime(),
 	}
 
-	id, channel, err := client.dataProvider.Subscribe(request.Path, client.authInfo)
+	if err = client.checkSubscriptionsLimit(); err != nil {
+		response.Error = createErrorInfo(err, client.protocolVersion)
+		return &response, response.Error, nil
+	}
+
+	filter, err := parseFilter(request.Filters)
+	if err != nil {
+		response.Error = createErrorInfo(err, client.protocolVersion)
+		return &response, response.Error, nil
+	}
+
+	subscription, err := client.dataProvider.SubscribeWithOptions(request.Path, client.authInfo,
+		dataprovider.SubscribeOptions{
+			Overflow: request.Overflow, MaxPaths: client.getLimits().MaxSubscriptionPaths, Snapshot: request.Snapshot,
+		})
 	if err != nil {
-		response.Error = createErrorInfo(err)
-		return &response, nil
+		response.Error = createErrorInfo(err, client.protocolVersion)
+		return &response, response.Error, nil
 	}
 
-	log.WithFields(log.Fields{"path": request.Path, "id": id}).Debug("Register subscription")
+	log.WithFields(log.Fields{"path": request.Path, "id": subscription.ID}).Debug("Register subscription")
 
-	response.SubscriptionID = strconv.FormatUint(id, 10)
+	response.SubscriptionID = strconv.FormatUint(subscription.ID, 10)
 
-	client.subscribeChannels[id] = channel
-	go client.processSubscribeChannel(id, channel)
+	client.subscriptions[subscription.ID] = request.Path
+	go client.processSubscribeChannel(subscription, request.Path, filter)
 
-	return &response, nil
+	return &response, nil, nil
 }
 
 // process Unsubscribe request.
-func (client *clientInfo) processUnsubscribeRequest(requestJSON []byte) (responseItf interface{}, err error) {
+func (client *clientInfo) processUnsubscribeRequest(
+	requestJSON []byte,
+) (responseItf interface{}, errorInfo *visprotocol.ErrorInfo, err error) {
 	var request visprotocol.UnsubscribeRequest
 
 	if err = json.Unmarshal(requestJSON, &request); err != nil {
-		return nil, aoserrors.Wrap(err)
+		return nil, nil, aoserrors.Wrap(err)
 	}
 
 	response := visprotocol.UnsubscribeResponse{
@@ -339,28 +724,30 @@ func (client *clientInfo) processUnsubscribeRequest(requestJSON []byte) (respons
 
 	subscribeID, err := strconv.ParseUint(request.SubscriptionID, 10, 64)
 	if err != nil {
-		response.Error = createErrorInfo(err)
-		return &response, nil
+		response.Error = createErrorInfo(err, client.protocolVersion)
+		return &response, response.Error, nil
 	}
 
 	if err = client.dataProvider.Unsubscribe(subscribeID, client.authInfo); err != nil {
-		response.Error = createErrorInfo(err)
-		return &response, nil
+		response.Error = createErrorInfo(err, client.protocolVersion)
+		return &response, response.Error, nil
 	}
 
-	delete(client.subscribeChannels, subscribeID)
+	delete(client.subscriptions, subscribeID)
 
 	log.WithFields(log.Fields{"id": request.SubscriptionID}).Debug("Unregister subscription")
 
-	return &response, nil
+	return &response, nil, nil
 }
 
 // process UnsubscribeAll request.
-func (client *clientInfo) processUnsubscribeAllRequest(requestJSON []byte) (responseItf interface{}, err error) {
+func (client *clientInfo) processUnsubscribeAllRequest(
+	requestJSON []byte,
+) (responseItf interface{}, errorInfo *visprotocol.ErrorInfo, err error) {
 	var request visprotocol.UnsubscribeAllRequest
 
 	if err = json.Unmarshal(requestJSON, &request); err != nil {
-		return nil, aoserrors.Wrap(err)
+		return nil, nil, aoserrors.Wrap(err)
 	}
 
 	response := visprotocol.UnsubscribeAllResponse{
@@ -369,60 +756,293 @@ func (client *clientInfo) processUnsubscribeAllRequest(requestJSON []byte) (resp
 	}
 
 	if err = client.unsubscribeAll(); err != nil {
-		response.Error = createErrorInfo(err)
-		return &response, nil
+		response.Error = createErrorInfo(err, client.protocolVersion)
+		return &response, response.Error, nil
 	}
 
-	return &response, nil
+	return &response, nil, nil
 }
 
-func (client *clientInfo) processSubscribeChannel(id uint64, channel <-chan interface{}) {
+func (client *clientInfo) processSubscribeChannel(
+	subscription *dataprovider.Subscription, path string, filter *subscribeFilter,
+) {
+	var (
+		pendingValues map[string]dataprovider.DataPoint
+		intervalTimer *time.Timer
+		timerChannel  <-chan time.Time
+		id            = subscription.ID
+	)
+
+	defer func() {
+		if intervalTimer != nil {
+			intervalTimer.Stop()
+		}
+	}()
+
 	for {
-		data, more := <-channel
-		if more {
-			subscriptionID := strconv.FormatUint(id, 10)
-
-			notification := visprotocol.SubscriptionNotification{
-				Action:         ActionSubscription,
-				SubscriptionID: subscriptionID,
-				Value:          data,
-				Timestamp:      getCurTime(),
-			}
+		select {
+		case notification, more := <-subscription.Channel:
+			if !more {
+				log.WithField("subscribeID", id).Debug("Subscription closed")
+
+				client.sendPendingValues(subscription, path, pendingValues)
 
-			notificationJSON, err := json.Marshal(notification)
-			if err != nil {
-				log.Errorf("Can't marshal subscription notification: %s", err)
+				if subscription.Overflowed() {
+					client.disconnectOverflowed(id)
+				}
+
+				return
 			}
 
-			if notificationJSON != nil {
-				if err := client.wsClient.SendMessage(websocket.TextMessage, notificationJSON); err != nil {
-					if errors.Is(err, websocket.ErrCloseSent) {
-						return
-					}
+			if lost := subscription.TakeLost(); lost != 0 {
+				client.sendErrorNotification(id, createOverflowErrorInfo(
+					fmt.Sprintf("%d notifications are lost due to subscription queue overflow", lost),
+					client.protocolVersion))
+			}
 
-					log.Errorf("Can't send message: %s", err)
+			if filter == nil {
+				if !client.sendNotification(subscription, path, notification) {
+					return
 				}
+
+				continue
 			}
-		} else {
-			log.WithField("subscribeID", id).Debug("Subscription closed")
+
+			values := filter.filterValues(notification.Data)
+			if len(values) == 0 {
+				continue
+			}
+
+			if pendingValues == nil {
+				pendingValues = make(map[string]dataprovider.DataPoint)
+			}
+
+			for valuePath, value := range values {
+				pendingValues[valuePath] = value
+			}
+
+			if timerChannel != nil {
+				continue
+			}
+
+			if wait := filter.waitInterval(time.Now()); wait > 0 {
+				intervalTimer = time.NewTimer(wait)
+				timerChannel = intervalTimer.C
+
+				continue
+			}
+
+		case <-timerChannel:
+			timerChannel = nil
+		}
+
+		filter.lastSent = time.Now()
+
+		if !client.sendNotification(subscription, path, &dataprovider.Notification{Data: pendingValues}) {
 			return
 		}
+
+		pendingValues = nil
 	}
 }
 
+// sendPendingValues sends values held back by interval filter when subscription is closed. Values are dropped if
+// subscription is terminated as path is not accessible anymore on authorization expiration.
+func (client *clientInfo) sendPendingValues(
+	subscription *dataprovider.Subscription, path string, pendingValues map[string]dataprovider.DataPoint,
+) {
+	if len(pendingValues) == 0 {
+		return
+	}
+
+	client.Lock()
+	authInfo := client.authInfo
+	client.Unlock()
+
+	if err := client.dataProvider.CheckPermissions(path, authInfo, "r"); err != nil {
+		log.WithField("subscribeID", subscription.ID).Debug("Pending values are dropped")
+
+		return
+	}
+
+	client.sendNotification(subscription, path, &dataprovider.Notification{Data: pendingValues})
+}
+
+// disconnectOverflowed closes connection of client which doesn't read notifications in time.
+func (client *clientInfo) disconnectOverflowed(id uint64) {
+	log.WithFields(log.Fields{
+		"remoteAddr": client.wsClient.RemoteAddr, "subscribeID": id,
+	}).Warn("Disconnect slow client")
+
+	client.sendErrorNotification(id, createOverflowErrorInfo(
+		"subscription queue overflow, client is disconnected", client.protocolVersion))
+
+	client.Lock()
+	delete(client.subscriptions, id)
+	client.Unlock()
+
+	if err := client.wsClient.SendMessage(websocket.CloseMessage, websocket.FormatCloseMessage(
+		websocket.CloseTryAgainLater, "subscription queue overflow")); err != nil {
+		log.Errorf("Can't send close message: %s", err)
+	}
+}
+
+// getAuthTTL returns authorization TTL limited by token expiration time if provider returns it.
+func getAuthTTL(authTTL time.Duration, expiresAt time.Time) (ttl time.Duration) {
+	ttl = authTTL
+
+	if expiresAt.IsZero() {
+		return ttl
+	}
+
+	if tokenTTL := time.Until(expiresAt); tokenTTL < ttl {
+		ttl = tokenTTL
+	}
+
+	return ttl
+}
+
+func (client *clientInfo) authExpired() {
+	log.WithField("remoteAddr", client.wsClient.RemoteAddr).Debug("Authorization expired")
+
+	client.authTimer = nil
+	client.authInfo = &dataprovider.AuthInfo{}
+
+	// Subscriptions which are not accessible without authorization are terminated
+	for id, path := range client.subscriptions {
+		if err := client.dataProvider.CheckPermissions(path, client.authInfo, "r"); err == nil {
+			continue
+		}
+
+		errorInfo := &visprotocol.ErrorInfo{
+			Number: 401, Reason: "token_expired", Message: "the access token has expired",
+		}
+
+		if client.protocolVersion == protocolVersion2 {
+			errorInfo.Reason = "expired_token"
+		}
+
+		client.sendErrorNotification(id, errorInfo)
+
+		if err := client.dataProvider.Unsubscribe(id, client.authInfo); err != nil {
+			log.Errorf("Can't unsubscribe on authorization expiration: %s", err)
+		}
+
+		delete(client.subscriptions, id)
+	}
+}
+
+func (client *clientInfo) pathsRemoved(removed map[uint64]*dataprovider.RemovedPaths) {
+	for id := range client.subscriptions {
+		removedPaths, ok := removed[id]
+		if !ok {
+			continue
+		}
+
+		log.WithFields(log.Fields{
+			"remoteAddr": client.wsClient.RemoteAddr, "subscribeID": id, "paths": removedPaths.Paths,
+		}).Debug("Subscribed paths removed")
+
+		client.sendErrorNotification(id, createErrorInfo(
+			aoserrors.Errorf("paths %s not found", strings.Join(removedPaths.Paths, ", ")), client.protocolVersion))
+
+		// Subscription without paths is terminated
+		if !removedPaths.Terminated {
+			continue
+		}
+
+		if err := client.dataProvider.Unsubscribe(id, client.authInfo); err != nil {
+			log.Errorf("Can't unsubscribe removed paths: %s", err)
+		}
+
+		delete(client.subscriptions, id)
+	}
+}
+
+func (client *clientInfo) sendErrorNotification(id uint64, errorInfo *visprotocol.ErrorInfo) {
+	var notification interface{} = visprotocol.SubscriptionNotification{
+		Action:         ActionSubscription,
+		SubscriptionID: strconv.FormatUint(id, 10),
+		Error:          errorInfo,
+		Timestamp:      getCurTime(),
+	}
+
+	if client.protocolVersion == protocolVersion2 {
+		notification = subscriptionNotificationV2{
+			Action:         ActionSubscription,
+			SubscriptionID: strconv.FormatUint(id, 10),
+			Error:          errorInfo,
+			Timestamp:      formatTimestampV2(time.Now()),
+		}
+	}
+
+	notificationJSON, err := json.Marshal(notification)
+	if err != nil {
+		log.Errorf("Can't marshal subscription notification: %s", err)
+		return
+	}
+
+	if err = client.wsClient.SendMessage(websocket.TextMessage, notificationJSON); err != nil {
+		log.Errorf("Can't send message: %s", err)
+	}
+}
+
+func (client *clientInfo) sendNotification(
+	subscription *dataprovider.Subscription, path string, notification *dataprovider.Notification,
+) (ok bool) {
+	id := subscription.ID
+
+	var message interface{} = visprotocol.SubscriptionNotification{
+		Action:         ActionSubscription,
+		SubscriptionID: strconv.FormatUint(id, 10),
+		Value:          notification.GetValue(path),
+		Timestamp:      getCurTime(),
+	}
+
+	if client.protocolVersion == protocolVersion2 {
+		message = subscriptionNotificationV2{
+			Action:         ActionSubscription,
+			SubscriptionID: strconv.FormatUint(id, 10),
+			Data:           convertDataPointsV2(notification.Data),
+			Timestamp:      formatTimestampV2(time.Now()),
+		}
+	}
+
+	notificationJSON, err := json.Marshal(message)
+	if err != nil {
+		log.Errorf("Can't marshal subscription notification: %s", err)
+
+		return true
+	}
+
+	if err := client.wsClient.SendMessage(websocket.TextMessage, notificationJSON); err != nil {
+		if errors.Is(err, websocket.ErrCloseSent) {
+			return false
+		}
+
+		log.Errorf("Can't send message: %s", err)
+
+		return true
+	}
+
+	subscription.NotificationSent()
+
+	return true
+}
+
 func (client *clientInfo) unsubscribeAll() (err error) {
-	for subscribeID := range client.subscribeChannels {
+	for subscribeID := range client.subscriptions {
 		if localErr := client.dataProvider.Unsubscribe(subscribeID, client.authInfo); localErr != nil {
 			err = localErr
 		}
 	}
 
-	client.subscribeChannels = make(map[uint64]<-chan interface{})
+	client.subscriptions = make(map[uint64]string)
 
 	return aoserrors.Wrap(err)
 }
 
-func createErrorInfo(err error) (errorInfo *visprotocol.ErrorInfo) {
+func createErrorInfo(err error, protocolVersion int) (errorInfo *visprotocol.ErrorInfo) {
 	if err == nil {
 		return nil
 	}
@@ -437,13 +1057,51 @@ func createErrorInfo(err error) (errorInfo *visprotocol.ErrorInfo) {
 		errorInfo.Number = 401
 	case strings.Contains(strings.ToLower(err.Error()), "not have permissions"):
 		errorInfo.Number = 403
+	case strings.Contains(strings.ToLower(err.Error()), "limit exceeded"):
+		errorInfo.Number = errorNumberTooManyRequests
 	default:
 		errorInfo.Number = 400
 	}
 
+	if protocolVersion == protocolVersion2 {
+		errorInfo.Reason = errorReasonsV2[errorInfo.Number]
+	}
+
 	return errorInfo
 }
 
+// getErrorNumber returns error number of response for request metrics.
+func getErrorNumber(errorInfo *visprotocol.ErrorInfo, err error) (errorNumber int) {
+	if err != nil {
+		return http.StatusBadRequest
+	}
+
+	if errorInfo == nil {
+		return 0
+	}
+
+	return errorInfo.Number
+}
+
+func createOverflowErrorInfo(message string, protocolVersion int) (errorInfo *visprotocol.ErrorInfo) {
+	errorInfo = &visprotocol.ErrorInfo{Number: errorNumberOverflow, Reason: "queue_overflow", Message: message}
+
+	if protocolVersion == protocolVersion2 {
+		errorInfo.Reason = errorReasonsV2[errorInfo.Number]
+	}
+
+	return errorInfo
+}
+
+// getConfigAuthTTL returns authorization TTL of config or default one if it is not set.
+func getConfigAuthTTL(config *config.Config) (authTTL time.Duration) {
+	if config.AuthTTL <= 0 {
+		return defaultAuthTTL * time.Second
+	}
+
+	return time.Duration(config.AuthTTL) * time.Second
+}
+
 func getCurTime() int64 {
 	return time.Now().UnixNano() / 1000000 //nolint:gomnd
 }